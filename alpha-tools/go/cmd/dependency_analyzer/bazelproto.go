@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers from Bazel's src/main/protobuf/build.proto, which --output=proto encodes. Only the fields
+// the analyzer reads are decoded, so the generated code for build.proto is not needed.
+const (
+	queryResultTargetField = 1 // QueryResult.target
+
+	targetRuleField          = 2 // Target.rule
+	targetSourceFileField    = 3 // Target.source_file
+	targetGeneratedFileField = 4 // Target.generated_file
+
+	ruleNameField      = 1 // Rule.name
+	ruleClassField     = 2 // Rule.rule_class
+	ruleAttributeField = 4 // Rule.attribute
+
+	fileNameField = 1 // SourceFile.name and GeneratedFile.name
+
+	attributeNameField       = 1 // Attribute.name
+	attributeStringField     = 5 // Attribute.string_value
+	attributeStringListField = 6 // Attribute.string_list_value
+)
+
+// protoAttribute is a decoded Rule.attribute with the values the analyzer uses
+type protoAttribute struct {
+	name        string
+	stringValue string
+	stringList  []string
+}
+
+// parseQueryProto decodes the output of bazelisk query --output=proto
+func parseQueryProto(data []byte) (*BazelQueryResult, error) {
+	result := &BazelQueryResult{}
+	err := forEachField(data, func(num protowire.Number, value []byte) error {
+		if num != queryResultTargetField {
+			return nil
+		}
+		target, err := parseProtoTarget(value)
+		if err != nil {
+			return err
+		}
+		if target.Name != "" {
+			result.Target = append(result.Target, target)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing proto output: %v", err)
+	}
+	return result, nil
+}
+
+// parseProtoTarget decodes a Target message; file targets only carry their name
+func parseProtoTarget(data []byte) (BazelTarget, error) {
+	var target BazelTarget
+	err := forEachField(data, func(num protowire.Number, value []byte) error {
+		switch num {
+		case targetRuleField:
+			rule, err := parseProtoRule(value)
+			if err != nil {
+				return err
+			}
+			target = rule
+		case targetSourceFileField, targetGeneratedFileField:
+			return forEachField(value, func(num protowire.Number, value []byte) error {
+				if num == fileNameField {
+					target.Name = string(value)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	return target, err
+}
+
+// parseProtoRule decodes a Rule message into a target with its srcs, hdrs, deps, tags and Swift attributes
+func parseProtoRule(data []byte) (BazelTarget, error) {
+	var target BazelTarget
+	err := forEachField(data, func(num protowire.Number, value []byte) error {
+		switch num {
+		case ruleNameField:
+			target.Name = string(value)
+		case ruleClassField:
+			target.Rule = string(value)
+		case ruleAttributeField:
+			attr, err := parseProtoAttribute(value)
+			if err != nil {
+				return err
+			}
+			switch attr.name {
+			case "srcs":
+				target.Sources = attr.stringList
+			case "hdrs":
+				target.Hdrs = attr.stringList
+			case "deps":
+				target.Deps = attr.stringList
+			case "tags":
+				target.Tag = attr.stringList
+			case "module_name":
+				target.ModuleName = attr.stringValue
+			case "generator_function":
+				target.GeneratorFunction = attr.stringValue
+			}
+		}
+		return nil
+	})
+	return target, err
+}
+
+// parseProtoAttribute decodes an Attribute message
+func parseProtoAttribute(data []byte) (protoAttribute, error) {
+	var attr protoAttribute
+	err := forEachField(data, func(num protowire.Number, value []byte) error {
+		switch num {
+		case attributeNameField:
+			attr.name = string(value)
+		case attributeStringField:
+			attr.stringValue = string(value)
+		case attributeStringListField:
+			attr.stringList = append(attr.stringList, string(value))
+		}
+		return nil
+	})
+	return attr, err
+}
+
+// forEachField calls fn with the number and contents of every length-delimited field in a message,
+// skipping fields of other wire types
+func forEachField(data []byte, fn func(num protowire.Number, value []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		if err := fn(num, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
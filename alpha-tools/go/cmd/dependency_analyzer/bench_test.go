@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/deprules"
+)
+
+// fixturePackages sets the size of the synthetic workspace, e.g. go test -bench . -args -fixture-packages 500
+var fixturePackages = flag.Int("fixture-packages", 100, "Number of packages in the synthetic benchmark workspace")
+
+// fixtureDepsPerPackage is how many other packages each synthetic package depends on
+const fixtureDepsPerPackage = 4
+
+// benchFixture is a synthetic workspace and the Bazel query output for its packages
+type benchFixture struct {
+	root    string
+	graph   map[string]map[string]bool
+	queries map[string][]byte // Query output by query expression
+}
+
+var fixture *benchFixture
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	var err error
+	fixture, err = newBenchFixture(*fixturePackages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error creating benchmark fixture: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	os.RemoveAll(fixture.root)
+	os.Exit(code)
+}
+
+// newBenchFixture writes a workspace with size packages to a temporary directory. Dependencies are chosen
+// with a fixed seed, so every run benchmarks the same graph, cycles included.
+func newBenchFixture(size int) (*benchFixture, error) {
+	root, err := ioutil.TempDir("", "dependency-analyzer-bench")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "WORKSPACE"), []byte{}, 0644); err != nil {
+		return nil, err
+	}
+
+	f := &benchFixture{
+		root:    root,
+		graph:   make(map[string]map[string]bool),
+		queries: make(map[string][]byte),
+	}
+
+	random := rand.New(rand.NewSource(1))
+	names := make([]string, size)
+	for i := range names {
+		names[i] = fmt.Sprintf("Pkg%04d", i)
+	}
+
+	all := BazelQueryResult{}
+	for _, name := range names {
+		deps := make(map[string]bool)
+		for len(deps) < fixtureDepsPerPackage && len(deps) < size-1 {
+			if dep := names[random.Intn(size)]; dep != name {
+				deps[dep] = true
+			}
+		}
+		f.graph[name] = deps
+
+		label := fmt.Sprintf("//packages/%s:%s", name, name)
+		depLabels := []string{}
+		for _, dep := range sortedKeys(deps) {
+			depLabels = append(depLabels, fmt.Sprintf("//packages/%s:%s", dep, dep))
+		}
+		target := BazelTarget{Name: label, Rule: "swift_library", Deps: depLabels}
+		all.Target = append(all.Target, target)
+
+		// deps() also reports the target itself
+		depsResult := BazelQueryResult{Target: []BazelTarget{target}}
+		for _, depLabel := range depLabels {
+			depsResult.Target = append(depsResult.Target, BazelTarget{Name: depLabel, Rule: "swift_library"})
+		}
+		if f.queries["deps("+label+")"], err = json.Marshal(depsResult); err != nil {
+			return nil, err
+		}
+
+		buildContent := fmt.Sprintf("swift_library(\n    name = %q,\n    deps = [\n", name)
+		for _, depLabel := range depLabels {
+			buildContent += fmt.Sprintf("        %q,\n", depLabel)
+		}
+		buildContent += "    ],\n)\n"
+		packageDir := filepath.Join(root, "packages", name)
+		if err := os.MkdirAll(packageDir, 0755); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(filepath.Join(packageDir, "BUILD.bazel"), []byte(buildContent), 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.queries["//packages/..."], err = json.Marshal(all); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// execute serves the fixture's query output in place of bazelisk
+func (f *benchFixture) execute(workspaceRoot string, args ...string) ([]byte, error) {
+	query := args[len(args)-1]
+	output, ok := f.queries[query]
+	if !ok || !strings.Contains(strings.Join(args, " "), "--output=json") {
+		return nil, fmt.Errorf("unexpected query: %s", strings.Join(args, " "))
+	}
+	return output, nil
+}
+
+// newBenchAnalyzer creates an analyzer for the fixture whose rules permit every dependency between its packages.
+// Every iteration runs its queries, rather than reusing those of the first.
+func newBenchAnalyzer() *DependencyAnalyzer {
+	analyzer := NewDependencyAnalyzer(fixture.root, filepath.Join(fixture.root, "packages"))
+	analyzer.ValidDeps = append(deprules.Default(), deprules.ValidDependency{SourcePattern: "Pkg*", TargetPattern: "Pkg*", IsPatternEntry: true})
+	analyzer.Executor = fixture.execute
+	analyzer.NoCache = true
+	analyzer.messages = ioutil.Discard
+	return analyzer
+}
+
+// silenceStdout discards what the benchmark prints to stdout
+func silenceStdout(b *testing.B) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	b.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+}
+
+func BenchmarkRunBazelQuery(b *testing.B) {
+	analyzer := newBenchAnalyzer()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := analyzer.RunBazelQuery("//packages/...")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(result.Target) != len(fixture.graph) {
+			b.Fatalf("got %d targets, want %d", len(result.Target), len(fixture.graph))
+		}
+	}
+}
+
+func BenchmarkAnalyzeDependencies(b *testing.B) {
+	analyzer := newBenchAnalyzer()
+	silenceStdout(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		valid, err := analyzer.AnalyzeDependencies()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if !valid {
+			b.Fatal("expected every fixture dependency to be valid")
+		}
+	}
+}
+
+// parallelBenchPackages is the size of the workspace BenchmarkBuildPackageGraphParallelism analyzes
+const parallelBenchPackages = 30
+
+// parallelBenchQueryLatency is how long each query takes in BenchmarkBuildPackageGraphParallelism
+const parallelBenchQueryLatency = 20 * time.Millisecond
+
+func BenchmarkBuildPackageGraphParallelism(b *testing.B) {
+	f, err := newBenchFixture(parallelBenchPackages)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(f.root)
+
+	// Each query takes as long as a fast bazelisk query against a warm server
+	execute := func(workspaceRoot string, args ...string) ([]byte, error) {
+		time.Sleep(parallelBenchQueryLatency)
+		return f.execute(workspaceRoot, args...)
+	}
+
+	for _, parallelism := range []int{1, 2, defaultQueryParallelism, 8} {
+		b.Run(fmt.Sprintf("queries=%d", parallelism), func(b *testing.B) {
+			analyzer := newBenchAnalyzer()
+			analyzer.WorkspaceRoot, analyzer.PackagesDir = f.root, filepath.Join(f.root, "packages")
+			analyzer.Executor = execute
+			analyzer.QueryParallelism = parallelism
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				packageDeps, err := analyzer.BuildPackageGraph()
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(packageDeps) != len(f.graph) {
+					b.Fatalf("got %d packages, want %d", len(packageDeps), len(f.graph))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDetectCycles(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		detectCycles(fixture.graph)
+	}
+}
+
+func BenchmarkComputeTransitiveClosure(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		closure := computeTransitiveClosure(fixture.graph)
+		if len(closure) != len(fixture.graph) {
+			b.Fatalf("got closure of %d packages, want %d", len(closure), len(fixture.graph))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ruleStartPattern matches the start of a top-level rule or macro call in a BUILD file
+	ruleStartPattern = regexp.MustCompile(`(?m)^(\w+)\(`)
+	// depsListPattern matches a rule's deps list
+	depsListPattern = regexp.MustCompile(`(?m)^([ \t]*)deps\s*=\s*\[([^\]]*)\]`)
+)
+
+// BuildRule is a rule call found in a BUILD file
+type BuildRule struct {
+	Kind string
+	Name string
+	Deps []string
+
+	// Byte offsets of the deps list (including the "deps =" prefix) in the file, or -1 if there is none
+	depsStart, depsEnd int
+	indent             string
+}
+
+// ParseBuildRules extracts the rules and their deps lists from BUILD file content
+func ParseBuildRules(content string) []BuildRule {
+	starts := ruleStartPattern.FindAllStringSubmatchIndex(content, -1)
+
+	rules := []BuildRule{}
+	for i, start := range starts {
+		end := len(content)
+		if i+1 < len(starts) {
+			end = starts[i+1][0]
+		}
+		body := content[start[0]:end]
+
+		rule := BuildRule{
+			Kind:      content[start[2]:start[3]],
+			Deps:      []string{},
+			depsStart: -1,
+			depsEnd:   -1,
+		}
+		if match := ruleNamePattern.FindStringSubmatch(body); match != nil {
+			rule.Name = match[1]
+		}
+		if match := depsListPattern.FindStringSubmatchIndex(body); match != nil {
+			rule.depsStart = start[0] + match[0]
+			rule.depsEnd = start[0] + match[1]
+			rule.indent = body[match[2]:match[3]]
+			for _, label := range quotedStringPattern.FindAllStringSubmatch(body[match[4]:match[5]], -1) {
+				rule.Deps = append(rule.Deps, label[1])
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// MergeBuildFile merges a deps change into the named rule of a BUILD file. Labels in remove are dropped,
+// labels in add are appended unless already present, and the rewritten content is returned.
+func MergeBuildFile(content, ruleName string, remove, add []string) (string, error) {
+	for _, rule := range ParseBuildRules(content) {
+		if rule.Name != ruleName {
+			continue
+		}
+		if rule.depsStart < 0 {
+			return "", fmt.Errorf("rule %s has no deps list", ruleName)
+		}
+
+		deps := []string{}
+		for _, dep := range rule.Deps {
+			if !contains(remove, dep) && !contains(deps, dep) {
+				deps = append(deps, dep)
+			}
+		}
+		for _, dep := range add {
+			if !contains(deps, dep) {
+				deps = append(deps, dep)
+			}
+		}
+
+		return content[:rule.depsStart] + formatDepsList(rule.indent, deps) + content[rule.depsEnd:], nil
+	}
+
+	return "", fmt.Errorf("rule %s not found", ruleName)
+}
+
+// formatDepsList formats a deps list in buildifier style
+func formatDepsList(indent string, deps []string) string {
+	if len(deps) == 0 {
+		return indent + "deps = []"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(indent + "deps = [\n")
+	for _, dep := range deps {
+		sb.WriteString(fmt.Sprintf("%s    \"%s\",\n", indent, dep))
+	}
+	sb.WriteString(indent + "]")
+	return sb.String()
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// printSnapshotDiff prints the edges, violations and metrics that changed between two snapshots
+func printSnapshotDiff(before, after DependencySnapshot, diff SnapshotDiff) {
+	for _, edge := range diff.AddedEdges {
+		fmt.Printf("➕ Added:    %s -> %s\n", edge.Source, edge.Target)
+	}
+	for _, edge := range diff.RemovedEdges {
+		fmt.Printf("➖ Removed:  %s -> %s\n", edge.Source, edge.Target)
+	}
+	for _, edge := range diff.NewViolations {
+		fmt.Printf("❌ New violation: %s -> %s\n", edge.Source, edge.Target)
+	}
+	for _, edge := range diff.ResolvedViolations {
+		fmt.Printf("✅ Resolved violation: %s -> %s\n", edge.Source, edge.Target)
+	}
+
+	beforeMetrics := computePackageMetrics(before)
+	afterMetrics := computePackageMetrics(after)
+
+	packages := make(map[string]bool)
+	for pkg := range beforeMetrics {
+		packages[pkg] = true
+	}
+	for pkg := range afterMetrics {
+		packages[pkg] = true
+	}
+
+	header := false
+	for _, pkg := range sortedKeys(packages) {
+		old, existed := beforeMetrics[pkg]
+		current, exists := afterMetrics[pkg]
+		if existed && exists && old == current {
+			continue
+		}
+		if !header {
+			fmt.Println("\nPackage metrics:")
+			fmt.Printf("  %-30s %-12s %-12s\n", "Package", "Fan-in", "Fan-out")
+			header = true
+		}
+		switch {
+		case !existed:
+			fmt.Printf("  %-30s %-12s %-12s\n", pkg+" (new)", fmt.Sprint(current.FanIn), fmt.Sprint(current.FanOut))
+		case !exists:
+			fmt.Printf("  %-30s %-12s %-12s\n", pkg+" (removed)", fmt.Sprint(old.FanIn), fmt.Sprint(old.FanOut))
+		default:
+			fmt.Printf("  %-30s %-12s %-12s\n", pkg,
+				fmt.Sprintf("%d -> %d", old.FanIn, current.FanIn),
+				fmt.Sprintf("%d -> %d", old.FanOut, current.FanOut))
+		}
+	}
+
+	if !diff.HasChanges() {
+		fmt.Println("✅ Dependency graphs are identical.")
+	} else {
+		fmt.Printf("\n%d edges added, %d removed, %d new violations, %d resolved.\n",
+			len(diff.AddedEdges), len(diff.RemovedEdges), len(diff.NewViolations), len(diff.ResolvedViolations))
+	}
+}
+
+// GenerateDiffGraph writes a DOT graph of the after snapshot with added edges in blue and removed edges dashed
+func GenerateDiffGraph(before, after DependencySnapshot, diff SnapshotDiff, outputFile string) error {
+	added := edgeIndex(diff.AddedEdges)
+
+	packages := make(map[string]bool)
+	for _, pkg := range before.Packages {
+		packages[pkg] = true
+	}
+	for _, pkg := range after.Packages {
+		packages[pkg] = true
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph DependencyDiff {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=filled, fillcolor=lightblue];\n")
+
+	for _, pkg := range sortedKeys(packages) {
+		sb.WriteString(fmt.Sprintf("  \"%s\" [fillcolor=%s];\n", pkg, packageColor(pkg)))
+	}
+
+	for _, edge := range after.Edges {
+		attrs := []string{}
+		if _, isNew := added[edgeKey(edge)]; isNew {
+			attrs = append(attrs, "color=blue", "penwidth=2.0")
+		}
+		if !edge.Valid {
+			attrs = append(attrs, "fontcolor=red", "label=\"invalid\"")
+		}
+		if len(attrs) == 0 {
+			sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\";\n", edge.Source, edge.Target))
+		} else {
+			sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [%s];\n", edge.Source, edge.Target, strings.Join(attrs, ", ")))
+		}
+	}
+	for _, edge := range diff.RemovedEdges {
+		sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [style=dashed, color=gray];\n", edge.Source, edge.Target))
+	}
+
+	sb.WriteString("}\n")
+
+	if err := ioutil.WriteFile(outputFile, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", outputFile, err)
+	}
+
+	fmt.Printf("Dependency diff graph written to %s\n", outputFile)
+	return nil
+}
+
+// runCompare implements the compare subcommand
+func runCompare(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	beforeFlag := fs.String("before", "", "Workspace root before the change")
+	afterFlag := fs.String("after", "", "Workspace root after the change")
+	packagesFlag := fs.String("packages", "packages", "Packages directory relative to each workspace")
+	configFlag := fs.String("config", "", "YAML configuration file with additional dependency rules")
+	outputFlag := fs.String("compare-output", "text", "Output format: text or dot")
+	dotFileFlag := fs.String("dot-file", "dependency_diff.dot", "File to write when --compare-output is dot")
+	fs.Parse(args)
+
+	if *beforeFlag == "" || *afterFlag == "" {
+		return fmt.Errorf("both --before and --after must be specified")
+	}
+	if *outputFlag != "text" && *outputFlag != "dot" {
+		return fmt.Errorf("unknown compare output %q (expected text or dot)", *outputFlag)
+	}
+
+	var config *AnalyzerConfig
+	if *configFlag != "" {
+		var err error
+		config, err = LoadAnalyzerConfig(*configFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	snapshots := make([]DependencySnapshot, 2)
+	for i, workspaceRoot := range []string{*beforeFlag, *afterFlag} {
+		workspaceRoot, err := filepath.Abs(workspaceRoot)
+		if err != nil {
+			return fmt.Errorf("error getting absolute path: %v", err)
+		}
+
+		analyzer := NewDependencyAnalyzer(workspaceRoot, filepath.Join(workspaceRoot, *packagesFlag))
+		if config != nil {
+			analyzer.ApplyConfig(config)
+		}
+
+		snapshots[i], err = analyzer.CaptureSnapshot()
+		if err != nil {
+			return fmt.Errorf("error analyzing %s: %v", workspaceRoot, err)
+		}
+	}
+
+	before, after := snapshots[0], snapshots[1]
+	diff := DiffSnapshots(before, after)
+
+	printSnapshotDiff(before, after, diff)
+
+	if *outputFlag == "dot" {
+		return GenerateDiffGraph(before, after, diff, *dotFileFlag)
+	}
+	return nil
+}
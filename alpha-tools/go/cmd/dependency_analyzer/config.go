@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/deprules"
+	"gopkg.in/yaml.v3"
+)
+
+// DependencyRuleGroup permits dependencies between whole tiers of packages by name suffix,
+// e.g. every "*Impl" package may depend on every "*Interfaces" package.
+type DependencyRuleGroup struct {
+	Name         string   `yaml:"name,omitempty"`
+	SourceSuffix string   `yaml:"sourceSuffix"`
+	TargetSuffix string   `yaml:"targetSuffix"`
+	ExceptSource []string `yaml:"exceptSource,omitempty"`
+	ExceptTarget []string `yaml:"exceptTarget,omitempty"`
+}
+
+// ADRReference points at an architecture decision record that governs some packages
+type ADRReference struct {
+	Title    string   `yaml:"title"`
+	Path     string   `yaml:"path"`
+	Packages []string `yaml:"packages,omitempty"` // Empty applies to every package
+}
+
+// AnalyzerConfig represents the YAML configuration for the dependency analyzer
+type AnalyzerConfig struct {
+	Rules      deprules.ValidDependencyRuleSet `yaml:"rules,omitempty"`
+	RuleGroups []DependencyRuleGroup           `yaml:"ruleGroups,omitempty"`
+	ADRs       []ADRReference                  `yaml:"adrs,omitempty"`
+}
+
+// AppliesTo checks if the ADR is relevant to any of the given packages
+func (r ADRReference) AppliesTo(packages ...string) bool {
+	if len(r.Packages) == 0 {
+		return true
+	}
+	for _, pkg := range packages {
+		if contains(r.Packages, pkg) {
+			return true
+		}
+	}
+	return false
+}
+
+// AppliesTo checks if the group covers pkg as a source package
+func (g DependencyRuleGroup) AppliesTo(pkg string) bool {
+	return strings.HasSuffix(pkg, g.SourceSuffix) && !contains(g.ExceptSource, pkg)
+}
+
+// Matches checks if the group permits a dependency from source to target
+func (g DependencyRuleGroup) Matches(source, target string) bool {
+	return g.AppliesTo(source) && strings.HasSuffix(target, g.TargetSuffix) && !contains(g.ExceptTarget, target)
+}
+
+// String describes the group for diagnostics
+func (g DependencyRuleGroup) String() string {
+	desc := fmt.Sprintf("*%s -> *%s", g.SourceSuffix, g.TargetSuffix)
+	if g.Name != "" {
+		desc = fmt.Sprintf("%s (%s)", g.Name, desc)
+	}
+	if len(g.ExceptSource) > 0 {
+		desc += fmt.Sprintf(" except sources %s", strings.Join(g.ExceptSource, ", "))
+	}
+	if len(g.ExceptTarget) > 0 {
+		desc += fmt.Sprintf(" except targets %s", strings.Join(g.ExceptTarget, ", "))
+	}
+	return desc
+}
+
+// LoadAnalyzerConfig reads the analyzer configuration from a YAML file
+func LoadAnalyzerConfig(path string) (*AnalyzerConfig, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %v", err)
+	}
+
+	var config AnalyzerConfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("error parsing config %s: %v", path, err)
+	}
+
+	for i, group := range config.RuleGroups {
+		if group.SourceSuffix == "" || group.TargetSuffix == "" {
+			return nil, fmt.Errorf("rule group %d in %s must set both sourceSuffix and targetSuffix", i+1, path)
+		}
+	}
+
+	return &config, nil
+}
+
+// ApplyConfig adds the rules from a configuration to the analyzer
+func (a *DependencyAnalyzer) ApplyConfig(config *AnalyzerConfig) {
+	a.ValidDeps = append(a.ValidDeps, config.Rules...)
+	a.RuleGroups = append(a.RuleGroups, config.RuleGroups...)
+	a.ADRs = append(a.ADRs, config.ADRs...)
+}
+
+// describeRule returns the rule that permits a dependency, or an empty string if none does
+func (a *DependencyAnalyzer) describeRule(source, target string) string {
+	if source == target {
+		return "self-dependency"
+	}
+
+	for _, dep := range a.ValidDeps {
+		if !dep.IsPatternEntry && dep.Source == source && dep.Target == target {
+			return fmt.Sprintf("exact rule %s -> %s", dep.Source, dep.Target)
+		}
+	}
+
+	for _, dep := range a.ValidDeps {
+		if dep.IsPatternEntry && dep.Matches(source, target) {
+			return fmt.Sprintf("pattern rule %s -> %s", dep.SourcePattern, dep.TargetPattern)
+		}
+	}
+
+	for _, group := range a.RuleGroups {
+		if group.Matches(source, target) {
+			return fmt.Sprintf("group rule %s", group)
+		}
+	}
+
+	return ""
+}
+
+// ShowEffectiveRules prints every rule that applies to pkg and which rule permitted or denied each observed dependency
+func (a *DependencyAnalyzer) ShowEffectiveRules(pkg string) error {
+	a.printRulesFor(pkg)
+
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return err
+	}
+
+	deps, exists := packageDeps[pkg]
+	if !exists {
+		return fmt.Errorf("package %s not found in dependency graph", pkg)
+	}
+
+	fmt.Printf("\nObserved dependencies of %s:\n", pkg)
+	if len(deps) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, target := range sortedKeys(deps) {
+		if rule := a.describeRule(pkg, target); rule != "" {
+			fmt.Printf("  ✅ %s: permitted by %s\n", target, rule)
+		} else {
+			fmt.Printf("  ❌ %s: denied, no exact, pattern or group rule matches\n", target)
+		}
+	}
+
+	return nil
+}
+
+// printRulesFor prints every exact, pattern and group rule whose source covers pkg
+func (a *DependencyAnalyzer) printRulesFor(pkg string) {
+	fmt.Printf("Rules applying to %s:\n", pkg)
+
+	ruleCount := 0
+	for _, dep := range a.ValidDeps {
+		if !dep.IsPatternEntry && dep.Source == pkg {
+			fmt.Printf("  • exact:   %s -> %s\n", dep.Source, dep.Target)
+			ruleCount++
+		}
+	}
+	for _, dep := range a.ValidDeps {
+		if dep.IsPatternEntry && deprules.PatternMatches(dep.SourcePattern, pkg) {
+			fmt.Printf("  • pattern: %s -> %s\n", dep.SourcePattern, dep.TargetPattern)
+			ruleCount++
+		}
+	}
+	for _, group := range a.RuleGroups {
+		if group.AppliesTo(pkg) {
+			fmt.Printf("  • group:   %s\n", group)
+			ruleCount++
+		}
+	}
+	if ruleCount == 0 {
+		fmt.Println("  (none)")
+	}
+}
+
+// contains checks if a string is in a slice
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// CouplingPair is the coupling between two packages, with A before B alphabetically
+type CouplingPair struct {
+	A          string `json:"a"`
+	B          string `json:"b"`
+	Strength   int    `json:"strength"` // Distinct dependency edges between A and B in either direction
+	DirectAtoB bool   `json:"directAtoB"`
+	DirectBtoA bool   `json:"directBtoA"`
+}
+
+// GenerateCouplingReport computes the coupling strength of every pair of packages with a dependency
+// between them, strongest first. Each direction counts the deps entries declared in BUILD files, and at
+// least one edge when the dependency graph has it.
+func (a *DependencyAnalyzer) GenerateCouplingReport() ([]CouplingPair, error) {
+	snapshot, err := a.CaptureSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return a.couplingReport(snapshot)
+}
+
+// couplingReport computes the coupling pairs of a snapshot's packages
+func (a *DependencyAnalyzer) couplingReport(snapshot DependencySnapshot) ([]CouplingPair, error) {
+	observed, err := declaredDependencyCounts(a.PackagesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	graphEdges := make(map[string]map[string]bool)
+	for _, edge := range snapshot.Edges {
+		if graphEdges[edge.Source] == nil {
+			graphEdges[edge.Source] = make(map[string]bool)
+		}
+		graphEdges[edge.Source][edge.Target] = true
+	}
+	for source, targets := range observed {
+		for target := range targets {
+			if graphEdges[source] == nil {
+				graphEdges[source] = make(map[string]bool)
+			}
+			graphEdges[source][target] = true
+		}
+	}
+
+	pairs := make(map[[2]string]*CouplingPair)
+	for source, targets := range graphEdges {
+		for target := range targets {
+			if source == target {
+				continue
+			}
+			first, second := source, target
+			if second < first {
+				first, second = second, first
+			}
+			key := [2]string{first, second}
+			if pairs[key] == nil {
+				pairs[key] = &CouplingPair{A: first, B: second}
+			}
+			pair := pairs[key]
+
+			count := observed[source][target]
+			if count == 0 {
+				count = 1
+			}
+			pair.Strength += count
+			if source == first {
+				pair.DirectAtoB = true
+			} else {
+				pair.DirectBtoA = true
+			}
+		}
+	}
+
+	report := make([]CouplingPair, 0, len(pairs))
+	for _, pair := range pairs {
+		report = append(report, *pair)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Strength != report[j].Strength {
+			return report[i].Strength > report[j].Strength
+		}
+		if report[i].A != report[j].A {
+			return report[i].A < report[j].A
+		}
+		return report[i].B < report[j].B
+	})
+
+	return report, nil
+}
+
+// declaredDependencyCounts counts the deps entries in BUILD files for each pair of packages. The graph
+// comes from Bazel, so a missing packages directory only means there are no declared deps to count.
+func declaredDependencyCounts(packagesDir string) (map[string]map[string]int, error) {
+	if _, err := os.Stat(packagesDir); err != nil {
+		return map[string]map[string]int{}, nil
+	}
+	return observedDependencies(packagesDir)
+}
+
+// decouplingSuggestion names the dependency of a pair to remove first, following the stable dependencies
+// principle: a package should only depend on packages at least as stable as itself. It returns "" when the
+// pair already follows the principle.
+func decouplingSuggestion(pair CouplingPair, metrics map[string]PackageMetrics) string {
+	instabilityA := metrics[pair.A].Instability
+	instabilityB := metrics[pair.B].Instability
+
+	if pair.DirectAtoB && pair.DirectBtoA {
+		// Break the cycle on the side of the more stable package
+		if instabilityA <= instabilityB {
+			return fmt.Sprintf("break the cycle by removing %s -> %s", pair.A, pair.B)
+		}
+		return fmt.Sprintf("break the cycle by removing %s -> %s", pair.B, pair.A)
+	}
+
+	source, target := pair.A, pair.B
+	sourceInstability, targetInstability := instabilityA, instabilityB
+	if pair.DirectBtoA {
+		source, target = pair.B, pair.A
+		sourceInstability, targetInstability = instabilityB, instabilityA
+	}
+	if sourceInstability < targetInstability {
+		return fmt.Sprintf("%s (I=%.2f) depends on less stable %s (I=%.2f); introduce an interface", source, sourceInstability, target, targetInstability)
+	}
+	return ""
+}
+
+// runCouplingReport implements the coupling-report subcommand
+func runCouplingReport(args []string) error {
+	fs := flag.NewFlagSet("coupling-report", flag.ExitOnError)
+	workspaceFlag := fs.String("workspace", "", "Workspace root directory")
+	workspaceAutoDetectFlag := fs.Bool("workspace-auto-detect", true, workspaceAutoDetectUsage)
+	packagesFlag := fs.String("packages", "packages", "Packages directory relative to workspace")
+	configFlag := fs.String("config", "", "YAML configuration file with additional dependency rules")
+	topFlag := fs.Int("top", 10, "Number of most coupled package pairs to show (0 shows all)")
+	fs.Parse(args)
+
+	workspaceRoot, err := resolveWorkspaceRoot(*workspaceFlag, *workspaceAutoDetectFlag)
+	if err != nil {
+		return err
+	}
+
+	analyzer := NewDependencyAnalyzer(workspaceRoot, filepath.Join(workspaceRoot, *packagesFlag))
+	if *configFlag != "" {
+		analyzerConfig, err := LoadAnalyzerConfig(*configFlag)
+		if err != nil {
+			return err
+		}
+		analyzer.ApplyConfig(analyzerConfig)
+	}
+
+	snapshot, err := analyzer.CaptureSnapshot()
+	if err != nil {
+		return err
+	}
+	report, err := analyzer.couplingReport(snapshot)
+	if err != nil {
+		return err
+	}
+	if len(report) == 0 {
+		fmt.Println("✅ No dependencies between packages")
+		return nil
+	}
+	if *topFlag > 0 && len(report) > *topFlag {
+		report = report[:*topFlag]
+	}
+
+	metrics := computePackageMetrics(snapshot)
+
+	fmt.Printf("Most coupled package pairs (%d):\n", len(report))
+	fmt.Printf("  %-8s  %-30s  %-30s  %s\n", "STRENGTH", "A", "B", "DIRECTION")
+	var first *CouplingPair
+	var firstSuggestion string
+	for i, pair := range report {
+		direction := "A -> B"
+		switch {
+		case pair.DirectAtoB && pair.DirectBtoA:
+			direction = "A <-> B"
+		case pair.DirectBtoA:
+			direction = "B -> A"
+		}
+		fmt.Printf("  %-8d  %-30s  %-30s  %s\n", pair.Strength, pair.A, pair.B, direction)
+
+		if first == nil {
+			if suggestion := decouplingSuggestion(pair, metrics); suggestion != "" {
+				first = &report[i]
+				firstSuggestion = suggestion
+			}
+		}
+	}
+
+	fmt.Println()
+	if first == nil {
+		fmt.Println("✅ Every pair depends in the direction of stability")
+		return nil
+	}
+	fmt.Printf("⚠️ Decouple %s and %s first (strength %d): %s\n", first.A, first.B, first.Strength, firstSuggestion)
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// cqueryResult is the output of bazelisk cquery --output=jsonproto: Bazel's CqueryResult message in the
+// proto3 JSON mapping, with only the fields the analyzer reads
+type cqueryResult struct {
+	Results []struct {
+		Target cqueryTarget `json:"target"`
+	} `json:"results"`
+}
+
+// cqueryTarget is a Target message in the proto3 JSON mapping
+type cqueryTarget struct {
+	Type string `json:"type"` // RULE, SOURCE_FILE, GENERATED_FILE, ...
+	Rule *struct {
+		Name      string `json:"name"`
+		RuleClass string `json:"ruleClass"`
+		Attribute []struct {
+			Name            string   `json:"name"`
+			StringValue     string   `json:"stringValue"`
+			StringListValue []string `json:"stringListValue"`
+		} `json:"attribute"`
+	} `json:"rule"`
+	SourceFile *struct {
+		Name string `json:"name"`
+	} `json:"sourceFile"`
+	GeneratedFile *struct {
+		Name string `json:"name"`
+	} `json:"generatedFile"`
+}
+
+// RunBazelCQuery runs a Bazel cquery, which resolves select() for the build configuration, e.g. a platform's
+// deps. config is passed as --config if it is set.
+func (a *DependencyAnalyzer) RunBazelCQuery(query, config string) (*BazelQueryResult, error) {
+	args := []string{"cquery", "--output=jsonproto"}
+	if config != "" {
+		args = append(args, "--config="+config)
+	}
+	output, err := a.runBazelisk(append(args, query)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseCQueryJSONProto(output)
+}
+
+// parseCQueryJSONProto decodes the output of bazelisk cquery --output=jsonproto
+func parseCQueryJSONProto(data []byte) (*BazelQueryResult, error) {
+	var result cqueryResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("error parsing jsonproto output: %v", err)
+	}
+	return translateCQueryResult(&result), nil
+}
+
+// translateCQueryResult converts cquery results to query results. A target built in several configurations
+// is reported once per configuration by cquery; it appears once here, with the deps of all of them.
+func translateCQueryResult(result *cqueryResult) *BazelQueryResult {
+	translated := &BazelQueryResult{}
+	index := make(map[string]int)
+	for _, configured := range result.Results {
+		target := translateCQueryTarget(configured.Target)
+		if target.Name == "" {
+			continue
+		}
+		if i, seen := index[target.Name]; seen {
+			for _, dep := range target.Deps {
+				if !contains(translated.Target[i].Deps, dep) {
+					translated.Target[i].Deps = append(translated.Target[i].Deps, dep)
+				}
+			}
+			continue
+		}
+		index[target.Name] = len(translated.Target)
+		translated.Target = append(translated.Target, target)
+	}
+	return translated
+}
+
+// translateCQueryTarget converts a Target message; file targets only carry their name. jsonproto has every
+// attribute, so rules get the same fields as with --query-output proto.
+func translateCQueryTarget(target cqueryTarget) BazelTarget {
+	switch {
+	case target.Rule != nil:
+		converted := BazelTarget{Name: target.Rule.Name, Rule: target.Rule.RuleClass}
+		for _, attr := range target.Rule.Attribute {
+			switch attr.Name {
+			case "srcs":
+				converted.Sources = attr.StringListValue
+			case "hdrs":
+				converted.Hdrs = attr.StringListValue
+			case "deps":
+				converted.Deps = attr.StringListValue
+			case "tags":
+				converted.Tag = attr.StringListValue
+			case "module_name":
+				converted.ModuleName = attr.StringValue
+			case "generator_function":
+				converted.GeneratorFunction = attr.StringValue
+			}
+		}
+		return converted
+	case target.SourceFile != nil:
+		return BazelTarget{Name: target.SourceFile.Name}
+	case target.GeneratedFile != nil:
+		return BazelTarget{Name: target.GeneratedFile.Name}
+	}
+	return BazelTarget{}
+}
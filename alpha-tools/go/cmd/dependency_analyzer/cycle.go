@@ -0,0 +1,240 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// DetectCycles queries the package graph and returns its dependency cycles, each as the packages in
+// traversal order with the first repeated at the end
+func (a *DependencyAnalyzer) DetectCycles() ([][]string, error) {
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return nil, err
+	}
+	return findCyclePaths(packageDeps), nil
+}
+
+// printCycles prints each cycle in arrow notation and returns false if there is any
+func printCycles(cycles [][]string) bool {
+	if len(cycles) == 0 {
+		fmt.Println("✅ No dependency cycles found.")
+		return true
+	}
+	for _, cycle := range cycles {
+		fmt.Printf("❌ Dependency cycle: %s\n", strings.Join(cycle, " → "))
+	}
+	fmt.Printf("Found %d dependency cycles. Run explain-cycle --cycle with a cycle's packages to see how to break it.\n", len(cycles))
+	return false
+}
+
+// CycleEdge explains one dependency of a cycle: the BUILD target whose deps create it and the Swift files
+// that import the dependency
+type CycleEdge struct {
+	Source      string
+	Target      string
+	Path        []string // Target labels from the source package to the target package
+	SourceLabel string   // Target in the source package that depends outside it
+	DepLabel    string   // The dependency of SourceLabel on the way to the target package
+	Files       []string // Swift files of SourceLabel's package that import DepLabel's module
+}
+
+// ExplainCycleEdge finds the BUILD target that makes source depend on target and the Swift files importing it
+func (a *DependencyAnalyzer) ExplainCycleEdge(source, target string) (CycleEdge, error) {
+	edge := CycleEdge{Source: source, Target: target}
+
+	result, err := a.RunBazelQuery(fmt.Sprintf("somepath(//packages/%s/..., //packages/%s/...)", source, target))
+	if err != nil {
+		return edge, fmt.Errorf("error querying path from %s to %s: %v", source, target, err)
+	}
+	if len(result.Target) == 0 {
+		return edge, fmt.Errorf("no dependency path from %s to %s", source, target)
+	}
+
+	inPath := make(map[string]bool)
+	for _, pathTarget := range result.Target {
+		edge.Path = append(edge.Path, pathTarget.Name)
+		inPath[pathTarget.Name] = true
+	}
+
+	// The edge leaves the source package where a target of the path depends on a target outside it
+	for _, pathTarget := range result.Target {
+		if a.ParseTargetPackage(pathTarget.Name) != source {
+			continue
+		}
+		for _, dep := range pathTarget.Deps {
+			if inPath[dep] && a.ParseTargetPackage(dep) != source {
+				edge.SourceLabel = pathTarget.Name
+				edge.DepLabel = dep
+				break
+			}
+		}
+		if edge.SourceLabel != "" {
+			break
+		}
+	}
+	if edge.SourceLabel == "" {
+		return edge, nil
+	}
+
+	// Swift files of the source target that import the dependency's module
+	packagePath, _ := splitLabel(edge.SourceLabel)
+	_, module := splitLabel(edge.DepLabel)
+	packageDir := filepath.Join(a.WorkspaceRoot, filepath.FromSlash(packagePath))
+	files, err := packageSwiftFiles(packageDir)
+	if err != nil {
+		return edge, nil // Sources are not available, e.g. generated packages
+	}
+	for _, file := range files {
+		path := filepath.Join(packageDir, filepath.FromSlash(file))
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return edge, fmt.Errorf("error reading %s: %v", path, err)
+		}
+		for _, match := range swiftImportPattern.FindAllStringSubmatch(string(content), -1) {
+			if match[1] == module {
+				edge.Files = append(edge.Files, packagePath+"/"+file)
+				break
+			}
+		}
+	}
+	return edge, nil
+}
+
+// ExplainCycle prints why each dependency of a package cycle exists and which one to cut
+func (a *DependencyAnalyzer) ExplainCycle(cycle []string) error {
+	if len(cycle) > 1 && cycle[0] == cycle[len(cycle)-1] {
+		cycle = cycle[:len(cycle)-1]
+	}
+	if len(cycle) < 2 {
+		return fmt.Errorf("a cycle needs at least two packages")
+	}
+
+	fmt.Printf("Cycle: %s -> %s\n", strings.Join(cycle, " -> "), cycle[0])
+
+	edges := []CycleEdge{}
+	for i, source := range cycle {
+		target := cycle[(i+1)%len(cycle)]
+		fmt.Printf("\n%s -> %s\n", source, target)
+
+		edge, err := a.ExplainCycleEdge(source, target)
+		if err != nil {
+			fmt.Printf("  ⚠️ %v\n", err)
+			continue
+		}
+		edges = append(edges, edge)
+
+		if edge.SourceLabel == "" {
+			fmt.Printf("  Path: %s\n", strings.Join(edge.Path, ", "))
+			continue
+		}
+		fmt.Printf("  %s depends on %s\n", edge.SourceLabel, edge.DepLabel)
+		if len(edge.Path) > 2 {
+			fmt.Printf("  Path: %s\n", strings.Join(edge.Path, ", "))
+		}
+		if len(edge.Files) == 0 {
+			fmt.Println("  No Swift file imports it directly")
+		}
+		for _, file := range edge.Files {
+			fmt.Printf("  • %s\n", file)
+		}
+	}
+
+	if len(edges) == 0 {
+		return fmt.Errorf("none of the cycle's dependencies exist")
+	}
+
+	cut, err := a.cycleEdgeToCut(edges)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nSuggested resolution: cut %s -> %s", cut.Source, cut.Target)
+	if len(cut.Files) > 0 {
+		fmt.Printf(" (%d files to change)", len(cut.Files))
+	}
+	fmt.Println()
+
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return err
+	}
+	resolver := NewDependencyConflictResolver(a, sortedKeys(packageDeps))
+	for i, strategy := range resolver.SuggestStrategies(cut.Source, cut.Target) {
+		fmt.Printf("  %d. %s: %s\n", i+1, strategy.Name, strategy.Description)
+	}
+	return nil
+}
+
+// cycleEdgeToCut picks the edge of a cycle to remove: one the rules forbid if there is any, otherwise the one
+// from the most stable package, which should not depend on less stable ones. Ties go to the edge with fewer
+// importing files, which is cheaper to change.
+func (a *DependencyAnalyzer) cycleEdgeToCut(edges []CycleEdge) (CycleEdge, error) {
+	snapshot, err := a.CaptureSnapshot()
+	if err != nil {
+		return CycleEdge{}, err
+	}
+	metrics := computePackageMetrics(snapshot)
+
+	better := func(edge, best CycleEdge) bool {
+		edgeInvalid := !a.IsDependencyValid(edge.Source, edge.Target)
+		bestInvalid := !a.IsDependencyValid(best.Source, best.Target)
+		if edgeInvalid != bestInvalid {
+			return edgeInvalid
+		}
+		if edgeInstability, bestInstability := metrics[edge.Source].Instability, metrics[best.Source].Instability; edgeInstability != bestInstability {
+			return edgeInstability < bestInstability
+		}
+		return len(edge.Files) < len(best.Files)
+	}
+
+	best := edges[0]
+	for _, edge := range edges[1:] {
+		if better(edge, best) {
+			best = edge
+		}
+	}
+	return best, nil
+}
+
+// runExplainCycle implements the explain-cycle subcommand
+func runExplainCycle(args []string) error {
+	fs := flag.NewFlagSet("explain-cycle", flag.ExitOnError)
+	workspaceFlag := fs.String("workspace", "", "Workspace root directory")
+	workspaceAutoDetectFlag := fs.Bool("workspace-auto-detect", true, workspaceAutoDetectUsage)
+	packagesFlag := fs.String("packages", "packages", "Packages directory relative to workspace")
+	configFlag := fs.String("config", "", "YAML configuration file with additional dependency rules")
+	resolveMacrosFlag := fs.Bool("resolve-macros", false, "Resolve custom macros (e.g., umbra_swift_library) to the deps of their expanded rules")
+	cycleFlag := fs.String("cycle", "", "Comma-separated packages of the cycle, e.g. A,B,C for A -> B -> C -> A")
+	fs.Parse(args)
+
+	if *cycleFlag == "" {
+		return fmt.Errorf("--cycle must be specified")
+	}
+	cycle := []string{}
+	for _, pkg := range strings.Split(*cycleFlag, ",") {
+		if pkg = strings.TrimSpace(pkg); pkg != "" {
+			cycle = append(cycle, pkg)
+		}
+	}
+
+	workspaceRoot, err := resolveWorkspaceRoot(*workspaceFlag, *workspaceAutoDetectFlag)
+	if err != nil {
+		return err
+	}
+
+	analyzer := NewDependencyAnalyzer(workspaceRoot, filepath.Join(workspaceRoot, *packagesFlag))
+	analyzer.ResolveMacros = *resolveMacrosFlag
+
+	if *configFlag != "" {
+		config, err := LoadAnalyzerConfig(*configFlag)
+		if err != nil {
+			return err
+		}
+		analyzer.ApplyConfig(config)
+	}
+
+	return analyzer.ExplainCycle(cycle)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// d3Node is a package in the D3.js graph
+type d3Node struct {
+	ID          string  `json:"id"`
+	Tier        int     `json:"tier"`
+	FileCount   int     `json:"fileCount"`
+	Instability float64 `json:"instability"`
+	Grade       string  `json:"grade"`
+}
+
+// d3Link is a dependency in the D3.js graph; Source and Target are node ids, as d3.forceLink().id() expects
+type d3Link struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Valid  bool   `json:"valid"`
+	Weight int    `json:"weight"` // deps entries declared in BUILD files, at least 1
+}
+
+// d3Graph is the {nodes, links} document read by d3.forceSimulation and d3.forceLink
+type d3Graph struct {
+	Nodes []d3Node `json:"nodes"`
+	Links []d3Link `json:"links"`
+}
+
+// GenerateD3JSON writes the dependency graph with package metrics as D3.js force layout JSON
+func (a *DependencyAnalyzer) GenerateD3JSON(outputFile string) error {
+	snapshot, err := a.CaptureSnapshot()
+	if err != nil {
+		return err
+	}
+
+	if len(snapshot.Packages) == 0 {
+		return fmt.Errorf("no targets found in packages directory")
+	}
+
+	observed, err := declaredDependencyCounts(a.PackagesDir)
+	if err != nil {
+		return err
+	}
+
+	tiers := packageTiers(a.ValidDeps)
+	metrics := computePackageMetrics(snapshot)
+	graph := d3Graph{Nodes: []d3Node{}, Links: []d3Link{}}
+	for _, pkg := range snapshot.Packages {
+		packageMetrics := metrics[pkg]
+		if err := addSourceMetrics(filepath.Join(a.PackagesDir, pkg), &packageMetrics); err != nil {
+			return err
+		}
+		card, err := a.scoreSnapshotPackage(snapshot, pkg, DefaultScoringConfig())
+		if err != nil {
+			return err
+		}
+
+		graph.Nodes = append(graph.Nodes, d3Node{
+			ID:          pkg,
+			Tier:        tiers[pkg],
+			FileCount:   packageMetrics.FileCount,
+			Instability: packageMetrics.Instability,
+			Grade:       card.Grade,
+		})
+	}
+
+	for _, edge := range snapshot.Edges {
+		weight := observed[edge.Source][edge.Target]
+		if weight == 0 {
+			weight = 1
+		}
+		graph.Links = append(graph.Links, d3Link{Source: edge.Source, Target: edge.Target, Valid: edge.Valid, Weight: weight})
+	}
+
+	content, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding D3 graph: %v", err)
+	}
+
+	if err := ioutil.WriteFile(outputFile, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", outputFile, err)
+	}
+
+	fmt.Printf("D3.js dependency graph written to %s\n", outputFile)
+	return nil
+}
+
+// packageTiers returns the layer of each package named by an exact rule: 0 for packages whose rules allow no
+// dependencies, such as UmbraCoreTypes, otherwise one more than the highest tier they may depend on.
+// Packages no rule names are not in the map, so they are tier 0.
+func packageTiers(rules []ValidDependency) map[string]int {
+	targets := make(map[string][]string)
+	for _, rule := range rules {
+		if rule.IsPatternEntry {
+			continue
+		}
+		targets[rule.Source] = append(targets[rule.Source], rule.Target)
+		if _, exists := targets[rule.Target]; !exists {
+			targets[rule.Target] = nil
+		}
+	}
+
+	tiers := make(map[string]int)
+	visiting := make(map[string]bool)
+	var tierOf func(pkg string) int
+	tierOf = func(pkg string) int {
+		if tier, done := tiers[pkg]; done {
+			return tier
+		}
+		if visiting[pkg] {
+			return 0 // Rules with a cycle; stop rather than recurse forever
+		}
+		visiting[pkg] = true
+
+		tier := 0
+		for _, target := range targets[pkg] {
+			if targetTier := tierOf(target) + 1; targetTier > tier {
+				tier = targetTier
+			}
+		}
+
+		visiting[pkg] = false
+		tiers[pkg] = tier
+		return tier
+	}
+
+	for pkg := range targets {
+		tierOf(pkg)
+	}
+	return tiers
+}
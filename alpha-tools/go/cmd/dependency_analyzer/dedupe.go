@@ -0,0 +1,164 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// depsListExtrasPattern matches what a deps list may contain besides its labels without deduplicate-deps
+// losing anything when it rewrites the list
+var depsListExtrasPattern = regexp.MustCompile(`^[\s,]*$`)
+
+// DeduplicateDeps removes repeated labels from the deps lists of BUILD file content, comparing them
+// case-insensitively and keeping the first, and sorts the lists the way buildifier does. Lists with comments
+// or anything but string labels are left alone. It returns the new content and the number of labels removed.
+func DeduplicateDeps(content string) (string, int) {
+	removed := 0
+	rules := ParseBuildRules(content)
+
+	// Rewrite from the end of the file so earlier offsets stay valid
+	for i := len(rules) - 1; i >= 0; i-- {
+		rule := rules[i]
+		if rule.depsStart < 0 {
+			continue
+		}
+		match := depsListPattern.FindStringSubmatch(content[rule.depsStart:rule.depsEnd])
+		if !depsListExtrasPattern.MatchString(quotedStringPattern.ReplaceAllString(match[2], "")) {
+			continue
+		}
+
+		deps := []string{}
+		seen := make(map[string]bool)
+		for _, dep := range rule.Deps {
+			if seen[strings.ToLower(dep)] {
+				continue
+			}
+			seen[strings.ToLower(dep)] = true
+			deps = append(deps, dep)
+		}
+		sort.SliceStable(deps, func(i, j int) bool { return lessBuildLabel(deps[i], deps[j]) })
+
+		if equalStrings(deps, rule.Deps) {
+			continue
+		}
+		removed += len(rule.Deps) - len(deps)
+		content = content[:rule.depsStart] + formatDepsList(rule.indent, deps) + content[rule.depsEnd:]
+	}
+
+	return content, removed
+}
+
+// lessBuildLabel orders labels like buildifier sorts deps: local labels first, then labels in the main
+// repository, then external ones, comparing the parts between dots and colons
+func lessBuildLabel(a, b string) bool {
+	phase := func(label string) int {
+		switch {
+		case strings.HasPrefix(label, ":"):
+			return 1
+		case strings.HasPrefix(label, "//"):
+			return 2
+		case strings.HasPrefix(label, "@"):
+			return 3
+		}
+		return 0
+	}
+	if phaseA, phaseB := phase(a), phase(b); phaseA != phaseB {
+		return phaseA < phaseB
+	}
+
+	splitA := strings.Split(strings.ReplaceAll(a, ":", "."), ".")
+	splitB := strings.Split(strings.ReplaceAll(b, ":", "."), ".")
+	for k := 0; k < len(splitA) && k < len(splitB); k++ {
+		if splitA[k] != splitB[k] {
+			return splitA[k] < splitB[k]
+		}
+	}
+	if len(splitA) != len(splitB) {
+		return len(splitA) < len(splitB)
+	}
+	return a < b
+}
+
+// equalStrings reports whether two slices hold the same strings in the same order
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// runDeduplicateDeps implements the deduplicate-deps subcommand
+func runDeduplicateDeps(args []string) error {
+	fs := flag.NewFlagSet("deduplicate-deps", flag.ExitOnError)
+	packagesDirFlag := fs.String("packages-dir", "packages", "Directory whose BUILD files to clean up")
+	dryRunFlag := fs.Bool("dry-run", false, "Only report the BUILD files that would change")
+	fs.Parse(args)
+
+	changed := []string{}
+	totalRemoved := 0
+	err := filepath.Walk(*packagesDirFlag, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || (info.Name() != "BUILD" && info.Name() != "BUILD.bazel") {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading BUILD file: %v", err)
+		}
+
+		updated, removed := DeduplicateDeps(string(content))
+		if updated == string(content) {
+			return nil
+		}
+
+		changed = append(changed, path)
+		totalRemoved += removed
+		fmt.Printf("%s: %d duplicate deps removed\n", path, removed)
+		if *dryRunFlag {
+			return nil
+		}
+		if err := ioutil.WriteFile(path, []byte(updated), info.Mode()); err != nil {
+			return fmt.Errorf("error writing BUILD file: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error scanning %s: %v", *packagesDirFlag, err)
+	}
+
+	if len(changed) == 0 {
+		fmt.Println("✅ No duplicate or unsorted deps found.")
+		return nil
+	}
+	if *dryRunFlag {
+		fmt.Printf("ℹ️ Dry run: %d BUILD files would change, %d duplicate deps removed.\n", len(changed), totalRemoved)
+		return nil
+	}
+
+	fmt.Printf("✅ Updated %d BUILD files, %d duplicate deps removed.\n", len(changed), totalRemoved)
+
+	if _, err := exec.LookPath("buildifier"); err != nil {
+		fmt.Println("ℹ️ buildifier not found; the changed files were not reformatted")
+		return nil
+	}
+	output, err := exec.Command("buildifier", changed...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error running buildifier: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// ComputeDepths queries the package graph and returns, for every package, the length of the longest
+// dependency path from it to a package without dependencies. Packages that only appear as dependencies, and
+// so have no edges of their own in the query result, have depth 0. Cycles are reported as a warning and the
+// edges that close them are ignored.
+func (a *DependencyAnalyzer) ComputeDepths() (map[string]int, error) {
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	packageSet := make(map[string]bool)
+	edges := graphEdges(packageDeps)
+	for pkg := range packageDeps {
+		packageSet[pkg] = true
+	}
+	for _, edge := range edges {
+		packageSet[edge.Target] = true
+	}
+
+	if cycles := findCyclePaths(packageDeps); len(cycles) > 0 {
+		a.warn("The graph has %d dependency cycles, so depths ignore the dependencies that close them; run --check-cycles to list them", len(cycles))
+	}
+	return packageDepths(sortedKeys(packageSet), edges), nil
+}
+
+// printDepthReport prints the packages by depth, deepest first. A negative maxDepth disables the threshold;
+// otherwise it returns false if any package is deeper than maxDepth.
+func printDepthReport(depths map[string]int, maxDepth int) bool {
+	packages := sortedKeys(depths)
+	sort.SliceStable(packages, func(i, j int) bool { return depths[packages[i]] > depths[packages[j]] })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Depth\tPackage")
+	tooDeep := 0
+	for _, pkg := range packages {
+		marker := ""
+		if maxDepth >= 0 && depths[pkg] > maxDepth {
+			marker = "\t❌ exceeds --max-depth"
+			tooDeep++
+		}
+		fmt.Fprintf(w, "%d\t%s%s\n", depths[pkg], pkg, marker)
+	}
+	w.Flush()
+
+	if maxDepth < 0 {
+		return true
+	}
+	if tooDeep > 0 {
+		fmt.Printf("\n❌ %d packages are deeper than %d.\n", tooDeep, maxDepth)
+		return false
+	}
+	fmt.Printf("\n✅ No package is deeper than %d.\n", maxDepth)
+	return true
+}
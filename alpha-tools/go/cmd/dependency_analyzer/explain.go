@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ExplainViolation prints the context of a dependency from source to target and how to resolve it
+func (a *DependencyAnalyzer) ExplainViolation(source, target string) error {
+	if a.IsDependencyValid(source, target) {
+		fmt.Printf("✅ %s may depend on %s: permitted by %s\n", source, target, a.describeRule(source, target))
+	} else {
+		fmt.Printf("❌ %s may not depend on %s\n", source, target)
+	}
+
+	// How source reaches target
+	fmt.Printf("\nDependency path:\n")
+	if path, err := a.FindShortestPath(source, target); err != nil {
+		fmt.Printf("  (%v)\n", err)
+	} else {
+		fmt.Printf("  %s\n", strings.Join(path, " -> "))
+	}
+
+	// Rules for source, and which of them target satisfies
+	fmt.Println()
+	a.printRulesFor(source)
+	fmt.Printf("\nRules of %s satisfied by %s:\n", source, target)
+	if rule := a.describeRule(source, target); rule != "" {
+		fmt.Printf("  • %s\n", rule)
+	} else {
+		fmt.Println("  (none)")
+	}
+
+	// Packages affected by changing source
+	impacted, err := a.GetImpactSet(source)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nPackages depending on %s (affected by a fix):\n", source)
+	if len(impacted) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, pkg := range impacted {
+		fmt.Printf("  • %s\n", pkg)
+	}
+
+	// Suggested remediation
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return err
+	}
+	resolver := NewDependencyConflictResolver(a, sortedKeys(packageDeps))
+	fmt.Println("\nSuggested strategies:")
+	for i, strategy := range resolver.SuggestStrategies(source, target) {
+		fmt.Printf("  %d. %s: %s\n", i+1, strategy.Name, strategy.Description)
+	}
+
+	// Relevant architecture decisions
+	adrs := []ADRReference{}
+	for _, adr := range a.ADRs {
+		if adr.AppliesTo(source, target) {
+			adrs = append(adrs, adr)
+		}
+	}
+	if len(adrs) > 0 {
+		fmt.Println("\nRelevant ADRs:")
+		for _, adr := range adrs {
+			fmt.Printf("  • %s: %s\n", adr.Title, adr.Path)
+		}
+	}
+
+	return nil
+}
+
+// runExplain implements the explain subcommand
+func runExplain(args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	workspaceFlag := fs.String("workspace", "", "Workspace root directory")
+	workspaceAutoDetectFlag := fs.Bool("workspace-auto-detect", true, workspaceAutoDetectUsage)
+	packagesFlag := fs.String("packages", "packages", "Packages directory relative to workspace")
+	configFlag := fs.String("config", "", "YAML configuration file with additional dependency rules and ADRs")
+	resolveMacrosFlag := fs.Bool("resolve-macros", false, "Resolve custom macros (e.g., umbra_swift_library) to the deps of their expanded rules")
+	sourceFlag := fs.String("source", "", "Package that has the dependency")
+	targetFlag := fs.String("target", "", "Package it depends on")
+	fs.Parse(args)
+
+	if *sourceFlag == "" || *targetFlag == "" {
+		return fmt.Errorf("both --source and --target must be specified")
+	}
+
+	workspaceRoot, err := resolveWorkspaceRoot(*workspaceFlag, *workspaceAutoDetectFlag)
+	if err != nil {
+		return err
+	}
+
+	analyzer := NewDependencyAnalyzer(workspaceRoot, filepath.Join(workspaceRoot, *packagesFlag))
+	analyzer.ResolveMacros = *resolveMacrosFlag
+
+	if *configFlag != "" {
+		config, err := LoadAnalyzerConfig(*configFlag)
+		if err != nil {
+			return err
+		}
+		analyzer.ApplyConfig(config)
+	}
+
+	return analyzer.ExplainViolation(*sourceFlag, *targetFlag)
+}
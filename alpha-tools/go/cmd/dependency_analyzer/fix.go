@@ -0,0 +1,196 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DepFix redirects one label in a rule's deps list
+type DepFix struct {
+	BuildFile string
+	Rule      string
+	OldLabel  string
+	NewLabel  string
+}
+
+// PlanFixes finds invalid dependencies with an unambiguous fix, namely a single interface package that the
+// source may depend on in place of the target, and returns the BUILD file changes that apply it
+func (a *DependencyAnalyzer) PlanFixes() ([]DepFix, []DepEdge, error) {
+	result, err := a.Analyze()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolver := NewDependencyConflictResolver(a, result.Packages)
+
+	fixes := []DepFix{}
+	unfixed := []DepEdge{}
+	for _, edge := range result.Edges {
+		if edge.Valid {
+			continue
+		}
+
+		replacement := ""
+		candidates := 0
+		for _, strategy := range resolver.SuggestStrategies(edge.Source, edge.Target) {
+			if strategy.Name == "depend-on-interface" {
+				replacement = strategy.Package
+				candidates++
+			}
+		}
+		if candidates != 1 {
+			unfixed = append(unfixed, edge)
+			continue
+		}
+
+		edgeFixes, err := a.redirectDeps(edge.Source, edge.Target, replacement)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(edgeFixes) == 0 {
+			unfixed = append(unfixed, edge)
+			continue
+		}
+		fixes = append(fixes, edgeFixes...)
+	}
+
+	return fixes, unfixed, nil
+}
+
+// redirectDeps finds deps on the top-level target of package from in the BUILD files of source, and plans
+// replacing them with the top-level target of package to
+func (a *DependencyAnalyzer) redirectDeps(source, from, to string) ([]DepFix, error) {
+	fixes := []DepFix{}
+
+	err := filepath.Walk(filepath.Join(a.PackagesDir, source), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || (info.Name() != "BUILD" && info.Name() != "BUILD.bazel") {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading BUILD file: %v", err)
+		}
+
+		for _, rule := range ParseBuildRules(string(content)) {
+			for _, dep := range rule.Deps {
+				// Only the package's main target maps unambiguously to the interface package
+				switch dep {
+				case "//packages/" + from:
+					fixes = append(fixes, DepFix{BuildFile: path, Rule: rule.Name, OldLabel: dep, NewLabel: "//packages/" + to})
+				case fmt.Sprintf("//packages/%s:%s", from, from):
+					fixes = append(fixes, DepFix{BuildFile: path, Rule: rule.Name, OldLabel: dep, NewLabel: fmt.Sprintf("//packages/%s:%s", to, to)})
+				}
+			}
+		}
+		return nil
+	})
+
+	return fixes, err
+}
+
+// ApplyFixes rewrites BUILD files with the planned fixes, or only prints them when confirm is false
+func ApplyFixes(fixes []DepFix, confirm bool) error {
+	byFile := make(map[string][]DepFix)
+	for _, fix := range fixes {
+		byFile[fix.BuildFile] = append(byFile[fix.BuildFile], fix)
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("error reading BUILD file: %v", err)
+		}
+
+		updated := string(content)
+		fmt.Printf("--- %s\n+++ %s\n", file, file)
+		for _, fix := range byFile[file] {
+			updated, err = MergeBuildFile(updated, fix.Rule, []string{fix.OldLabel}, []string{fix.NewLabel})
+			if err != nil {
+				return fmt.Errorf("error updating %s: %v", file, err)
+			}
+			fmt.Printf("@@ %s @@\n-    \"%s\",\n+    \"%s\",\n", fix.Rule, fix.OldLabel, fix.NewLabel)
+		}
+
+		if confirm {
+			if err := ioutil.WriteFile(file, []byte(updated), 0644); err != nil {
+				return fmt.Errorf("error writing BUILD file: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runFix implements the fix subcommand
+func runFix(args []string) error {
+	fs := flag.NewFlagSet("fix", flag.ExitOnError)
+	workspaceFlag := fs.String("workspace", "", "Workspace root directory")
+	workspaceAutoDetectFlag := fs.Bool("workspace-auto-detect", true, workspaceAutoDetectUsage)
+	packagesFlag := fs.String("packages", "packages", "Packages directory relative to workspace")
+	configFlag := fs.String("config", "", "YAML configuration file with additional dependency rules")
+	resolveMacrosFlag := fs.Bool("resolve-macros", false, "Resolve custom macros (e.g., umbra_swift_library) to the deps of their expanded rules")
+	confirmFlag := fs.Bool("confirm", false, "Write the changes; without this only a dry-run diff is printed")
+	fs.Parse(args)
+
+	workspaceRoot, err := resolveWorkspaceRoot(*workspaceFlag, *workspaceAutoDetectFlag)
+	if err != nil {
+		return err
+	}
+
+	analyzer := NewDependencyAnalyzer(workspaceRoot, filepath.Join(workspaceRoot, *packagesFlag))
+	analyzer.ResolveMacros = *resolveMacrosFlag
+
+	if *configFlag != "" {
+		config, err := LoadAnalyzerConfig(*configFlag)
+		if err != nil {
+			return err
+		}
+		analyzer.ApplyConfig(config)
+	}
+
+	fixes, unfixed, err := analyzer.PlanFixes()
+	if err != nil {
+		return err
+	}
+
+	if err := ApplyFixes(fixes, *confirmFlag); err != nil {
+		return err
+	}
+
+	files := make(map[string]bool)
+	for _, fix := range fixes {
+		files[fix.BuildFile] = true
+	}
+
+	if len(fixes) == 0 {
+		fmt.Println("No invalid dependencies with an unambiguous fix found.")
+	} else if *confirmFlag {
+		fmt.Printf("✅ Applied %d changes to %d BUILD files.\n", len(fixes), len(files))
+	} else {
+		fmt.Printf("ℹ️ Dry run: %d changes to %d BUILD files. Re-run with --confirm to apply them.\n", len(fixes), len(files))
+	}
+
+	for _, edge := range unfixed {
+		fmt.Printf("⚠️ No unambiguous fix for %s -> %s; run explain --source %s --target %s for options\n",
+			edge.Source, edge.Target, edge.Source, edge.Target)
+	}
+
+	if len(unfixed) > 0 {
+		return fmt.Errorf("%d invalid dependencies need manual fixes", len(unfixed))
+	}
+	return nil
+}
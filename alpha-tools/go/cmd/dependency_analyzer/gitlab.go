@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// gitlabCITemplate is the .gitlab-ci.yml snippet printed by generate-gitlab-ci
+var gitlabCITemplate = template.Must(template.New("gitlab-ci").Parse(`# Generated by dependency_analyzer generate-gitlab-ci.
+# Runs the Alpha Dot Five dependency analysis and migration checks on merge requests into {{.MainBranch}}.
+
+.alpha-tools:
+  image: {{.Image}}
+  stage: {{.Stage}}
+  rules:
+    - if: '$CI_PIPELINE_SOURCE == "merge_request_event" && $CI_MERGE_REQUEST_TARGET_BRANCH_NAME == "{{.MainBranch}}"'
+  variables:
+    GOPATH: "$CI_PROJECT_DIR/.go"
+    GOCACHE: "$CI_PROJECT_DIR/.go/cache"
+  cache:
+    key:
+      files:
+        - {{.MappingsFile}}
+    paths:
+      - .go/
+
+dep-analysis:
+  extends: .alpha-tools
+  script:
+    - (cd {{.ToolsDir}} && go build -o "$CI_PROJECT_DIR/bin/dependency_analyzer" ./cmd/dependency_analyzer)
+    - bin/dependency_analyzer --workspace "$CI_PROJECT_DIR" --packages {{.PackagesDir}} --strict --html-report {{.ReportFile}}
+  artifacts:
+    when: always
+    expose_as: dependency report
+    paths:
+      - {{.ReportFile}}
+
+migration-validate:
+  extends: .alpha-tools
+  script:
+    - (cd {{.ToolsDir}} && go build -o "$CI_PROJECT_DIR/bin/migration_helper" ./cmd/migration_helper)
+    - bin/migration_helper validate --source {{.SourceDir}} --target {{.PackagesDir}} --mappings-file {{.MappingsFile}}
+`))
+
+// GitLabCIOptions configures the generated GitLab CI jobs
+type GitLabCIOptions struct {
+	MainBranch   string
+	Image        string
+	Stage        string
+	ToolsDir     string // Directory containing the tools' go.mod
+	SourceDir    string
+	PackagesDir  string
+	MappingsFile string // Also used as the cache key
+	ReportFile   string
+}
+
+// GenerateGitLabCI renders the GitLab CI jobs for the given options
+func GenerateGitLabCI(options GitLabCIOptions) (string, error) {
+	var sb strings.Builder
+	if err := gitlabCITemplate.Execute(&sb, options); err != nil {
+		return "", fmt.Errorf("error rendering GitLab CI template: %v", err)
+	}
+	return sb.String(), nil
+}
+
+// runGenerateGitLabCI implements the generate-gitlab-ci subcommand
+func runGenerateGitLabCI(args []string) error {
+	fs := flag.NewFlagSet("generate-gitlab-ci", flag.ExitOnError)
+	mainBranchFlag := fs.String("main-branch", "main", "Only run the jobs on merge requests targeting this branch")
+	imageFlag := fs.String("image", "golang:1.20", "Docker image the jobs run in")
+	stageFlag := fs.String("stage", "test", "Pipeline stage of the jobs")
+	toolsDirFlag := fs.String("tools-dir", "alpha-tools/go", "Directory containing the tools' go.mod, relative to the repository root")
+	sourceFlag := fs.String("source", "Sources", "Source directory passed to migration_helper validate")
+	packagesFlag := fs.String("packages", "packages", "Packages directory relative to the repository root")
+	mappingsFileFlag := fs.String("mappings-file", "mappings.json", "Package mappings file; also the cache key")
+	reportFlag := fs.String("report", "dependency_report.html", "HTML report kept as a job artifact")
+	fs.Parse(args)
+
+	content, err := GenerateGitLabCI(GitLabCIOptions{
+		MainBranch:   *mainBranchFlag,
+		Image:        *imageFlag,
+		Stage:        *stageFlag,
+		ToolsDir:     *toolsDirFlag,
+		SourceDir:    *sourceFlag,
+		PackagesDir:  *packagesFlag,
+		MappingsFile: *mappingsFileFlag,
+		ReportFile:   *reportFlag,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.WriteString(content)
+	return err
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/analyzerclient"
+)
+
+// DepEdge represents a dependency from one package to another
+type DepEdge = analyzerclient.DepEdge
+
+// AnalysisResult represents the outcome of a dependency analysis run
+type AnalysisResult = analyzerclient.AnalysisResult
+
+// GetImpactSet returns every package that directly or transitively depends on pkg
+func (a *DependencyAnalyzer) GetImpactSet(pkg string) ([]string, error) {
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, exists := packageDeps[pkg]; !exists {
+		return nil, fmt.Errorf("package %s not found in dependency graph", pkg)
+	}
+
+	return impactSet(packageDeps, pkg), nil
+}
+
+// FindShortestPath returns the shortest dependency path from one package to another
+func (a *DependencyAnalyzer) FindShortestPath(from, to string) ([]string, error) {
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range []string{from, to} {
+		if _, exists := packageDeps[pkg]; !exists {
+			return nil, fmt.Errorf("package %s not found in dependency graph", pkg)
+		}
+	}
+
+	path := shortestPath(packageDeps, from, to)
+	if path == nil {
+		return nil, fmt.Errorf("no dependency path from %s to %s", from, to)
+	}
+
+	return path, nil
+}
+
+// maxPathResults caps the number of paths returned by FindAllPaths
+const maxPathResults = 20
+
+// FindAllPaths returns every simple dependency path from one package to another of at most maxDepth hops,
+// capped at maxPathResults paths
+func (a *DependencyAnalyzer) FindAllPaths(from, to string, maxDepth int) ([][]string, error) {
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range []string{from, to} {
+		if _, exists := packageDeps[pkg]; !exists {
+			return nil, fmt.Errorf("package %s not found in dependency graph", pkg)
+		}
+	}
+
+	paths, truncated, limited := allPaths(packageDeps, from, to, maxDepth, maxPathResults)
+	if truncated {
+		a.warn("Some paths from %s to %s are longer than %d hops and were not explored; increase --max-depth to see them", from, to, maxDepth)
+	}
+	if limited {
+		a.warn("Showing the first %d paths; there may be more", maxPathResults)
+	}
+
+	return paths, nil
+}
+
+// allPaths enumerates simple paths with an iterative depth-first search. Each stack entry carries its own
+// path, so a package is only excluded from paths that already contain it. truncated reports whether a
+// path was cut off at maxDepth while the target was still reachable, and limited whether the search stopped
+// at limit paths with part of the graph still unexplored.
+func allPaths(packageDeps map[string]map[string]bool, from, to string, maxDepth, limit int) (paths [][]string, truncated, limited bool) {
+	paths = [][]string{}
+	stack := [][]string{{from}}
+	for len(stack) > 0 && len(paths) < limit {
+		path := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		current := path[len(path)-1]
+		if current == to && len(path) > 1 {
+			paths = append(paths, path)
+			continue
+		}
+
+		onPath := make(map[string]bool, len(path))
+		for _, pkg := range path {
+			onPath[pkg] = true
+		}
+
+		// Push in reverse sorted order so paths come out in sorted order
+		next := sortedKeys(packageDeps[current])
+		for i := len(next) - 1; i >= 0; i-- {
+			if onPath[next[i]] {
+				continue
+			}
+			if len(path) > maxDepth {
+				// One more hop would exceed maxDepth; note if the target could still be reached
+				if !truncated && shortestPath(packageDeps, next[i], to) != nil {
+					truncated = true
+				}
+				continue
+			}
+			extended := make([]string, len(path), len(path)+1)
+			copy(extended, path)
+			stack = append(stack, append(extended, next[i]))
+		}
+	}
+
+	return paths, truncated, len(stack) > 0
+}
+
+// impactSet walks the reversed graph to find all packages that reach pkg
+func impactSet(packageDeps map[string]map[string]bool, pkg string) []string {
+	// Build reverse edges
+	dependents := make(map[string][]string)
+	for source, targets := range packageDeps {
+		for target := range targets {
+			dependents[target] = append(dependents[target], source)
+		}
+	}
+
+	visited := map[string]bool{pkg: true}
+	queue := []string{pkg}
+	impacted := []string{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[current] {
+			if !visited[dependent] {
+				visited[dependent] = true
+				impacted = append(impacted, dependent)
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	sort.Strings(impacted)
+	return impacted
+}
+
+// shortestPath runs a breadth-first search from one package to another, returning nil if unreachable
+func shortestPath(packageDeps map[string]map[string]bool, from, to string) []string {
+	if from == to {
+		return []string{from}
+	}
+
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		// Visit neighbours in sorted order so the result is deterministic
+		for _, next := range sortedKeys(packageDeps[current]) {
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = current
+			if next == to {
+				path := []string{to}
+				for step := current; step != ""; step = previous[step] {
+					path = append([]string{step}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// detectCycles returns the groups of packages that depend on each other in a cycle, found as the strongly
+// connected components of the graph (Tarjan's algorithm). Each group and the list of groups are sorted.
+func detectCycles(packageDeps map[string]map[string]bool) [][]string {
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	stack := []string{}
+	cycles := [][]string{}
+
+	var visit func(pkg string)
+	visit = func(pkg string) {
+		index[pkg] = len(index)
+		lowLink[pkg] = index[pkg]
+		stack = append(stack, pkg)
+		onStack[pkg] = true
+
+		for _, next := range sortedKeys(packageDeps[pkg]) {
+			if _, seen := index[next]; !seen {
+				visit(next)
+				if lowLink[next] < lowLink[pkg] {
+					lowLink[pkg] = lowLink[next]
+				}
+			} else if onStack[next] && index[next] < lowLink[pkg] {
+				lowLink[pkg] = index[next]
+			}
+		}
+
+		if lowLink[pkg] != index[pkg] {
+			return
+		}
+		component := []string{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == pkg {
+				break
+			}
+		}
+		if len(component) > 1 || packageDeps[pkg][pkg] {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, pkg := range sortedKeys(packageDeps) {
+		if _, seen := index[pkg]; !seen {
+			visit(pkg)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// findCyclePaths runs a depth-first search that colours packages white (unvisited), grey (on the current path)
+// and black (done). Each dependency on a grey package closes a cycle, which is returned as the path from that
+// package back to itself, e.g. [A B A]. Packages and dependencies are visited in sorted order.
+func findCyclePaths(packageDeps map[string]map[string]bool) [][]string {
+	const (
+		white = iota
+		grey
+		black
+	)
+	colour := make(map[string]int)
+	path := []string{}
+	cycles := [][]string{}
+
+	var visit func(pkg string)
+	visit = func(pkg string) {
+		colour[pkg] = grey
+		path = append(path, pkg)
+		for _, next := range sortedKeys(packageDeps[pkg]) {
+			switch colour[next] {
+			case white:
+				visit(next)
+			case grey:
+				start := len(path) - 1
+				for path[start] != next {
+					start--
+				}
+				cycle := append([]string{}, path[start:]...)
+				cycles = append(cycles, append(cycle, next))
+			}
+		}
+		path = path[:len(path)-1]
+		colour[pkg] = black
+	}
+
+	for _, pkg := range sortedKeys(packageDeps) {
+		if colour[pkg] == white {
+			visit(pkg)
+		}
+	}
+	return cycles
+}
+
+// packageDepths returns the length of the longest dependency path from each package to a package without
+// dependencies, which has depth 0. Packages are included even if they have no edges. Edges that close a
+// cycle are ignored.
+func packageDepths(packages []string, edges []DepEdge) map[string]int {
+	deps := make(map[string][]string)
+	for _, edge := range edges {
+		deps[edge.Source] = append(deps[edge.Source], edge.Target)
+	}
+
+	depths := make(map[string]int)
+	visiting := make(map[string]bool)
+	var depth func(pkg string) int
+	depth = func(pkg string) int {
+		if d, done := depths[pkg]; done {
+			return d
+		}
+		visiting[pkg] = true
+		d := 0
+		for _, dep := range deps[pkg] {
+			if visiting[dep] {
+				continue
+			}
+			if depDepth := depth(dep) + 1; depDepth > d {
+				d = depDepth
+			}
+		}
+		visiting[pkg] = false
+		depths[pkg] = d
+		return d
+	}
+	for _, pkg := range packages {
+		depth(pkg)
+	}
+	return depths
+}
+
+// computeTransitiveClosure returns, for every package, the set of packages it depends on directly or
+// transitively
+func computeTransitiveClosure(packageDeps map[string]map[string]bool) map[string]map[string]bool {
+	closure := make(map[string]map[string]bool, len(packageDeps))
+	for pkg := range packageDeps {
+		reachable := make(map[string]bool)
+		queue := []string{pkg}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for next := range packageDeps[current] {
+				if !reachable[next] {
+					reachable[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		closure[pkg] = reachable
+	}
+	return closure
+}
+
+// graphEdges returns all edges of the package graph sorted by source then target
+func graphEdges(packageDeps map[string]map[string]bool) []DepEdge {
+	edges := []DepEdge{}
+	for _, source := range sortedKeys(packageDeps) {
+		for _, target := range sortedKeys(packageDeps[source]) {
+			edges = append(edges, DepEdge{Source: source, Target: target})
+		}
+	}
+	return edges
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// hookMarker identifies pre-commit hooks written by this tool
+const hookMarker = "# Installed by dependency_analyzer install-hooks"
+
+// preCommitHookTemplate is the pre-commit script; %s is the analyzer binary path
+const preCommitHookTemplate = `#!/bin/sh
+` + hookMarker + `
+# Blocks commits that introduce Alpha Dot Five dependency violations.
+# Set SKIP_DEP_CHECK=1 to bypass the check in an emergency.
+
+if [ "${SKIP_DEP_CHECK:-0}" = "1" ]; then
+    echo "⚠️ SKIP_DEP_CHECK=1 set, skipping dependency analysis" >&2
+    exit 0
+fi
+
+WORKSPACE="$(git rev-parse --show-toplevel)"
+
+if ! "%s" --workspace "$WORKSPACE"; then
+    echo "❌ Commit blocked: dependency violations found." >&2
+    echo "   Fix the violations above or re-run with SKIP_DEP_CHECK=1 to bypass." >&2
+    exit 1
+fi
+`
+
+// GitHooksDir returns the hooks directory of the git repository containing dir
+func GitHooksDir(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = dir
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error locating git hooks directory: %v", err)
+	}
+
+	hooksDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+
+	return hooksDir, nil
+}
+
+// PreCommitHookContent returns the pre-commit script that runs the given analyzer binary
+func PreCommitHookContent(analyzerPath string) string {
+	return fmt.Sprintf(preCommitHookTemplate, shellDoubleQuoteEscaper.Replace(analyzerPath))
+}
+
+// shellDoubleQuoteEscaper escapes the characters that keep their meaning inside a double-quoted shell string
+var shellDoubleQuoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`")
+
+// InstallPreCommitHook atomically writes an executable pre-commit hook into hooksDir
+func InstallPreCommitHook(hooksDir, analyzerPath string, force bool) (string, error) {
+	hookPath := filepath.Join(hooksDir, "pre-commit")
+
+	// Never clobber a hook we did not write unless explicitly asked to
+	if existing, err := ioutil.ReadFile(hookPath); err == nil {
+		if !strings.Contains(string(existing), hookMarker) && !force {
+			return "", fmt.Errorf("%s already exists and was not installed by this tool (use --force to overwrite)", hookPath)
+		}
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return "", fmt.Errorf("error creating hooks directory: %v", err)
+	}
+
+	// Write to a temporary file in the same directory and rename it into place
+	tmp, err := ioutil.TempFile(hooksDir, ".pre-commit-*")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary hook file: %v", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.WriteString(PreCommitHookContent(analyzerPath)); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("error writing hook: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("error writing hook: %v", err)
+	}
+
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		return "", fmt.Errorf("error making hook executable: %v", err)
+	}
+
+	if err := os.Rename(tmpPath, hookPath); err != nil {
+		return "", fmt.Errorf("error installing hook: %v", err)
+	}
+
+	return hookPath, nil
+}
+
+// UninstallPreCommitHook removes a pre-commit hook previously written by this tool
+func UninstallPreCommitHook(hooksDir string) (string, error) {
+	hookPath := filepath.Join(hooksDir, "pre-commit")
+
+	content, err := ioutil.ReadFile(hookPath)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("no pre-commit hook installed at %s", hookPath)
+	} else if err != nil {
+		return "", fmt.Errorf("error reading hook: %v", err)
+	}
+
+	if !strings.Contains(string(content), hookMarker) {
+		return "", fmt.Errorf("%s was not installed by this tool, refusing to remove it", hookPath)
+	}
+
+	if err := os.Remove(hookPath); err != nil {
+		return "", fmt.Errorf("error removing hook: %v", err)
+	}
+
+	return hookPath, nil
+}
+
+// runInstallHooks implements the install-hooks subcommand
+func runInstallHooks(args []string) error {
+	fs := flag.NewFlagSet("install-hooks", flag.ExitOnError)
+	workspaceFlag := fs.String("workspace", "", "Workspace root inside the git repository")
+	workspaceAutoDetectFlag := fs.Bool("workspace-auto-detect", true, workspaceAutoDetectUsage)
+	analyzerFlag := fs.String("analyzer", "", "Path to the dependency_analyzer binary the hook should run (default: this binary)")
+	uninstallFlag := fs.Bool("uninstall-hooks", false, "Remove a previously installed pre-commit hook")
+	forceFlag := fs.Bool("force", false, "Overwrite an existing pre-commit hook not installed by this tool")
+	fs.Parse(args)
+
+	workspaceRoot, err := resolveWorkspaceRoot(*workspaceFlag, *workspaceAutoDetectFlag)
+	if err != nil {
+		return err
+	}
+
+	hooksDir, err := GitHooksDir(workspaceRoot)
+	if err != nil {
+		return err
+	}
+
+	if *uninstallFlag {
+		hookPath, err := UninstallPreCommitHook(hooksDir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Removed pre-commit hook %s\n", hookPath)
+		return nil
+	}
+
+	analyzerPath := *analyzerFlag
+	if analyzerPath == "" {
+		analyzerPath, err = os.Executable()
+		if err != nil {
+			return fmt.Errorf("error locating dependency_analyzer binary: %v", err)
+		}
+	}
+	if !filepath.IsAbs(analyzerPath) {
+		analyzerPath, err = filepath.Abs(analyzerPath)
+		if err != nil {
+			return fmt.Errorf("error getting absolute path: %v", err)
+		}
+	}
+
+	hookPath, err := InstallPreCommitHook(hooksDir, analyzerPath, *forceFlag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Installed pre-commit hook %s\n", hookPath)
+	fmt.Println("   Use SKIP_DEP_CHECK=1 git commit to bypass it in an emergency.")
+	return nil
+}
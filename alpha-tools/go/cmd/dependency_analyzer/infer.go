@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// InferValidDeps reads every BUILD file under packagesDir and returns one rule for each package-to-package
+// dependency declared in a deps list, giving a starting point for a config that reflects the current state
+func InferValidDeps(packagesDir string) ([]ValidDependency, error) {
+	observed, err := observedDependencies(packagesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []ValidDependency{}
+	for _, source := range sortedKeys(observed) {
+		for _, target := range sortedKeys(observed[source]) {
+			rules = append(rules, ValidDependency{Source: source, Target: target})
+		}
+	}
+
+	return rules, nil
+}
+
+// observedDependencies counts the deps entries in BUILD files under packagesDir for each pair of packages
+func observedDependencies(packagesDir string) (map[string]map[string]int, error) {
+	// The packages directory name is the first label segment, e.g. //packages/UmbraCoreTypes
+	labelPrefix := "//" + filepath.Base(packagesDir) + "/"
+
+	observed := make(map[string]map[string]int)
+	err := filepath.Walk(packagesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || (info.Name() != "BUILD" && info.Name() != "BUILD.bazel") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(packagesDir, path)
+		if err != nil {
+			return err
+		}
+		sourcePkg := strings.Split(filepath.ToSlash(rel), "/")[0]
+		if sourcePkg == info.Name() {
+			return nil // BUILD file at the root of the packages directory
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading BUILD file: %v", err)
+		}
+
+		for _, rule := range ParseBuildRules(string(content)) {
+			for _, dep := range rule.Deps {
+				if !strings.HasPrefix(dep, labelPrefix) {
+					continue // Relative or external label
+				}
+				targetPkg := strings.SplitN(strings.TrimPrefix(dep, labelPrefix), "/", 2)[0]
+				targetPkg = strings.SplitN(targetPkg, ":", 2)[0]
+				if targetPkg == "" || targetPkg == sourcePkg {
+					continue
+				}
+				if observed[sourcePkg] == nil {
+					observed[sourcePkg] = make(map[string]int)
+				}
+				observed[sourcePkg][targetPkg]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning BUILD files: %v", err)
+	}
+
+	return observed, nil
+}
+
+// RuleGap is a dependency declared in BUILD files that no rule allows
+type RuleGap struct {
+	Source        string
+	Target        string
+	ObservedCount int // Number of deps entries declaring the dependency
+}
+
+// ValidateRules compares the dependencies declared in BUILD files with the configured rules and returns
+// every declared dependency the rules do not allow, most used first
+func (a *DependencyAnalyzer) ValidateRules() ([]RuleGap, error) {
+	observed, err := observedDependencies(a.PackagesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	gaps := []RuleGap{}
+	for _, source := range sortedKeys(observed) {
+		for _, target := range sortedKeys(observed[source]) {
+			if !a.IsDependencyValid(source, target) {
+				gaps = append(gaps, RuleGap{Source: source, Target: target, ObservedCount: observed[source][target]})
+			}
+		}
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool {
+		return gaps[i].ObservedCount > gaps[j].ObservedCount
+	})
+
+	return gaps, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// InvalidDependency is an invalid edge in a JSON report, with the targets its source may depend on instead
+type InvalidDependency struct {
+	Source            string   `json:"source"`
+	Target            string   `json:"target"`
+	ValidAlternatives []string `json:"validAlternatives"`
+}
+
+// JSONReport is the dependency report written by --output-format json
+type JSONReport struct {
+	Invalid      []InvalidDependency `json:"invalid"`
+	Valid        []DepEdge           `json:"valid"`
+	PackageCount int                 `json:"packageCount"`
+	Result       string              `json:"result"` // "pass" or "fail"
+}
+
+// JSONLDependency is one line of --output-format jsonl
+type JSONLDependency struct {
+	Source            string   `json:"source"`
+	Target            string   `json:"target"`
+	Valid             bool     `json:"valid"`
+	ValidAlternatives []string `json:"validAlternatives,omitempty"` // Only for invalid edges
+}
+
+// analyzeSorted analyzes the workspace with warnings on stderr, so stdout stays machine-readable, and returns
+// the edges sorted by source then target
+func (a *DependencyAnalyzer) analyzeSorted() (*AnalysisResult, error) {
+	a.messages = os.Stderr
+	defer func() { a.messages = nil }()
+
+	result, err := a.Analyze()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result.Edges, func(i, j int) bool {
+		if result.Edges[i].Source != result.Edges[j].Source {
+			return result.Edges[i].Source < result.Edges[j].Source
+		}
+		return result.Edges[i].Target < result.Edges[j].Target
+	})
+	return result, nil
+}
+
+// BuildJSONReport analyzes the workspace and returns the report for --output-format json
+func (a *DependencyAnalyzer) BuildJSONReport() (*JSONReport, error) {
+	result, err := a.analyzeSorted()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &JSONReport{
+		Invalid:      []InvalidDependency{},
+		Valid:        []DepEdge{},
+		PackageCount: len(result.Packages),
+		Result:       "pass",
+	}
+	for _, edge := range result.Edges {
+		if edge.Valid {
+			report.Valid = append(report.Valid, edge)
+			continue
+		}
+		report.Invalid = append(report.Invalid, InvalidDependency{
+			Source:            edge.Source,
+			Target:            edge.Target,
+			ValidAlternatives: a.GetValidDependenciesFor(edge.Source),
+		})
+	}
+	if result.InvalidCount+a.strictErrors > 0 {
+		report.Result = "fail"
+	}
+	return report, nil
+}
+
+// PrintJSONReport writes the dependency report to out as one indented JSON object. It returns false if any
+// dependency is invalid.
+func (a *DependencyAnalyzer) PrintJSONReport(out io.Writer) (bool, error) {
+	report, err := a.BuildJSONReport()
+	if err != nil {
+		return false, err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return false, fmt.Errorf("error writing JSON report: %v", err)
+	}
+	return report.Result == "pass", nil
+}
+
+// PrintJSONLReport writes every package dependency to out as a JSON line, sorted by source then target. It
+// returns false if any dependency is invalid.
+func (a *DependencyAnalyzer) PrintJSONLReport(out io.Writer) (bool, error) {
+	result, err := a.analyzeSorted()
+	if err != nil {
+		return false, err
+	}
+
+	encoder := json.NewEncoder(out)
+	for _, edge := range result.Edges {
+		line := JSONLDependency{Source: edge.Source, Target: edge.Target, Valid: edge.Valid}
+		if !edge.Valid {
+			line.ValidAlternatives = a.GetValidDependenciesFor(edge.Source)
+		}
+		if err := encoder.Encode(line); err != nil {
+			return false, fmt.Errorf("error writing dependency: %v", err)
+		}
+	}
+	return result.InvalidCount+a.strictErrors == 0, nil
+}
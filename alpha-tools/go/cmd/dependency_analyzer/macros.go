@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ruleLocationPattern matches the location comment that precedes each rule in --output=build
+	ruleLocationPattern = regexp.MustCompile(`(?m)^# (.+)/BUILD(?:\.bazel)?:\d+:\d+`)
+
+	// ruleNamePattern matches the name attribute of an expanded rule
+	ruleNamePattern = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
+
+	// ruleDepsPattern matches the deps list of an expanded rule
+	ruleDepsPattern = regexp.MustCompile(`(?s)\bdeps\s*=\s*\[(.*?)\]`)
+
+	// quotedStringPattern matches a Starlark string literal
+	quotedStringPattern = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// ExpandedRule represents a rule as Bazel sees it after macro expansion
+type ExpandedRule struct {
+	Label string
+	Deps  []string
+}
+
+// RunBazelBuildQuery runs a Bazel query with --output=build, which prints rules after macro expansion
+func (a *DependencyAnalyzer) RunBazelBuildQuery(query string) (string, error) {
+	output, err := a.runBazelisk("query", "--output=build", query)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+// ParseExpandedRules extracts rule labels and their effective deps from --output=build output
+func (a *DependencyAnalyzer) ParseExpandedRules(output string) []ExpandedRule {
+	rules := []ExpandedRule{}
+
+	locations := ruleLocationPattern.FindAllStringSubmatchIndex(output, -1)
+	for i, loc := range locations {
+		end := len(output)
+		if i+1 < len(locations) {
+			end = locations[i+1][0]
+		}
+		block := output[loc[1]:end]
+
+		// The package comes from the BUILD file location relative to the workspace
+		buildDir := output[loc[2]:loc[3]]
+		pkgPath, err := filepath.Rel(a.WorkspaceRoot, buildDir)
+		if err != nil || strings.HasPrefix(pkgPath, "..") {
+			continue
+		}
+		pkgPath = filepath.ToSlash(pkgPath)
+
+		nameMatch := ruleNamePattern.FindStringSubmatch(block)
+		if nameMatch == nil {
+			continue
+		}
+
+		rule := ExpandedRule{Label: fmt.Sprintf("//%s:%s", pkgPath, nameMatch[1])}
+		if depsMatch := ruleDepsPattern.FindStringSubmatch(block); depsMatch != nil {
+			for _, dep := range quotedStringPattern.FindAllStringSubmatch(depsMatch[1], -1) {
+				rule.Deps = append(rule.Deps, resolveLabel(pkgPath, dep[1]))
+			}
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// resolveLabel turns a label relative to pkgPath into an absolute label
+func resolveLabel(pkgPath, label string) string {
+	switch {
+	case strings.HasPrefix(label, "//"), strings.HasPrefix(label, "@"):
+		return label
+	case strings.HasPrefix(label, ":"):
+		return "//" + pkgPath + label
+	default:
+		return "//" + pkgPath + ":" + label
+	}
+}
+
+// buildExpandedPackageGraph builds the package graph from macro-expanded rules
+func (a *DependencyAnalyzer) buildExpandedPackageGraph() (map[string]map[string]bool, error) {
+	output, err := a.RunBazelBuildQuery("//packages/...")
+	if err != nil {
+		return nil, fmt.Errorf("error querying packages: %v", err)
+	}
+
+	packageDeps := make(map[string]map[string]bool)
+	for _, rule := range a.ParseExpandedRules(output) {
+		sourcePkg := a.ParseTargetPackage(rule.Label)
+		if sourcePkg == "" {
+			continue
+		}
+
+		if _, exists := packageDeps[sourcePkg]; !exists {
+			packageDeps[sourcePkg] = make(map[string]bool)
+		}
+
+		for _, dep := range rule.Deps {
+			targetPkg := a.ParseTargetPackage(dep)
+			if targetPkg != "" && targetPkg != sourcePkg && a.isKnownPackage(targetPkg) {
+				packageDeps[sourcePkg][targetPkg] = true
+			}
+		}
+	}
+
+	addDependencyNodes(packageDeps)
+	return packageDeps, nil
+}
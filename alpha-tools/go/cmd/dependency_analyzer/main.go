@@ -1,22 +1,30 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/deprules"
+	"github.com/mpy/umbracore/alpha-tools/pkg/querycache"
+	"github.com/mpy/umbracore/alpha-tools/pkg/workspace"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
 )
 
 // ValidDependency represents a valid dependency between packages
-type ValidDependency struct {
-	Source string
-	Target string
-}
+type ValidDependency = deprules.ValidDependency
 
 // BazelTarget represents a target returned by Bazel query
 type BazelTarget struct {
@@ -25,6 +33,11 @@ type BazelTarget struct {
 	Tag     []string `json:"tag,omitempty"`
 	Sources []string `json:"sources,omitempty"`
 	Deps    []string `json:"deps,omitempty"`
+
+	// Only reported by --output=proto
+	Hdrs              []string `json:"hdrs,omitempty"`
+	ModuleName        string   `json:"moduleName,omitempty"`        // Explicit module_name attribute
+	GeneratorFunction string   `json:"generatorFunction,omitempty"` // Macro that created the rule, e.g. umbra_swift_library
 }
 
 // BazelQueryResult represents the result of a Bazel query
@@ -32,56 +45,178 @@ type BazelQueryResult struct {
 	Target []BazelTarget `json:"target"`
 }
 
+// defaultQueryParallelism is the number of deps() queries run at once unless --query-parallelism says otherwise.
+// It is kept low so the queries do not overwhelm the Bazel server.
+const defaultQueryParallelism = 4
+
+// defaultQueryTimeout is how long a Bazel query may run unless --query-timeout says otherwise
+const defaultQueryTimeout = 5 * time.Minute
+
 // DependencyAnalyzer analyzes Bazel dependencies
 type DependencyAnalyzer struct {
-	WorkspaceRoot string
-	PackagesDir   string
-	ValidDeps     []ValidDependency
+	WorkspaceRoot     string
+	PackagesDir       string
+	ValidDeps         deprules.ValidDependencyRuleSet
+	RuleGroups        []DependencyRuleGroup
+	ADRs              []ADRReference
+	Strict            bool                        // Treat warnings as errors
+	ResolveMacros     bool                        // Read deps from macro-expanded rules instead of deps() queries
+	QueryCache        *querycache.BazelQueryCache // Caches query output between runs; queries always run if nil
+	QueryOutputFormat string                      // "json" (default) or "proto", which also reports hdrs and Swift attributes
+	RateLimiter       *RateLimiter                // Limits how often Bazel is invoked; unlimited if nil
+	Executor          querycache.Executor         // Runs bazelisk when there is no query cache; BazeliskExecutor if nil
+	UseCQuery         bool                        // Run queries with cquery so select() follows the build configuration
+	CQueryConfig      string                      // --config for cquery, if any
+	QueryParallelism  int                         // deps() queries BuildPackageGraph runs at once
+	NoCache           bool                        // Run every query, even one this analyzer has already run
+
+	mu           sync.Mutex // Serialises warnings, which parallel queries report
+	strictErrors int
+	messages     io.Writer       // Destination for warnings; stdout if nil
+	lastResult   *AnalysisResult // Result of the most recent Analyze call
+
+	results     map[string]*BazelQueryResult // RunBazelQuery results, by query and how it was run
+	resultsMu   sync.RWMutex
+	cacheHits   int64 // RunBazelQuery calls answered from results
+	cacheMisses int64 // RunBazelQuery calls that ran the query
 }
 
 // NewDependencyAnalyzer creates a new dependency analyzer
 func NewDependencyAnalyzer(workspaceRoot, packagesDir string) *DependencyAnalyzer {
-	// Define valid dependencies according to Alpha Dot Five structure
-	validDeps := []ValidDependency{
-		{"UmbraErrorKit", "UmbraCoreTypes"},
-		{"UmbraInterfaces", "UmbraCoreTypes"},
-		{"UmbraInterfaces", "UmbraErrorKit"},
-		{"UmbraUtils", "UmbraCoreTypes"},
-		{"UmbraImplementations", "UmbraInterfaces"},
-		{"UmbraImplementations", "UmbraCoreTypes"},
-		{"UmbraImplementations", "UmbraErrorKit"},
-		{"UmbraImplementations", "UmbraUtils"},
-		{"UmbraFoundationBridge", "UmbraCoreTypes"},
-		{"ResticKit", "UmbraInterfaces"},
-		{"ResticKit", "UmbraCoreTypes"},
-		{"ResticKit", "UmbraUtils"},
+	return &DependencyAnalyzer{
+		WorkspaceRoot:    workspaceRoot,
+		PackagesDir:      packagesDir,
+		ValidDeps:        deprules.Default(),
+		QueryParallelism: defaultQueryParallelism,
 	}
+}
 
+// withPackagesDir returns an analyzer with the same configuration for another packages directory. It
+// starts without query results, warnings or a last result of its own.
+func (a *DependencyAnalyzer) withPackagesDir(packagesDir string) *DependencyAnalyzer {
 	return &DependencyAnalyzer{
-		WorkspaceRoot: workspaceRoot,
-		PackagesDir:   packagesDir,
-		ValidDeps:     validDeps,
+		WorkspaceRoot:     a.WorkspaceRoot,
+		PackagesDir:       packagesDir,
+		ValidDeps:         a.ValidDeps,
+		RuleGroups:        a.RuleGroups,
+		ADRs:              a.ADRs,
+		Strict:            a.Strict,
+		ResolveMacros:     a.ResolveMacros,
+		QueryCache:        a.QueryCache,
+		QueryOutputFormat: a.QueryOutputFormat,
+		RateLimiter:       a.RateLimiter,
+		Executor:          a.Executor,
+		UseCQuery:         a.UseCQuery,
+		CQueryConfig:      a.CQueryConfig,
+		QueryParallelism:  a.QueryParallelism,
+		NoCache:           a.NoCache,
+		messages:          a.messages,
 	}
 }
 
-// RunBazelQuery runs a Bazel query and returns the result
+// RunBazelQuery runs a Bazel query, or a cquery if UseCQuery is set, and returns the result. Results are kept
+// for the analyzer's lifetime, so running the same query again does not invoke Bazel, unless NoCache is set.
 func (a *DependencyAnalyzer) RunBazelQuery(query string) (*BazelQueryResult, error) {
-	cmd := exec.Command("bazelisk", "query", "--output=json", query)
-	cmd.Dir = a.WorkspaceRoot
+	// How the query runs changes its result, so it is part of the key
+	key := fmt.Sprintf("%t\x00%s\x00%s\x00%s", a.UseCQuery, a.CQueryConfig, a.QueryOutputFormat, query)
+	if !a.NoCache {
+		a.resultsMu.RLock()
+		result, cached := a.results[key]
+		a.resultsMu.RUnlock()
+		if cached {
+			atomic.AddInt64(&a.cacheHits, 1)
+			return result, nil
+		}
+	}
+	atomic.AddInt64(&a.cacheMisses, 1)
+
+	result, err := a.runBazelQuery(query)
+	if err != nil || a.NoCache {
+		return result, err
+	}
+	a.resultsMu.Lock()
+	defer a.resultsMu.Unlock()
+	if a.results == nil {
+		a.results = make(map[string]*BazelQueryResult)
+	}
+	a.results[key] = result
+	return result, nil
+}
+
+// CacheStats returns how many RunBazelQuery calls were answered from the in-memory cache and how many ran
+// the query
+func (a *DependencyAnalyzer) CacheStats() (hits, misses int) {
+	return int(atomic.LoadInt64(&a.cacheHits)), int(atomic.LoadInt64(&a.cacheMisses))
+}
+
+// runBazelQuery runs a query or cquery, bypassing the in-memory cache
+func (a *DependencyAnalyzer) runBazelQuery(query string) (*BazelQueryResult, error) {
+	if a.UseCQuery {
+		return a.RunBazelCQuery(query, a.CQueryConfig)
+	}
+	if a.QueryOutputFormat == "proto" {
+		output, err := a.runBazelisk("query", "--output=proto", query)
+		if err != nil {
+			return nil, err
+		}
+		return parseQueryProto(output)
+	}
 
-	output, err := cmd.Output()
+	output, err := a.runBazelisk("query", "--output=json", query)
 	if err != nil {
-		return nil, fmt.Errorf("error running bazel query: %v: %v", err, string(output))
+		return nil, err
 	}
+	return parseQueryJSON(output)
+}
 
+// parseQueryJSON decodes the output of bazelisk query --output=json
+func parseQueryJSON(data []byte) (*BazelQueryResult, error) {
 	var result BazelQueryResult
-	if err := json.Unmarshal(output, &result); err != nil {
+	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("error parsing JSON output: %v", err)
 	}
-
 	return &result, nil
 }
 
+// runBazelisk runs bazelisk in the workspace, through the query cache if one is configured, once the rate
+// limiter allows it
+func (a *DependencyAnalyzer) runBazelisk(args ...string) ([]byte, error) {
+	if a.RateLimiter != nil {
+		start := time.Now()
+		if err := a.RateLimiter.Wait(context.Background()); err != nil {
+			return nil, fmt.Errorf("error waiting for rate limiter: %v", err)
+		}
+		if waited := time.Since(start); waited > throttleWarningThreshold {
+			log.Printf("Warning: Throttled Bazel query for %s (--qps %.2f, --burst %d)", waited.Round(time.Millisecond), a.RateLimiter.QueriesPerSecond, a.RateLimiter.Burst)
+		}
+	}
+
+	if a.QueryCache != nil {
+		return a.QueryCache.Query(a.WorkspaceRoot, args...)
+	}
+	if a.Executor != nil {
+		return a.Executor(a.WorkspaceRoot, args...)
+	}
+	return querycache.BazeliskExecutor(a.WorkspaceRoot, args...)
+}
+
+// warn reports a warning, which counts as an error in strict mode
+func (a *DependencyAnalyzer) warn(format string, args ...interface{}) {
+	out := a.messages
+	if out == nil {
+		out = os.Stdout
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.Strict {
+		a.strictErrors++
+		fmt.Fprintf(out, "❌ ERROR (strict): "+format+"\n", args...)
+		return
+	}
+	fmt.Fprintf(out, "⚠️ Warning: "+format+"\n", args...)
+}
+
 // ParseTargetPackage extracts the package name from a target
 func (a *DependencyAnalyzer) ParseTargetPackage(target string) string {
 	// Strip leading // and trailing :target if present
@@ -110,8 +245,22 @@ func (a *DependencyAnalyzer) IsDependencyValid(source, target string) bool {
 		return true // Self-dependencies are allowed
 	}
 
+	// Exact entries are checked before any pattern entries
 	for _, dep := range a.ValidDeps {
-		if dep.Source == source && dep.Target == target {
+		if !dep.IsPatternEntry && dep.Source == source && dep.Target == target {
+			return true
+		}
+	}
+
+	for _, dep := range a.ValidDeps {
+		if dep.IsPatternEntry && dep.Matches(source, target) {
+			return true
+		}
+	}
+
+	// Group rules are checked last
+	for _, group := range a.RuleGroups {
+		if group.Matches(source, target) {
 			return true
 		}
 	}
@@ -122,147 +271,270 @@ func (a *DependencyAnalyzer) IsDependencyValid(source, target string) bool {
 func (a *DependencyAnalyzer) GetValidDependenciesFor(pkg string) []string {
 	deps := []string{}
 	for _, dep := range a.ValidDeps {
-		if dep.Source == pkg {
+		if !dep.IsPatternEntry && dep.Source == pkg {
 			deps = append(deps, dep.Target)
 		}
 	}
+
+	// Pattern entries are listed by their target pattern
+	for _, dep := range a.ValidDeps {
+		if dep.IsPatternEntry && deprules.PatternMatches(dep.SourcePattern, pkg) {
+			deps = append(deps, dep.TargetPattern)
+		}
+	}
+
+	// Group rules are listed by their target suffix
+	for _, group := range a.RuleGroups {
+		if group.AppliesTo(pkg) {
+			deps = append(deps, "*"+group.TargetSuffix)
+		}
+	}
 	return deps
 }
 
-// AnalyzeDependencies analyzes dependencies between packages
-func (a *DependencyAnalyzer) AnalyzeDependencies() (bool, error) {
+// BuildPackageGraph queries Bazel and returns the dependencies between top-level packages.
+// Every package seen in the workspace has an entry, even if it has no dependencies.
+func (a *DependencyAnalyzer) BuildPackageGraph() (map[string]map[string]bool, error) {
+	if a.ResolveMacros {
+		return a.buildExpandedPackageGraph()
+	}
+
 	// Get all targets in packages directory
 	result, err := a.RunBazelQuery("//packages/...")
 	if err != nil {
-		return false, fmt.Errorf("error querying packages: %v", err)
-	}
-
-	if result == nil || len(result.Target) == 0 {
-		fmt.Println("No targets found in packages directory")
-		return true, nil
+		return nil, fmt.Errorf("error querying packages: %v", err)
 	}
 
 	// Track dependencies by package
 	packageDeps := make(map[string]map[string]bool)
+	if result == nil {
+		return packageDeps, nil
+	}
 
-	// Process each target
+	// Query each target's dependencies, at most QueryParallelism at once. A failed query is a warning, but one
+	// that timed out stops the analysis, since the Bazel server is likely overwhelmed.
+	// Every inner map is created before the first query starts, since the goroutines write into them.
 	for _, target := range result.Target {
 		sourcePkg := a.ParseTargetPackage(target.Name)
 		if sourcePkg == "" {
 			continue
 		}
-
-		// Initialize dependency map if needed
 		if _, exists := packageDeps[sourcePkg]; !exists {
 			packageDeps[sourcePkg] = make(map[string]bool)
 		}
+	}
 
-		// Query dependencies for this target
-		depsResult, err := a.RunBazelQuery(fmt.Sprintf("deps(%s)", target.Name))
-		if err != nil {
-			fmt.Printf("Warning: Error querying dependencies for %s: %v\n", target.Name, err)
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, a.queryParallelism())
+	group, ctx := errgroup.WithContext(context.Background())
+	for _, target := range result.Target {
+		target := target
+		sourcePkg := a.ParseTargetPackage(target.Name)
+		if sourcePkg == "" {
 			continue
 		}
 
-		for _, depTarget := range depsResult.Target {
-			targetPkg := a.ParseTargetPackage(depTarget.Name)
-			if targetPkg != "" && targetPkg != sourcePkg {
-				// Only track dependencies between Alpha Dot Five packages
-				// Check if it's a known package
-				isKnown := false
-				for _, dep := range a.ValidDeps {
-					if dep.Source == targetPkg || dep.Target == targetPkg {
-						isKnown = true
-						break
-					}
-				}
-				if isKnown || targetPkg == "UmbraCoreTypes" {
-					packageDeps[sourcePkg][targetPkg] = true
-				}
+		group.Go(func() error {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			defer func() { <-semaphore }()
+
+			targetPkgs, err := a.targetPackageDeps(target.Name, sourcePkg)
+			if errors.Is(err, querycache.ErrQueryTimeout) {
+				return fmt.Errorf("error querying dependencies for %s: %v", target.Name, err)
+			}
+			if err != nil {
+				a.warn("Error querying dependencies for %s: %v", target.Name, err)
+				return nil
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, targetPkg := range targetPkgs {
+				packageDeps[sourcePkg][targetPkg] = true
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	addDependencyNodes(packageDeps)
+	return packageDeps, nil
+}
+
+// queryParallelism returns how many queries BuildPackageGraph runs at once, at least one
+func (a *DependencyAnalyzer) queryParallelism() int {
+	if a.QueryParallelism < 1 {
+		return 1
+	}
+	return a.QueryParallelism
+}
+
+// targetPackageDeps returns the other Alpha Dot Five packages a target depends on
+func (a *DependencyAnalyzer) targetPackageDeps(targetName, sourcePkg string) ([]string, error) {
+	depsResult, err := a.RunBazelQuery(fmt.Sprintf("deps(%s)", targetName))
+	if err != nil {
+		return nil, err
+	}
+
+	targetPkgs := []string{}
+	for _, depTarget := range depsResult.Target {
+		targetPkg := a.ParseTargetPackage(depTarget.Name)
+		if targetPkg != "" && targetPkg != sourcePkg {
+			// Only track dependencies between Alpha Dot Five packages
+			if a.isKnownPackage(targetPkg) && !contains(targetPkgs, targetPkg) {
+				targetPkgs = append(targetPkgs, targetPkg)
 			}
 		}
 	}
 
-	// Validate dependencies
-	invalidCount := 0
-	for sourcePkg, deps := range packageDeps {
+	return targetPkgs, nil
+}
+
+// addDependencyNodes makes sure dependency-only packages appear as nodes too
+func addDependencyNodes(packageDeps map[string]map[string]bool) {
+	for _, deps := range packageDeps {
 		for targetPkg := range deps {
-			if !a.IsDependencyValid(sourcePkg, targetPkg) {
-				invalidCount++
-				fmt.Printf("❌ INVALID DEPENDENCY: %s depends on %s\n", sourcePkg, targetPkg)
-				fmt.Printf("   This violates the Alpha Dot Five dependency rules.\n")
-				fmt.Printf("   Valid dependencies for %s are:\n", sourcePkg)
-				for _, validDep := range a.GetValidDependenciesFor(sourcePkg) {
-					fmt.Printf("   - %s\n", validDep)
-				}
-				fmt.Println()
+			if _, exists := packageDeps[targetPkg]; !exists {
+				packageDeps[targetPkg] = make(map[string]bool)
 			}
 		}
 	}
+}
 
-	if invalidCount == 0 {
-		fmt.Println("✅ All dependencies conform to Alpha Dot Five structure.")
-		return true, nil
-	} else {
-		fmt.Printf("❌ Found %d invalid dependencies.\n", invalidCount)
-		return false, nil
+// isKnownPackage checks if a package takes part in the Alpha Dot Five rules
+func (a *DependencyAnalyzer) isKnownPackage(pkg string) bool {
+	if pkg == "UmbraCoreTypes" {
+		return true
 	}
+	for _, dep := range a.ValidDeps {
+		if dep.IsPatternEntry {
+			if deprules.PatternMatches(dep.SourcePattern, pkg) || deprules.PatternMatches(dep.TargetPattern, pkg) {
+				return true
+			}
+		} else if dep.Source == pkg || dep.Target == pkg {
+			return true
+		}
+	}
+	for _, group := range a.RuleGroups {
+		if strings.HasSuffix(pkg, group.SourceSuffix) || strings.HasSuffix(pkg, group.TargetSuffix) {
+			return true
+		}
+	}
+	return false
 }
 
-// GenerateDependencyGraph generates a DOT format dependency graph
-func (a *DependencyAnalyzer) GenerateDependencyGraph(outputFile string) error {
-	// Get all targets in packages directory
-	result, err := a.RunBazelQuery("//packages/...")
+// Analyze builds the package graph and classifies every edge as valid or invalid
+func (a *DependencyAnalyzer) Analyze() (*AnalysisResult, error) {
+	packageDeps, err := a.BuildPackageGraph()
 	if err != nil {
-		return fmt.Errorf("error querying packages: %v", err)
+		return nil, err
 	}
 
-	if result == nil || len(result.Target) == 0 {
-		return fmt.Errorf("no targets found in packages directory")
+	result := &AnalysisResult{
+		Packages: sortedKeys(packageDeps),
+		Edges:    []DepEdge{},
+	}
+	for _, edge := range graphEdges(packageDeps) {
+		edge.Valid = a.IsDependencyValid(edge.Source, edge.Target)
+		if !edge.Valid {
+			result.InvalidCount++
+		}
+		result.Edges = append(result.Edges, edge)
 	}
 
-	// Track dependencies by package
-	packageDeps := make(map[string]map[string]bool)
-	allPackages := make(map[string]bool)
+	a.lastResult = result
+	return result, nil
+}
 
-	// Process each target
-	for _, target := range result.Target {
-		sourcePkg := a.ParseTargetPackage(target.Name)
-		if sourcePkg == "" {
+// AnalyzeDependencies analyzes dependencies between packages
+func (a *DependencyAnalyzer) AnalyzeDependencies() (bool, error) {
+	a.strictErrors = 0
+	result, err := a.Analyze()
+	if err != nil {
+		return false, err
+	}
+
+	if len(result.Packages) == 0 {
+		fmt.Println("No targets found in packages directory")
+		return true, nil
+	}
+
+	// Report invalid dependencies
+	for _, edge := range result.Edges {
+		if edge.Valid {
 			continue
 		}
+		fmt.Printf("❌ INVALID DEPENDENCY: %s depends on %s\n", edge.Source, edge.Target)
+		fmt.Printf("   This violates the Alpha Dot Five dependency rules.\n")
+		fmt.Printf("   Valid dependencies for %s are:\n", edge.Source)
+		for _, validDep := range a.GetValidDependenciesFor(edge.Source) {
+			fmt.Printf("   - %s\n", validDep)
+		}
+		fmt.Println()
+	}
 
-		allPackages[sourcePkg] = true
+	// In strict mode every warning counts as a failure
+	invalidCount := result.InvalidCount + a.strictErrors
 
-		// Initialize dependency map if needed
-		if _, exists := packageDeps[sourcePkg]; !exists {
-			packageDeps[sourcePkg] = make(map[string]bool)
-		}
+	if invalidCount == 0 {
+		fmt.Println("✅ All dependencies conform to Alpha Dot Five structure.")
+		return true, nil
+	} else if a.strictErrors > 0 {
+		fmt.Printf("❌ Found %d invalid dependencies and %d warnings treated as errors (strict mode).\n", result.InvalidCount, a.strictErrors)
+		return false, nil
+	} else {
+		fmt.Printf("❌ Found %d invalid dependencies.\n", invalidCount)
+		return false, nil
+	}
+}
 
-		// Query dependencies for this target
-		depsResult, err := a.RunBazelQuery(fmt.Sprintf("deps(%s)", target.Name))
-		if err != nil {
-			fmt.Printf("Warning: Error querying dependencies for %s: %v\n", target.Name, err)
-			continue
-		}
+// packageColor returns the DOT fill color for a package based on its type
+func packageColor(pkg string) string {
+	switch pkg {
+	case "UmbraCoreTypes":
+		return "lightgreen"
+	case "UmbraErrorKit":
+		return "lightyellow"
+	case "UmbraInterfaces":
+		return "lightcoral"
+	}
+	return "lightblue"
+}
 
-		for _, depTarget := range depsResult.Target {
-			targetPkg := a.ParseTargetPackage(depTarget.Name)
-			if targetPkg != "" && targetPkg != sourcePkg {
-				// Only track dependencies between Alpha Dot Five packages
-				isKnown := false
-				for _, dep := range a.ValidDeps {
-					if dep.Source == targetPkg || dep.Target == targetPkg {
-						isKnown = true
-						break
-					}
-				}
-				if isKnown || targetPkg == "UmbraCoreTypes" {
-					packageDeps[sourcePkg][targetPkg] = true
-					allPackages[targetPkg] = true
-				}
-			}
+// GenerateDependencyGraph generates a dependency graph in DOT format, as D3.js JSON with format d3json, as a
+// PlantUML component diagram with format plantuml or as a Mermaid diagram with format mermaid. Format both
+// writes the DOT graph to outputFile and the Mermaid diagram next to it with a .mmd extension.
+func (a *DependencyAnalyzer) GenerateDependencyGraph(outputFile, format string) error {
+	switch format {
+	case "", "dot":
+	case "d3json":
+		return a.GenerateD3JSON(outputFile)
+	case "plantuml":
+		return a.GeneratePlantUML(outputFile)
+	case "mermaid":
+		return a.GenerateMermaidGraph(outputFile)
+	case "both":
+		if err := a.GenerateDependencyGraph(outputFile, "dot"); err != nil {
+			return err
 		}
+		return a.GenerateMermaidGraph(mermaidPath(outputFile))
+	default:
+		return fmt.Errorf("unknown graph format %q (expected dot, mermaid, both, d3json or plantuml)", format)
+	}
+
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return err
+	}
+
+	if len(packageDeps) == 0 {
+		return fmt.Errorf("no targets found in packages directory")
 	}
 
 	// Generate DOT file content
@@ -272,28 +544,17 @@ func (a *DependencyAnalyzer) GenerateDependencyGraph(outputFile string) error {
 	sb.WriteString("  node [shape=box, style=filled, fillcolor=lightblue];\n")
 
 	// Add nodes with different colors based on package type
-	for pkg := range allPackages {
-		color := "lightblue"
-		if pkg == "UmbraCoreTypes" {
-			color = "lightgreen"
-		} else if pkg == "UmbraErrorKit" {
-			color = "lightyellow"
-		} else if pkg == "UmbraInterfaces" {
-			color = "lightcoral"
-		}
-
-		sb.WriteString(fmt.Sprintf("  \"%s\" [fillcolor=%s];\n", pkg, color))
+	for _, pkg := range sortedKeys(packageDeps) {
+		sb.WriteString(fmt.Sprintf("  \"%s\" [fillcolor=%s];\n", pkg, packageColor(pkg)))
 	}
 
 	// Add edges
-	for source, targets := range packageDeps {
-		for target := range targets {
-			// Color invalid dependencies red
-			if a.IsDependencyValid(source, target) {
-				sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\";\n", source, target))
-			} else {
-				sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [color=red, penwidth=2.0];\n", source, target))
-			}
+	for _, edge := range graphEdges(packageDeps) {
+		// Color invalid dependencies red
+		if a.IsDependencyValid(edge.Source, edge.Target) {
+			sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\";\n", edge.Source, edge.Target))
+		} else {
+			sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [color=red, penwidth=2.0];\n", edge.Source, edge.Target))
 		}
 	}
 
@@ -310,46 +571,418 @@ func (a *DependencyAnalyzer) GenerateDependencyGraph(outputFile string) error {
 	return nil
 }
 
+// subcommands maps subcommand names to their handlers
+var subcommands = map[string]func(args []string) error{
+	"compare":            runCompare,
+	"coupling-report":    runCouplingReport,
+	"deduplicate-deps":   runDeduplicateDeps,
+	"explain":            runExplain,
+	"explain-cycle":      runExplainCycle,
+	"fix":                runFix,
+	"generate-gitlab-ci": runGenerateGitLabCI,
+	"generate-makefile":  runGenerateMakefile,
+	"install-hooks":      runInstallHooks,
+	"list-targets":       runListTargets,
+	"scorecard":          runScorecard,
+}
+
+// workspaceAutoDetectUsage describes the --workspace-auto-detect flag of the tool and its subcommands
+const workspaceAutoDetectUsage = "Without --workspace, use the nearest parent of the current directory with a WORKSPACE, WORKSPACE.bazel or MODULE.bazel file as the workspace root (if false, use the current directory)"
+
+// resolveWorkspaceRoot returns the workspace root passed with --workspace, or else the current directory or, with
+// autoDetect, its nearest parent that is a Bazel workspace
+func resolveWorkspaceRoot(workspaceFlag string, autoDetect bool) (string, error) {
+	if workspaceFlag != "" {
+		return workspaceFlag, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("error getting current directory: %v", err)
+	}
+	if !autoDetect {
+		return cwd, nil
+	}
+	workspaceRoot, err := workspace.FindWorkspaceRoot(cwd)
+	if err != nil {
+		return "", fmt.Errorf("error detecting workspace root: %v; pass --workspace or --workspace-auto-detect=false", err)
+	}
+	return workspaceRoot, nil
+}
+
 func main() {
+	// Dispatch to a subcommand if one was given
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				log.Fatalf("Error running %s: %v", os.Args[1], err)
+			}
+			return
+		}
+	}
+
 	workspaceFlag := flag.String("workspace", "", "Workspace root directory")
+	workspaceAutoDetectFlag := flag.Bool("workspace-auto-detect", true, workspaceAutoDetectUsage)
 	packagesFlag := flag.String("packages", "packages", "Packages directory relative to workspace")
 	graphFlag := flag.String("graph", "", "Generate dependency graph and save to specified file")
+	graphFormatFlag := flag.String("graph-format", "dot", "Format of the --graph file: dot, mermaid, both (dot, plus mermaid in a .mmd file next to it), d3json for D3.js force layouts with package metrics, or plantuml (graph formats go here, not on --output-format, which formats the dependency report)")
+	renderPNGFlag := flag.String("render-png", "", "Render the --graph DOT file to this PNG file with Graphviz dot")
+	renderSVGFlag := flag.String("render-svg", "", "Render the --graph DOT file to this SVG file with Graphviz dot")
+	renderPDFFlag := flag.String("render-pdf", "", "Render the --graph DOT file to this PDF file with Graphviz dot")
+	inferRulesFlag := flag.Bool("infer-rules", false, "Print a config with a rule for every package dependency declared in BUILD files")
+	reportFlag := flag.String("report", "", "Write a snapshot of the dependency graph and package metrics to this file for other tools")
+	formatFlag := flag.String("format", "json", "Format of the --report file: json, or proto for a binary DependencySnapshot message (alpha-tools/proto)")
+	csvMatrixFlag := flag.String("csv-matrix", "", "Write the package dependency matrix as CSV to this file, e.g. for spreadsheet heat maps")
+	htmlReportFlag := flag.String("html-report", "", "Write a self-contained interactive HTML dependency report to this file")
+	flag.StringVar(htmlReportFlag, "html", "", "Alias for --html-report")
+	compareBaselineFlag := flag.String("compare-baseline", "", "Fail if the dependency graph has edges not in this baseline snapshot")
+	updateBaselineFlag := flag.Bool("update-baseline", false, "Overwrite the --compare-baseline file with the current snapshot")
+	configFlag := flag.String("config", "", "YAML configuration file with additional dependency rules")
+	metricsFlag := flag.String("metrics", "", "Print the coupling, stability and size metrics of a package")
+	metricsJSONFlag := flag.Bool("metrics-json", false, "Print --metrics as JSON")
+	showRulesFlag := flag.String("show-effective-rules", "", "Print the rules that apply to a package and how they judge its dependencies")
+	resolveMacrosFlag := flag.Bool("resolve-macros", false, "Resolve custom macros (e.g., umbra_swift_library) to the deps of their expanded rules")
+	cacheDirFlag := flag.String("cache-dir", "", "Directory for cached Bazel query results (default ~/.cache/umbra/queries)")
+	cacheTTLFlag := flag.Duration("cache-ttl", 0, "Reuse cached Bazel query results younger than this (e.g., 10m); 0 disables the cache")
+	strictFlag := flag.Bool("strict", false, "Treat all warnings as errors (recommended for CI)")
+	slackWebhookFlag := flag.String("slack-webhook", "", "Post dependency violations to this Slack incoming webhook URL when the analysis completes")
+	reportURLFlag := flag.String("report-url", "", "Link to the HTML report to include in the Slack notification")
+	outputFormatFlag := flag.String("output-format", "text", "Output format for the dependency report: text, markdown, tsv, json or jsonl")
+	flag.StringVar(outputFormatFlag, "output", "text", "Alias for --output-format")
+	tsvHeadersFlag := flag.Bool("tsv-headers", false, "Print a header row with --output-format tsv")
+	streamFlag := flag.Bool("stream", false, "Write each dependency edge to stdout as a JSON line while analyzing; progress goes to stderr")
+	validateRulesFlag := flag.Bool("validate-rules", false, "List dependencies declared in BUILD files that no rule allows")
+	strictRulesFlag := flag.Bool("strict-rules", false, "Fail --validate-rules when any dependency is not covered by a rule")
+	qpsFlag := flag.Float64("qps", 0, "Maximum Bazel queries per second, e.g. 2.0 (0 disables rate limiting)")
+	burstFlag := flag.Int("burst", 5, "Bazel queries that may run back to back before --qps applies")
+	queryParallelismFlag := flag.Int("query-parallelism", defaultQueryParallelism, "Number of deps() queries to run at once")
+	bazelBinaryFlag := flag.String("bazel-binary", "", "Binary to run Bazel queries with (default bazelisk, or bazel if bazelisk is not on the PATH)")
+	noCacheFlag := flag.Bool("no-cache", false, "Run every Bazel query, even one already run in this invocation (for debugging)")
+	queryTimeoutFlag := flag.Duration("query-timeout", defaultQueryTimeout, "Kill a Bazel query that runs longer than this (0 disables the timeout)")
+	queryOutputFlag := flag.String("query-output", "json", "Bazel query output format: json, or proto for srcs, hdrs and Swift attributes")
+	useCQueryFlag := flag.Bool("use-cquery", false, "Query with bazelisk cquery so deps inside select() follow the build configuration")
+	cqueryConfigFlag := flag.String("cquery-config", "", "Bazel --config to pass to cquery with --use-cquery, e.g. macos")
+	validateNamesFlag := flag.Bool("validate-names", false, "Check that umbra_swift_library target names match their Swift module names")
+	allPathsFlag := flag.Bool("all-paths", false, "Print every dependency path from --from to --to instead of analyzing")
+	fromFlag := flag.String("from", "", "Package the paths printed by --all-paths start from")
+	toFlag := flag.String("to", "", "Package the paths printed by --all-paths end at")
+	maxDepthFlag := flag.Int("max-depth", 5, "Maximum number of hops in paths printed by --all-paths; with --depth-report, fail if a package is deeper than this (no limit unless set)")
+	depthReportFlag := flag.Bool("depth-report", false, "Print the longest dependency path from each package to a leaf package, deepest first")
+	checkCyclesFlag := flag.Bool("check-cycles", false, "Print every dependency cycle between packages and exit non-zero if there is any")
+	serveFlag := flag.String("serve", "", "Serve JSON-RPC 2.0 requests on the given address, with the rules and query settings of the other flags (e.g., :9876, which binds localhost only; use 0.0.0.0:9876 to accept remote connections)")
 
 	flag.Parse()
 
-	workspaceRoot := *workspaceFlag
-	if workspaceRoot == "" {
-		// Try to detect workspace root
-		var err error
-		workspaceRoot, err = os.Getwd()
+	workspaceRoot, err := resolveWorkspaceRoot(*workspaceFlag, *workspaceAutoDetectFlag)
+	if err != nil {
+		log.Fatalf("Error resolving workspace root: %v", err)
+	}
+
+	packagesDir := filepath.Join(workspaceRoot, *packagesFlag)
+
+	// Print the dependencies declared in BUILD files as a starting config if requested
+	if *inferRulesFlag {
+		rules, err := InferValidDeps(packagesDir)
 		if err != nil {
-			log.Fatalf("Error getting current directory: %v", err)
+			log.Fatalf("Error inferring rules: %v", err)
 		}
+		fmt.Printf("# Inferred from BUILD files in %s. Prune to the dependencies you want to allow.\n", packagesDir)
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(AnalyzerConfig{Rules: rules}); err != nil {
+			log.Fatalf("Error encoding rules: %v", err)
+		}
+		return
 	}
 
-	// Validate workspace root
-	if _, err := os.Stat(filepath.Join(workspaceRoot, "WORKSPACE")); err != nil && !os.IsNotExist(err) {
-		log.Printf("Warning: Could not find WORKSPACE file in %s", workspaceRoot)
+	// Validate target names instead of dependencies if requested; the proto output format needs a query
+	if *validateNamesFlag && *queryOutputFlag != "proto" {
+		mismatches, err := ValidateTargetNames(packagesDir)
+		if err != nil {
+			log.Fatalf("Error validating target names: %v", err)
+		}
+		if !printNameMismatches(mismatches) {
+			os.Exit(1)
+		}
+		return
 	}
 
-	packagesDir := filepath.Join(workspaceRoot, *packagesFlag)
-
 	analyzer := NewDependencyAnalyzer(workspaceRoot, packagesDir)
+	analyzer.Strict = *strictFlag
+	analyzer.ResolveMacros = *resolveMacrosFlag
+	analyzer.QueryOutputFormat = *queryOutputFlag
+	analyzer.UseCQuery = *useCQueryFlag
+	analyzer.CQueryConfig = *cqueryConfigFlag
+	if *cqueryConfigFlag != "" && !*useCQueryFlag {
+		log.Fatal("--cquery-config requires --use-cquery")
+	}
+	if *useCQueryFlag && *resolveMacrosFlag {
+		log.Fatal("--use-cquery cannot be combined with --resolve-macros, which reads BUILD output that only query produces")
+	}
+	if *qpsFlag > 0 {
+		analyzer.RateLimiter = NewRateLimiter(*qpsFlag, *burstFlag)
+	}
+	if *queryParallelismFlag < 1 {
+		log.Fatal("--query-parallelism must be at least 1")
+	}
+	analyzer.QueryParallelism = *queryParallelismFlag
+	analyzer.NoCache = *noCacheFlag
+	querycache.BazelBinary = *bazelBinaryFlag
+	analyzer.Executor = querycache.BazeliskExecutorWithTimeout(*queryTimeoutFlag)
+
+	if *cacheTTLFlag > 0 {
+		cache, err := querycache.New(*cacheDirFlag, *cacheTTLFlag)
+		if err != nil {
+			log.Fatalf("Error setting up query cache: %v", err)
+		}
+		cache.Execute = analyzer.Executor
+		analyzer.QueryCache = cache
+	}
+
+	if *configFlag != "" {
+		config, err := LoadAnalyzerConfig(*configFlag)
+		if err != nil {
+			log.Fatalf("Error loading config: %v", err)
+		}
+		analyzer.ApplyConfig(config)
+	}
+
+	switch *queryOutputFlag {
+	case "json", "proto":
+	default:
+		log.Fatalf("Unknown query output format %q (expected json or proto)", *queryOutputFlag)
+	}
+
+	// Run as a JSON-RPC server for editor integrations if requested
+	if *serveFlag != "" {
+		server := NewAnalyzerServer(analyzer)
+		log.Fatal(server.ListenAndServe(*serveFlag))
+	}
+
+	if *validateNamesFlag {
+		mismatches, err := analyzer.ValidateTargetNamesFromQuery()
+		if err != nil {
+			log.Fatalf("Error validating target names: %v", err)
+		}
+		if !printNameMismatches(mismatches) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Print every path between two packages if requested
+	if *allPathsFlag {
+		if *fromFlag == "" || *toFlag == "" {
+			log.Fatal("Required flags: --from and --to with --all-paths")
+		}
+		paths, err := analyzer.FindAllPaths(*fromFlag, *toFlag, *maxDepthFlag)
+		if err != nil {
+			log.Fatalf("Error finding paths: %v", err)
+		}
+		if len(paths) == 0 {
+			fmt.Printf("No dependency path from %s to %s within %d hops\n", *fromFlag, *toFlag, *maxDepthFlag)
+			return
+		}
+		fmt.Printf("%d dependency paths from %s to %s:\n", len(paths), *fromFlag, *toFlag)
+		for _, path := range paths {
+			fmt.Printf("  %s\n", strings.Join(path, " → "))
+		}
+		return
+	}
+
+	// Print how deep each package is in the dependency graph if requested
+	if *depthReportFlag {
+		depths, err := analyzer.ComputeDepths()
+		if err != nil {
+			log.Fatalf("Error computing depths: %v", err)
+		}
+		// --max-depth defaults to a limit for --all-paths, so it is only a threshold here when given
+		maxDepth := -1
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "max-depth" {
+				maxDepth = *maxDepthFlag
+			}
+		})
+		if !printDepthReport(depths, maxDepth) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Check the package graph for dependency cycles if requested
+	if *checkCyclesFlag {
+		cycles, err := analyzer.DetectCycles()
+		if err != nil {
+			log.Fatalf("Error detecting cycles: %v", err)
+		}
+		if !printCycles(cycles) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Check the rules against the dependencies declared in BUILD files if requested
+	if *validateRulesFlag {
+		gaps, err := analyzer.ValidateRules()
+		if err != nil {
+			log.Fatalf("Error validating rules: %v", err)
+		}
+		if len(gaps) == 0 {
+			fmt.Println("✅ Every declared dependency is covered by a rule.")
+			return
+		}
+		for _, gap := range gaps {
+			fmt.Printf("⚠️ No rule allows %s -> %s (declared %d times)\n", gap.Source, gap.Target, gap.ObservedCount)
+		}
+		fmt.Printf("Found %d declared dependencies without a rule. Add rules for the legitimate ones to your config.\n", len(gaps))
+		if *strictRulesFlag {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Print effective rules for a package if requested
+	if *showRulesFlag != "" {
+		if err := analyzer.ShowEffectiveRules(*showRulesFlag); err != nil {
+			log.Fatalf("Error showing effective rules: %v", err)
+		}
+		return
+	}
+
+	// Print the metrics of a package if requested
+	if *metricsFlag != "" {
+		metrics, err := analyzer.ComputeAllMetrics(*metricsFlag)
+		if err != nil {
+			log.Fatalf("Error computing metrics: %v", err)
+		}
+		if *metricsJSONFlag {
+			fmt.Println(string(metrics.JSON()))
+		} else {
+			fmt.Println(metrics)
+		}
+		return
+	}
 
 	// Generate dependency graph if requested
 	if *graphFlag != "" {
-		if err := analyzer.GenerateDependencyGraph(*graphFlag); err != nil {
+		if err := analyzer.GenerateDependencyGraph(*graphFlag, *graphFormatFlag); err != nil {
 			log.Fatalf("Error generating dependency graph: %v", err)
 		}
 	}
 
+	// Render the DOT graph with Graphviz if requested
+	renderings := map[string]string{"png": *renderPNGFlag, "svg": *renderSVGFlag, "pdf": *renderPDFFlag}
+	for _, format := range []string{"png", "svg", "pdf"} {
+		if renderings[format] == "" {
+			continue
+		}
+		if *graphFlag == "" || (*graphFormatFlag != "" && *graphFormatFlag != "dot" && *graphFormatFlag != "both") {
+			log.Fatalf("--render-%s requires --graph with the dot graph format", format)
+		}
+		if err := RenderGraph(*graphFlag, renderings[format], format); err != nil {
+			log.Fatalf("Error rendering dependency graph: %v", err)
+		}
+	}
+
+	// Write the dependency report for other tools if requested
+	if *reportFlag != "" {
+		if err := analyzer.GenerateReport(*reportFlag, *formatFlag); err != nil {
+			log.Fatalf("Error generating report: %v", err)
+		}
+	}
+
+	// Export the dependency matrix if requested
+	if *csvMatrixFlag != "" {
+		if err := analyzer.ExportAsCSV(*csvMatrixFlag); err != nil {
+			log.Fatalf("Error exporting dependency matrix: %v", err)
+		}
+	}
+
+	// Generate interactive HTML report if requested
+	if *htmlReportFlag != "" {
+		if err := analyzer.GenerateHTMLReport(*htmlReportFlag); err != nil {
+			log.Fatalf("Error generating HTML report: %v", err)
+		}
+	}
+
+	// finish notifies the team channel about violations and exits with a failure if the analysis found any
+	finish := func(valid bool) {
+		if *slackWebhookFlag != "" && !*updateBaselineFlag {
+			if err := analyzer.NotifySlack(*slackWebhookFlag, *compareBaselineFlag, *reportURLFlag); err != nil {
+				log.Printf("Warning: Error sending Slack notification: %v", err)
+			}
+		}
+		if !valid {
+			os.Exit(1)
+		}
+	}
+
+	// Compare against or update a committed baseline snapshot
+	if *compareBaselineFlag != "" {
+		if *updateBaselineFlag {
+			snapshot, err := analyzer.CaptureSnapshot()
+			if err != nil {
+				log.Fatalf("Error capturing snapshot: %v", err)
+			}
+			if err := SaveSnapshot(*compareBaselineFlag, snapshot); err != nil {
+				log.Fatalf("Error updating baseline: %v", err)
+			}
+			fmt.Printf("Baseline %s updated with %d edges.\n", *compareBaselineFlag, len(snapshot.Edges))
+			return
+		}
+
+		matches, err := analyzer.CompareBaseline(*compareBaselineFlag)
+		if err != nil {
+			log.Fatalf("Error comparing baseline: %v", err)
+		}
+		finish(matches)
+		return
+	}
+
+	// Stream edges as JSON Lines for large workspaces
+	if *streamFlag {
+		valid, err := analyzer.StreamDependencies(os.Stdout, os.Stderr)
+		if err != nil {
+			log.Fatalf("Error analyzing dependencies: %v", err)
+		}
+		finish(valid)
+		return
+	}
+
+	// Print a Markdown table for PR descriptions, TSV rows for scripts or JSON for CI pipelines if requested
+	switch *outputFormatFlag {
+	case "markdown", "tsv":
+		report := analyzer.PrintMarkdownReport
+		if *outputFormatFlag == "tsv" {
+			report = func() (bool, error) { return analyzer.PrintTSVReport(*tsvHeadersFlag) }
+		}
+		valid, err := report()
+		if err != nil {
+			log.Fatalf("Error analyzing dependencies: %v", err)
+		}
+		finish(valid)
+		return
+	case "json", "jsonl":
+		report := analyzer.PrintJSONReport
+		if *outputFormatFlag == "jsonl" {
+			report = analyzer.PrintJSONLReport
+		}
+		valid, err := report(os.Stdout)
+		if err != nil {
+			log.Fatalf("Error analyzing dependencies: %v", err)
+		}
+		finish(valid)
+		return
+	case "text":
+	default:
+		log.Fatalf("Unknown output format %q (expected text, markdown, tsv, json or jsonl)", *outputFormatFlag)
+	}
+
 	// Analyze dependencies
 	valid, err := analyzer.AnalyzeDependencies()
 	if err != nil {
 		log.Fatalf("Error analyzing dependencies: %v", err)
 	}
 
-	if !valid {
-		os.Exit(1)
-	}
+	finish(valid)
 }
@@ -0,0 +1,495 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/alphapb"
+	"github.com/mpy/umbracore/alpha-tools/pkg/analyzerclient"
+	"github.com/mpy/umbracore/alpha-tools/pkg/deprules"
+	"google.golang.org/protobuf/proto"
+)
+
+func FuzzParseTargetPackage(f *testing.F) {
+	for _, seed := range []string{
+		"//packages/UmbraCoreTypes:UmbraCoreTypes",
+		"//packages/UmbraCoreTypes/Sources/CoreDTOs:CoreDTOs",
+		"//packages/UmbraErrorKit",
+		"packages/UmbraUtils:UmbraUtils",
+		"@rules_swift//swift:swift",
+		"//Sources/CoreDTOs:CoreDTOs",
+		"",
+		"//",
+		":",
+		"packages/",
+		"//packages/",
+		"//packages:packages",
+		"//packages/A:b:c:d",
+		"//packages/:Empty",
+		"//packages/Ümbra/Sources/日本:目标",
+		"//packages/\x00/\xff:\xfe",
+	} {
+		f.Add(seed)
+	}
+
+	analyzer := NewDependencyAnalyzer("", "")
+	f.Fuzz(func(t *testing.T, label string) {
+		pkg := analyzer.ParseTargetPackage(label)
+		if strings.ContainsAny(pkg, "/:") {
+			t.Errorf("ParseTargetPackage(%q) = %q, which contains a separator", label, pkg)
+		}
+		if pkg != "" && !strings.Contains(label, "packages/"+pkg) {
+			t.Errorf("ParseTargetPackage(%q) = %q, which is not in the label", label, pkg)
+		}
+	})
+}
+
+func FuzzUnmarshalBazelQueryResult(f *testing.F) {
+	valid := `{"target": [{"name": "//packages/UmbraCoreTypes:UmbraCoreTypes", "rule": "swift_library", "deps": ["//packages/UmbraCoreTypes/Sources/CoreDTOs:CoreDTOs"], "tag": ["manual"], "srcs": ["A.swift"]}]}`
+	for _, seed := range []string{
+		valid,
+		valid[:len(valid)/2],
+		valid[:len(valid)-1],
+		`{"target": []}`,
+		`{"target": null}`,
+		`{}`,
+		`[]`,
+		`null`,
+		``,
+		`{"target": [{"name": 42}]}`,
+		`{"target": [{"deps": "//packages/A"}]}`,
+		`{"target": [{"name": "Ümbra\ud800"}]}`,
+		"{\"target\": [{\"name\": \"\xff\"}]}",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		result, err := parseQueryJSON(data)
+		if err != nil {
+			return
+		}
+
+		// Whatever decoded must survive a round trip
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("error encoding decoded result: %v", err)
+		}
+		decoded, err := parseQueryJSON(encoded)
+		if err != nil {
+			t.Fatalf("error decoding re-encoded result %s: %v", encoded, err)
+		}
+		if len(decoded.Target) != len(result.Target) {
+			t.Errorf("round trip changed the number of targets from %d to %d", len(result.Target), len(decoded.Target))
+		}
+	})
+}
+
+func TestInstallHooks(t *testing.T) {
+	repo := t.TempDir()
+	if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, output)
+	}
+	hookPath := filepath.Join(repo, ".git", "hooks", "pre-commit")
+
+	// A stand-in analyzer in a directory whose name the hook must quote correctly
+	analyzerDir := filepath.Join(t.TempDir(), `tools "$HOME" \`+"`x`")
+	if err := os.MkdirAll(analyzerDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	analyzerPath := filepath.Join(analyzerDir, "dependency_analyzer")
+	if err := ioutil.WriteFile(analyzerPath, []byte("#!/bin/sh\necho \"analyzer $*\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A hook this tool did not write is only replaced with --force
+	foreign := "#!/bin/sh\necho mine\n"
+	if err := os.MkdirAll(filepath.Dir(hookPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(hookPath, []byte(foreign), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := runInstallHooks([]string{"--workspace", repo, "--analyzer", analyzerPath}); err == nil {
+		t.Fatal("overwrote a foreign pre-commit hook without --force")
+	}
+	if content, _ := ioutil.ReadFile(hookPath); string(content) != foreign {
+		t.Fatalf("foreign hook changed to:\n%s", content)
+	}
+
+	if err := runInstallHooks([]string{"--workspace", repo, "--analyzer", analyzerPath, "--force"}); err != nil {
+		t.Fatalf("install-hooks --force: %v", err)
+	}
+	content, err := ioutil.ReadFile(hookPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fragment := range []string{"SKIP_DEP_CHECK", `--workspace "$WORKSPACE"`, hookMarker} {
+		if !strings.Contains(string(content), fragment) {
+			t.Errorf("hook does not contain %s:\n%s", fragment, content)
+		}
+	}
+	info, err := os.Stat(hookPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0755 {
+		t.Errorf("hook mode is %o, want 755", mode)
+	}
+
+	// The hook runs the analyzer at its exact path with the workspace
+	hook := exec.Command(hookPath)
+	hook.Dir = repo
+	output, err := hook.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running hook: %v: %s", err, output)
+	}
+	if !strings.Contains(string(output), "analyzer --workspace") {
+		t.Errorf("hook did not run the analyzer, output:\n%s", output)
+	}
+
+	if err := runInstallHooks([]string{"--workspace", repo, "--uninstall-hooks"}); err != nil {
+		t.Fatalf("install-hooks --uninstall-hooks: %v", err)
+	}
+	if _, err := os.Stat(hookPath); !os.IsNotExist(err) {
+		t.Errorf("hook still exists after --uninstall-hooks: %v", err)
+	}
+}
+
+func TestAllPathsLimit(t *testing.T) {
+	// Two paths from A to D, and a third through E when E is in the graph
+	deps := map[string][]string{
+		"A": {"B", "C"},
+		"B": {"D"},
+		"C": {"D"},
+	}
+	packageDeps := func(extra bool) map[string]map[string]bool {
+		graph := make(map[string]map[string]bool)
+		for source, targets := range deps {
+			graph[source] = make(map[string]bool)
+			for _, target := range targets {
+				graph[source][target] = true
+			}
+		}
+		if extra {
+			graph["A"]["E"] = true
+			graph["E"] = map[string]bool{"D": true}
+		}
+		return graph
+	}
+
+	tests := []struct {
+		name        string
+		extra       bool
+		wantPaths   int
+		wantLimited bool
+	}{
+		{name: "exactly the limit", extra: false, wantPaths: 2, wantLimited: false},
+		{name: "more than the limit", extra: true, wantPaths: 2, wantLimited: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, _, limited := allPaths(packageDeps(test.extra), "A", "D", 10, 2)
+			if len(paths) != test.wantPaths || limited != test.wantLimited {
+				t.Errorf("got %d paths, limited %v; want %d, %v", len(paths), limited, test.wantPaths, test.wantLimited)
+			}
+		})
+	}
+}
+
+func TestDefaultRulesMatchShared(t *testing.T) {
+	// The migration helper checks against deprules.Default too, so the two tools only agree while this holds
+	onlyAnalyzer, onlyShared := deprules.CompareValidDepsLists(NewDependencyAnalyzer("", "").ValidDeps, deprules.Default())
+	if len(onlyAnalyzer) > 0 || len(onlyShared) > 0 {
+		t.Errorf("analyzer default rules diverge from deprules.Default: only in the analyzer %v, only in the shared rules %v", onlyAnalyzer, onlyShared)
+	}
+}
+
+func TestFindCyclePaths(t *testing.T) {
+	tests := []struct {
+		name string
+		deps map[string][]string
+		want [][]string
+	}{
+		{
+			name: "no cycles",
+			deps: map[string][]string{
+				"UmbraImplementations": {"UmbraInterfaces", "UmbraErrorKit"},
+				"UmbraInterfaces":      {"UmbraCoreTypes"},
+				"UmbraErrorKit":        {"UmbraCoreTypes"},
+			},
+			want: [][]string{},
+		},
+		{
+			name: "two-node cycle",
+			deps: map[string][]string{
+				"UmbraInterfaces":      {"UmbraImplementations", "UmbraCoreTypes"},
+				"UmbraImplementations": {"UmbraInterfaces"},
+			},
+			want: [][]string{{"UmbraImplementations", "UmbraInterfaces", "UmbraImplementations"}},
+		},
+		{
+			name: "longer cycle",
+			deps: map[string][]string{
+				"A": {"B"},
+				"B": {"C"},
+				"C": {"D"},
+				"D": {"B", "E"},
+			},
+			want: [][]string{{"B", "C", "D", "B"}},
+		},
+		{
+			name: "self dependency",
+			deps: map[string][]string{"A": {"A"}},
+			want: [][]string{{"A", "A"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			packageDeps := make(map[string]map[string]bool)
+			for source, targets := range test.deps {
+				packageDeps[source] = make(map[string]bool)
+				for _, target := range targets {
+					packageDeps[source][target] = true
+				}
+			}
+
+			got := findCyclePaths(packageDeps)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("findCyclePaths() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRunBazelQueryCache(t *testing.T) {
+	spawned := []string{} // Queries that reached the executor, which spawns bazelisk
+	newAnalyzer := func() *DependencyAnalyzer {
+		analyzer := NewDependencyAnalyzer("", "")
+		analyzer.Executor = func(workspaceRoot string, args ...string) ([]byte, error) {
+			spawned = append(spawned, args[len(args)-1])
+			return []byte(`{"target": [{"name": "//packages/UmbraCoreTypes:UmbraCoreTypes"}]}`), nil
+		}
+		return analyzer
+	}
+
+	analyzer := newAnalyzer()
+	for _, query := range []string{"//packages/...", "//packages/...", "deps(//packages/...)", "//packages/..."} {
+		if _, err := analyzer.RunBazelQuery(query); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if expected := []string{"//packages/...", "deps(//packages/...)"}; !reflect.DeepEqual(spawned, expected) {
+		t.Errorf("spawned bazelisk for %v, want %v", spawned, expected)
+	}
+	if hits, misses := analyzer.CacheStats(); hits != 2 || misses != 2 {
+		t.Errorf("got %d hits and %d misses, want 2 and 2", hits, misses)
+	}
+
+	// The same query with another output format is a different query
+	analyzer.QueryOutputFormat = "proto"
+	analyzer.Executor = func(workspaceRoot string, args ...string) ([]byte, error) {
+		spawned = append(spawned, strings.Join(args, " "))
+		return nil, nil
+	}
+	if _, err := analyzer.RunBazelQuery("//packages/..."); err != nil {
+		t.Fatal(err)
+	}
+	if len(spawned) != 3 || spawned[2] != "query --output=proto //packages/..." {
+		t.Errorf("spawned bazelisk for %v, want a proto query last", spawned)
+	}
+
+	spawned = nil
+	analyzer = newAnalyzer()
+	analyzer.NoCache = true
+	for i := 0; i < 2; i++ {
+		if _, err := analyzer.RunBazelQuery("//packages/..."); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(spawned) != 2 {
+		t.Errorf("spawned bazelisk %d times with NoCache, want 2", len(spawned))
+	}
+	if hits, misses := analyzer.CacheStats(); hits != 0 || misses != 2 {
+		t.Errorf("got %d hits and %d misses with NoCache, want 0 and 2", hits, misses)
+	}
+}
+
+func TestResolveWorkspaceRoot(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspaceDir := filepath.Join(root, "workspace")
+	nestedDir := filepath.Join(workspaceDir, "packages", "UmbraCoreTypes")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(workspaceDir, "WORKSPACE"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+	if err := os.Chdir(nestedDir); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		flag       string
+		autoDetect bool
+		expected   string
+	}{
+		{"flag wins", "/some/workspace", true, "/some/workspace"},
+		{"auto-detect finds the parent workspace", "", true, workspaceDir},
+		{"no auto-detect uses the current directory", "", false, nestedDir},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspaceRoot, err := resolveWorkspaceRoot(tt.flag, tt.autoDetect)
+			if err != nil {
+				t.Fatalf("resolveWorkspaceRoot() error = %v", err)
+			}
+			if workspaceRoot != tt.expected {
+				t.Errorf("resolveWorkspaceRoot() = %s, want %s", workspaceRoot, tt.expected)
+			}
+		})
+	}
+
+	// Outside any workspace, auto-detection fails instead of guessing
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resolveWorkspaceRoot("", true); err == nil || !strings.Contains(err.Error(), "--workspace-auto-detect=false") {
+		t.Errorf("resolveWorkspaceRoot() outside a workspace error = %v, want a hint to pass --workspace", err)
+	}
+}
+
+func TestAnalyzerServerRoundTrip(t *testing.T) {
+	// The fixture's dependencies are only valid under the configured rules, not the default ones
+	server := httptest.NewServer(NewAnalyzerServer(newBenchAnalyzer()))
+	defer server.Close()
+	client := analyzerclient.NewAnalyzerClient(server.URL)
+
+	result, err := client.Analyze("")
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(result.Packages) != len(fixture.graph) || result.InvalidCount != 0 {
+		t.Errorf("Analyze() = %d packages, %d invalid; want %d packages, 0 invalid", len(result.Packages), result.InvalidCount, len(fixture.graph))
+	}
+	if _, err := client.Analyze("packages"); err != nil {
+		t.Errorf("Analyze(packages) error = %v", err)
+	}
+	for _, packages := range []string{"..", "../other", "packages/../../other", "/etc"} {
+		var rpcErr *analyzerclient.RPCError
+		if _, err := client.Analyze(packages); !errors.As(err, &rpcErr) || rpcErr.Code != rpcInvalidParams {
+			t.Errorf("Analyze(%q) error = %v, want invalid params", packages, err)
+		}
+	}
+
+	impacted, err := client.GetImpact("Pkg0000")
+	if err != nil {
+		t.Fatalf("GetImpact() error = %v", err)
+	}
+	if expected := impactSet(fixture.graph, "Pkg0000"); !reflect.DeepEqual(impacted, expected) {
+		t.Errorf("GetImpact() = %v, want %v", impacted, expected)
+	}
+
+	dep := sortedKeys(fixture.graph["Pkg0000"])[0]
+	path, err := client.FindPath("Pkg0000", dep)
+	if err != nil {
+		t.Fatalf("FindPath() error = %v", err)
+	}
+	if expected := []string{"Pkg0000", dep}; !reflect.DeepEqual(path, expected) {
+		t.Errorf("FindPath() = %v, want %v", path, expected)
+	}
+
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if snapshot.Version != snapshotVersion || len(snapshot.Packages) != len(fixture.graph) || len(snapshot.Metrics) != len(fixture.graph) {
+		t.Errorf("Snapshot() = version %q, %d packages, %d metrics; want version %q, %d of each",
+			snapshot.Version, len(snapshot.Packages), len(snapshot.Metrics), snapshotVersion, len(fixture.graph))
+	}
+	if snapshot.Metrics["Pkg0000"].Efferent != len(fixture.graph["Pkg0000"]) {
+		t.Errorf("Snapshot() Pkg0000 efferent = %d, want %d", snapshot.Metrics["Pkg0000"].Efferent, len(fixture.graph["Pkg0000"]))
+	}
+
+	var rpcErr *analyzerclient.RPCError
+	if err := client.Call("unknown", analyzerclient.Params{}, nil); !errors.As(err, &rpcErr) || rpcErr.Code != rpcMethodNotFound {
+		t.Errorf("Call(unknown) error = %v, want method not found", err)
+	}
+}
+
+func TestMarshalProto(t *testing.T) {
+	snapshot := DependencySnapshot{
+		Version:       snapshotVersion,
+		CapturedAt:    time.Date(2024, 3, 1, 12, 30, 0, 500, time.UTC),
+		WorkspaceRoot: "/workspace",
+		Packages:      []string{"UmbraInterfaces", "UmbraCoreTypes"},
+		Edges: []DepEdge{
+			{Source: "UmbraInterfaces", Target: "UmbraCoreTypes", Valid: true},
+			{Source: "UmbraCoreTypes", Target: "UmbraInterfaces"},
+		},
+		Metrics: map[string]PackageMetrics{
+			"UmbraCoreTypes": {PackageName: "UmbraCoreTypes", Afferent: 1, Efferent: 1, Instability: 0.5, Abstractness: 0.25,
+				Distance: 0.25, FanIn: 1, FanOut: 1, FileCount: 3, LOC: 120, Grade: "B"},
+		},
+	}
+	content, err := snapshot.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto() error = %v", err)
+	}
+
+	var decoded alphapb.DependencySnapshot
+	if err := proto.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("error decoding DependencySnapshot: %v", err)
+	}
+	sorted := snapshot.sorted()
+	if decoded.Version != sorted.Version || !decoded.CapturedAt.AsTime().Equal(sorted.CapturedAt) || decoded.WorkspaceRoot != sorted.WorkspaceRoot {
+		t.Errorf("decoded header = %q, %v, %q; want %q, %v, %q", decoded.Version, decoded.CapturedAt.AsTime(), decoded.WorkspaceRoot,
+			sorted.Version, sorted.CapturedAt, sorted.WorkspaceRoot)
+	}
+	if !reflect.DeepEqual(decoded.Packages, sorted.Packages) {
+		t.Errorf("decoded packages = %v, want %v", decoded.Packages, sorted.Packages)
+	}
+	if len(decoded.Edges) != len(sorted.Edges) {
+		t.Fatalf("decoded %d edges, want %d", len(decoded.Edges), len(sorted.Edges))
+	}
+	for i, edge := range sorted.Edges {
+		if got := decoded.Edges[i]; got.Source != edge.Source || got.Target != edge.Target || got.Valid != edge.Valid {
+			t.Errorf("decoded edge %d = %v, want %+v", i, got, edge)
+		}
+	}
+	if len(decoded.Metrics) != len(sorted.Metrics) {
+		t.Fatalf("decoded %d metrics, want %d", len(decoded.Metrics), len(sorted.Metrics))
+	}
+	for pkg, metrics := range sorted.Metrics {
+		got := decoded.Metrics[pkg]
+		decodedMetrics := PackageMetrics{
+			PackageName: got.GetPackageName(), Afferent: int(got.GetAfferent()), Efferent: int(got.GetEfferent()),
+			Instability: got.GetInstability(), Abstractness: got.GetAbstractness(), Distance: got.GetDistance(),
+			FanIn: int(got.GetFanIn()), FanOut: int(got.GetFanOut()), FileCount: int(got.GetFileCount()), LOC: int(got.GetLoc()),
+			Grade: got.GetGrade(),
+		}
+		if decodedMetrics != metrics {
+			t.Errorf("decoded metrics of %s = %+v, want %+v", pkg, decodedMetrics, metrics)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Markers around the generated targets, so generate-makefile can replace them when re-run
+const (
+	makefileBeginMarker = "# BEGIN alpha-tools targets (generated by dependency_analyzer generate-makefile; do not edit)"
+	makefileEndMarker   = "# END alpha-tools targets"
+)
+
+// makefileTargets are the targets written by generate-makefile
+var makefileTargets = []string{"help", "dep-analyze", "dep-graph", "dep-graph-html", "migrate-module", "migration-status", "migration-validate", "migration-rollback"}
+
+// makefileTemplate is the block of targets written by generate-makefile; recipes are indented with tabs
+var makefileTemplate = template.Must(template.New("makefile").Parse(makefileBeginMarker + `
+# Override any variable on the command line, e.g. make migrate-module MODULE=CoreDTOs DESTINATION=UmbraCoreTypes/CoreDTOs
+
+ALPHA_TOOLS_BIN ?= {{.ToolsBin}}
+WORKSPACE ?= {{.Workspace}}
+PACKAGES ?= {{.PackagesDir}}
+SOURCE ?= {{.SourceDir}}
+MODULE ?=
+DESTINATION ?=
+DEP_GRAPH ?= dependency_graph.dot
+DEP_REPORT ?= dependency_report.html
+
+.PHONY: {{range $i, $t := .Targets}}{{if $i}} {{end}}{{$t}}{{end}}
+
+help: ## List the alpha-tools targets
+	@grep -hE '^[a-zA-Z_-]+:.*## ' $(MAKEFILE_LIST) | awk 'BEGIN {FS = ":.*## "} {printf "  %-20s %s\n", $$1, $$2}'
+
+dep-analyze: ## Check package dependencies against the Alpha Dot Five rules
+	$(ALPHA_TOOLS_BIN)/dependency_analyzer --workspace $(WORKSPACE) --packages $(PACKAGES)
+
+dep-graph: ## Write the package dependency graph to $(DEP_GRAPH)
+	$(ALPHA_TOOLS_BIN)/dependency_analyzer --workspace $(WORKSPACE) --packages $(PACKAGES) --graph $(DEP_GRAPH)
+
+dep-graph-html: ## Write the interactive HTML dependency report to $(DEP_REPORT)
+	$(ALPHA_TOOLS_BIN)/dependency_analyzer --workspace $(WORKSPACE) --packages $(PACKAGES) --html-report $(DEP_REPORT)
+
+migrate-module: ## Migrate MODULE to DESTINATION (e.g. UmbraCoreTypes/CoreDTOs)
+	$(if $(MODULE),,$(error MODULE is required))
+	$(if $(DESTINATION),,$(error DESTINATION is required))
+	$(ALPHA_TOOLS_BIN)/migration_helper --source $(SOURCE) --target $(PACKAGES) --workspace $(WORKSPACE) --module $(MODULE) --destination $(DESTINATION)
+
+migration-status: ## Show which modules have been migrated
+	$(ALPHA_TOOLS_BIN)/migration_helper status --source $(SOURCE) --target $(PACKAGES)
+
+migration-validate: ## Validate the package mappings and migrated packages
+	$(ALPHA_TOOLS_BIN)/migration_helper validate --source $(SOURCE) --target $(PACKAGES)
+
+migration-rollback: ## Restore DESTINATION's files from git and mark MODULE's migration as failed
+	$(if $(MODULE),,$(error MODULE is required))
+	$(if $(DESTINATION),,$(error DESTINATION is required))
+	dest="$(DESTINATION)"; dir="$(PACKAGES)/$${dest%%/*}/Sources/$${dest#*/}"; \
+		git checkout -- "$$dir" 2>/dev/null || true; \
+		git clean -fd -- "$$dir"
+	$(ALPHA_TOOLS_BIN)/migration_helper journal --target $(PACKAGES) --mark-failed $(MODULE) --reason "rolled back with make migration-rollback"
+` + makefileEndMarker + "\n"))
+
+// makefileBlockPattern matches a previously generated block of targets
+var makefileBlockPattern = regexp.MustCompile(`(?s)` + regexp.QuoteMeta(makefileBeginMarker) + `.*?` + regexp.QuoteMeta(makefileEndMarker) + `\n?`)
+
+// MakefileOptions configures the generated Makefile targets
+type MakefileOptions struct {
+	ToolsBin    string // Directory containing the built tools
+	Workspace   string
+	PackagesDir string
+	SourceDir   string
+	Targets     []string
+}
+
+// GenerateMakefileTargets renders the block of Makefile targets for the given options
+func GenerateMakefileTargets(options MakefileOptions) (string, error) {
+	options.Targets = makefileTargets
+	var sb strings.Builder
+	if err := makefileTemplate.Execute(&sb, options); err != nil {
+		return "", fmt.Errorf("error rendering Makefile template: %v", err)
+	}
+	return sb.String(), nil
+}
+
+// MergeMakefileTargets replaces the generated block in an existing Makefile, or appends it if there is none
+func MergeMakefileTargets(existing, block string) string {
+	if makefileBlockPattern.MatchString(existing) {
+		return makefileBlockPattern.ReplaceAllLiteralString(existing, block)
+	}
+	if existing == "" {
+		return block
+	}
+	if !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	return existing + "\n" + block
+}
+
+// conflictingMakefileTargets returns the generated targets that a Makefile also defines outside the generated block
+func conflictingMakefileTargets(existing string) []string {
+	outside := makefileBlockPattern.ReplaceAllString(existing, "")
+	conflicts := []string{}
+	for _, target := range makefileTargets {
+		if regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(target) + `\s*:`).MatchString(outside) {
+			conflicts = append(conflicts, target)
+		}
+	}
+	return conflicts
+}
+
+// runGenerateMakefile implements the generate-makefile subcommand
+func runGenerateMakefile(args []string) error {
+	fs := flag.NewFlagSet("generate-makefile", flag.ExitOnError)
+	outputFlag := fs.String("output", "Makefile", "Makefile to create, or to add the targets to")
+	toolsBinFlag := fs.String("tools-bin", "alpha-tools/bin", "Default directory containing the built tools")
+	workspaceFlag := fs.String("workspace", ".", "Default workspace root")
+	packagesFlag := fs.String("packages", "packages", "Default packages directory")
+	sourceFlag := fs.String("source", "Sources", "Default source directory for migrations")
+	fs.Parse(args)
+
+	block, err := GenerateMakefileTargets(MakefileOptions{
+		ToolsBin:    *toolsBinFlag,
+		Workspace:   *workspaceFlag,
+		PackagesDir: *packagesFlag,
+		SourceDir:   *sourceFlag,
+	})
+	if err != nil {
+		return err
+	}
+
+	existing, err := ioutil.ReadFile(*outputFlag)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading %s: %v", *outputFlag, err)
+	}
+
+	if conflicts := conflictingMakefileTargets(string(existing)); len(conflicts) > 0 {
+		fmt.Printf("⚠️ Warning: %s already defines %s; make will use the generated recipes\n", *outputFlag, strings.Join(conflicts, ", "))
+	}
+
+	content := MergeMakefileTargets(string(existing), block)
+	if content == string(existing) {
+		fmt.Printf("✅ %s is up to date\n", *outputFlag)
+		return nil
+	}
+
+	if err := ioutil.WriteFile(*outputFlag, []byte(content), 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", *outputFlag, err)
+	}
+	fmt.Printf("✅ Wrote alpha-tools targets to %s\n", *outputFlag)
+	return nil
+}
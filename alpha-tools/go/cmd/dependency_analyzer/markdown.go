@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PrintMarkdownReport prints every package dependency as a GitHub Flavored Markdown table for PR descriptions.
+// It returns false if any dependency is invalid.
+func (a *DependencyAnalyzer) PrintMarkdownReport() (bool, error) {
+	// Warnings go to stderr so they do not end up among the rows
+	a.messages = os.Stderr
+	defer func() { a.messages = nil }()
+
+	result, err := a.Analyze()
+	if err != nil {
+		return false, err
+	}
+
+	packagesPath, err := filepath.Rel(a.WorkspaceRoot, a.PackagesDir)
+	if err != nil {
+		packagesPath = a.PackagesDir
+	}
+
+	fmt.Println("| Source | Target | Status | Path |")
+	fmt.Println("| --- | --- | :---: | --- |")
+	for _, edge := range result.Edges {
+		status := "✅"
+		if !edge.Valid {
+			status = "❌"
+		}
+		fmt.Printf("| `%s` | `%s` | %s | `%s` |\n", edge.Source, edge.Target, status,
+			filepath.ToSlash(filepath.Join(packagesPath, edge.Source)))
+	}
+
+	if len(result.Edges) == 0 {
+		fmt.Println("| _none_ | | | |")
+	}
+
+	fmt.Printf("\n**%d dependencies, %d invalid**\n", len(result.Edges), result.InvalidCount)
+	return result.InvalidCount+a.strictErrors == 0, nil
+}
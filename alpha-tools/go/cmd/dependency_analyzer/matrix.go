@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+)
+
+// Cells of the CSV dependency matrix
+const (
+	matrixDirect     = "1" // The row package depends on the column package
+	matrixTransitive = "t" // The row package only depends on the column package through others
+	matrixInvalid    = "!" // Prefix of direct dependencies the rules do not allow
+)
+
+// ExportAsCSV writes the package dependency matrix to outputPath for spreadsheet heat maps. Rows are
+// dependents and columns dependencies, both sorted by package name. A trailing row counts the direct
+// dependencies of each column's package.
+func (a *DependencyAnalyzer) ExportAsCSV(outputPath string) error {
+	packageDeps, err := a.BuildPackageGraph()
+	if err != nil {
+		return err
+	}
+
+	if len(packageDeps) == 0 {
+		return fmt.Errorf("no targets found in packages directory")
+	}
+
+	content, err := a.dependencyMatrixCSV(packageDeps)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", outputPath, err)
+	}
+
+	fmt.Printf("Dependency matrix saved to %s\n", outputPath)
+	return nil
+}
+
+// dependencyMatrixCSV renders the dependency matrix of a package graph as CSV
+func (a *DependencyAnalyzer) dependencyMatrixCSV(packageDeps map[string]map[string]bool) ([]byte, error) {
+	packages := sortedKeys(packageDeps)
+	closure := computeTransitiveClosure(packageDeps)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(append([]string{"dependent \\ dependency"}, packages...))
+
+	for _, source := range packages {
+		row := []string{source}
+		for _, target := range packages {
+			cell := ""
+			switch {
+			case source == target:
+			case packageDeps[source][target]:
+				cell = matrixDirect
+				if !a.IsDependencyValid(source, target) {
+					cell = matrixInvalid + cell
+				}
+			case closure[source][target]:
+				cell = matrixTransitive
+			}
+			row = append(row, cell)
+		}
+		writer.Write(row)
+	}
+
+	summary := []string{"out-degree"}
+	for _, pkg := range packages {
+		summary = append(summary, strconv.Itoa(len(packageDeps[pkg])))
+	}
+	writer.Write(summary)
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("error encoding dependency matrix: %v", err)
+	}
+	return buf.Bytes(), nil
+}
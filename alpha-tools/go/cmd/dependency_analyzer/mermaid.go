@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// GenerateMermaidGraph writes the dependency graph as a Mermaid flowchart, which GitHub, GitLab and Notion
+// render without Graphviz. Invalid dependencies are drawn red. A .md output file gets the diagram in a mermaid
+// code block, ready to render as it is.
+func (a *DependencyAnalyzer) GenerateMermaidGraph(outputFile string) error {
+	result, err := a.Analyze()
+	if err != nil {
+		return err
+	}
+
+	if len(result.Packages) == 0 {
+		return fmt.Errorf("no targets found in packages directory")
+	}
+
+	diagram := mermaidDiagram(result)
+	if strings.EqualFold(filepath.Ext(outputFile), ".md") {
+		diagram = "```mermaid\n" + diagram + "```\n"
+	}
+	if err := ioutil.WriteFile(outputFile, []byte(diagram), 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", outputFile, err)
+	}
+
+	fmt.Printf("Mermaid dependency graph written to %s\n", outputFile)
+	return nil
+}
+
+// mermaidDiagram renders an analysis result as a Mermaid graph LR diagram. Packages get generated IDs, so a
+// name Mermaid would misparse is only ever a quoted label.
+func mermaidDiagram(result *AnalysisResult) string {
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+
+	ids := make(map[string]string)
+	for i, pkg := range result.Packages {
+		ids[pkg] = fmt.Sprintf("p%d", i)
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[pkg], strings.ReplaceAll(pkg, "\"", "#quot;")))
+	}
+	for _, pkg := range result.Packages {
+		sb.WriteString(fmt.Sprintf("  style %s fill:%s\n", ids[pkg], packageColor(pkg)))
+	}
+
+	// linkStyle refers to edges by the order they are declared in
+	invalid := []string{}
+	for i, edge := range result.Edges {
+		sb.WriteString(fmt.Sprintf("  %s --> %s\n", ids[edge.Source], ids[edge.Target]))
+		if !edge.Valid {
+			invalid = append(invalid, fmt.Sprint(i))
+		}
+	}
+	if len(invalid) > 0 {
+		sb.WriteString(fmt.Sprintf("  linkStyle %s stroke:red,stroke-width:2px\n", strings.Join(invalid, ",")))
+	}
+
+	return sb.String()
+}
+
+// mermaidPath returns the file to write the Mermaid graph to when --graph-format both writes the DOT graph to
+// dotFile
+func mermaidPath(dotFile string) string {
+	return strings.TrimSuffix(dotFile, filepath.Ext(dotFile)) + ".mmd"
+}
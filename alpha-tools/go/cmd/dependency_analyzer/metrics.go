@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/analyzerclient"
+	"github.com/mpy/umbracore/alpha-tools/pkg/workspace"
+)
+
+// PackageMetrics holds the coupling, stability and size metrics of a package
+type PackageMetrics = analyzerclient.PackageMetrics
+
+// computePackageMetrics derives the graph metrics of each package in a snapshot
+func computePackageMetrics(snapshot DependencySnapshot) map[string]PackageMetrics {
+	metrics := make(map[string]PackageMetrics)
+	for _, pkg := range snapshot.Packages {
+		metrics[pkg] = PackageMetrics{PackageName: pkg}
+	}
+	for _, edge := range snapshot.Edges {
+		source := metrics[edge.Source]
+		source.PackageName = edge.Source
+		source.FanOut++
+		metrics[edge.Source] = source
+
+		target := metrics[edge.Target]
+		target.PackageName = edge.Target
+		target.FanIn++
+		metrics[edge.Target] = target
+	}
+
+	// At package granularity Martin's coupling counts are the fan-in and fan-out
+	for pkg, m := range metrics {
+		m.Afferent = m.FanIn
+		m.Efferent = m.FanOut
+		if coupling := m.Afferent + m.Efferent; coupling > 0 {
+			m.Instability = float64(m.Efferent) / float64(coupling)
+		}
+		metrics[pkg] = m
+	}
+	return metrics
+}
+
+// addSourceMetrics fills in the abstractness, distance and size of a package from its Swift files
+func addSourceMetrics(packageDir string, metrics *PackageMetrics) error {
+	metrics.Distance = math.Abs(metrics.Abstractness + metrics.Instability - 1)
+	if _, err := os.Stat(packageDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	module, err := workspace.NewWorkspaceScanner().ScanModule(packageDir)
+	if err != nil {
+		return err
+	}
+	metrics.FileCount = module.FileCount
+
+	protocols, types := 0, 0
+	for _, file := range module.SwiftFiles {
+		path := filepath.Join(packageDir, filepath.FromSlash(file))
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", path, err)
+		}
+		metrics.LOC += bytes.Count(content, []byte("\n"))
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			metrics.LOC++
+		}
+		for _, match := range typeDeclPattern.FindAllStringSubmatch(string(content), -1) {
+			types++
+			if match[1] == "protocol" {
+				protocols++
+			}
+		}
+	}
+
+	if types > 0 {
+		metrics.Abstractness = float64(protocols) / float64(types)
+	}
+	metrics.Distance = math.Abs(metrics.Abstractness + metrics.Instability - 1)
+	return nil
+}
+
+// ComputeAllMetrics analyzes the workspace and returns every metric of a package
+func (a *DependencyAnalyzer) ComputeAllMetrics(pkg string) (PackageMetrics, error) {
+	snapshot, err := a.CaptureSnapshot()
+	if err != nil {
+		return PackageMetrics{}, err
+	}
+
+	if !contains(snapshot.Packages, pkg) {
+		return PackageMetrics{}, fmt.Errorf("package %s not found in dependency graph", pkg)
+	}
+
+	metrics := computePackageMetrics(snapshot)[pkg]
+	if err := addSourceMetrics(filepath.Join(a.PackagesDir, pkg), &metrics); err != nil {
+		return PackageMetrics{}, err
+	}
+
+	card, err := a.scoreSnapshotPackage(snapshot, pkg, DefaultScoringConfig())
+	if err != nil {
+		return PackageMetrics{}, err
+	}
+	metrics.Grade = card.Grade
+
+	return metrics, nil
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// swiftLibraryCallPattern matches the start of an umbra_swift_library call and its name attribute
+	swiftLibraryCallPattern = regexp.MustCompile(`umbra_swift_library\(\s*name\s*=\s*"([^"]+)"`)
+	// moduleNameAttrPattern matches an explicit module_name attribute
+	moduleNameAttrPattern = regexp.MustCompile(`module_name\s*=\s*"([^"]+)"`)
+	// swiftImportPattern matches Swift import statements
+	swiftImportPattern = regexp.MustCompile(`(?m)^\s*(?:@\w+\s+)*import\s+(?:(?:class|struct|enum|protocol|func|var|let|typealias)\s+)?(\w+)`)
+)
+
+// NameMismatch describes a Bazel target whose name does not match the Swift module it provides
+type NameMismatch struct {
+	BuildFile          string
+	TargetName         string
+	ExpectedModuleName string
+	ActualSwiftFiles   []string
+}
+
+// ValidateTargetNames checks that every umbra_swift_library target is named after the Swift module it provides.
+// A target is reported if it overrides module_name with a different value, or if no Swift file under packagesDir
+// imports a module with the target's name.
+func ValidateTargetNames(packagesDir string) ([]NameMismatch, error) {
+	buildFiles := []string{}
+	imported := make(map[string]bool)
+
+	err := filepath.Walk(packagesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		name := info.Name()
+		if name == "BUILD" || name == "BUILD.bazel" {
+			buildFiles = append(buildFiles, path)
+			return nil
+		}
+
+		if strings.HasSuffix(name, ".swift") {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("error reading %s: %v", path, err)
+			}
+			for _, match := range swiftImportPattern.FindAllStringSubmatch(string(content), -1) {
+				imported[match[1]] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning packages: %v", err)
+	}
+
+	mismatches := []NameMismatch{}
+	for _, buildFile := range buildFiles {
+		content, err := ioutil.ReadFile(buildFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading BUILD file: %v", err)
+		}
+
+		calls := swiftLibraryCallPattern.FindAllStringSubmatchIndex(string(content), -1)
+		for i, call := range calls {
+			targetName := string(content[call[2]:call[3]])
+
+			// Limit the module_name search to this call
+			end := len(content)
+			if i+1 < len(calls) {
+				end = calls[i+1][0]
+			}
+			body := string(content[call[1]:end])
+
+			moduleName := targetName
+			if match := moduleNameAttrPattern.FindStringSubmatch(body); match != nil {
+				moduleName = match[1]
+			}
+
+			if moduleName == targetName && imported[targetName] {
+				continue
+			}
+
+			swiftFiles, err := packageSwiftFiles(filepath.Dir(buildFile))
+			if err != nil {
+				return nil, err
+			}
+
+			mismatches = append(mismatches, NameMismatch{
+				BuildFile:          buildFile,
+				TargetName:         targetName,
+				ExpectedModuleName: targetName,
+				ActualSwiftFiles:   swiftFiles,
+			})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].BuildFile != mismatches[j].BuildFile {
+			return mismatches[i].BuildFile < mismatches[j].BuildFile
+		}
+		return mismatches[i].TargetName < mismatches[j].TargetName
+	})
+
+	return mismatches, nil
+}
+
+// ValidateTargetNamesFromQuery performs the ValidateTargetNames check on a --output=proto query of the
+// packages instead of reading BUILD files: the query reports each target's module_name attribute and srcs,
+// so only the Swift sources themselves are read.
+func (a *DependencyAnalyzer) ValidateTargetNamesFromQuery() ([]NameMismatch, error) {
+	if a.QueryOutputFormat != "proto" {
+		return nil, fmt.Errorf("target names can only be validated from a query with the proto output format")
+	}
+
+	result, err := a.RunBazelQuery("//packages/...")
+	if err != nil {
+		return nil, fmt.Errorf("error querying packages: %v", err)
+	}
+
+	libraries := []BazelTarget{}
+	imported := make(map[string]bool)
+	for _, target := range result.Target {
+		if target.Rule != "umbra_swift_library" && target.GeneratorFunction != "umbra_swift_library" {
+			continue
+		}
+		libraries = append(libraries, target)
+
+		for _, src := range target.Sources {
+			if !strings.HasSuffix(src, ".swift") {
+				continue
+			}
+			path := a.labelPath(src)
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s: %v", path, err)
+			}
+			for _, match := range swiftImportPattern.FindAllStringSubmatch(string(content), -1) {
+				imported[match[1]] = true
+			}
+		}
+	}
+
+	mismatches := []NameMismatch{}
+	for _, target := range libraries {
+		packagePath, targetName := splitLabel(target.Name)
+		moduleName := targetName
+		if target.ModuleName != "" {
+			moduleName = target.ModuleName
+		}
+		if moduleName == targetName && imported[targetName] {
+			continue
+		}
+
+		swiftFiles := []string{}
+		for _, src := range target.Sources {
+			if srcPackage, file := splitLabel(src); strings.HasSuffix(file, ".swift") {
+				rel := strings.TrimPrefix(strings.TrimPrefix(srcPackage, packagePath), "/")
+				swiftFiles = append(swiftFiles, strings.TrimPrefix(rel+"/"+file, "/"))
+			}
+		}
+
+		mismatches = append(mismatches, NameMismatch{
+			BuildFile:          filepath.Join(a.WorkspaceRoot, filepath.FromSlash(packagePath), "BUILD.bazel"),
+			TargetName:         targetName,
+			ExpectedModuleName: targetName,
+			ActualSwiftFiles:   swiftFiles,
+		})
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].BuildFile != mismatches[j].BuildFile {
+			return mismatches[i].BuildFile < mismatches[j].BuildFile
+		}
+		return mismatches[i].TargetName < mismatches[j].TargetName
+	})
+
+	return mismatches, nil
+}
+
+// splitLabel splits a label such as //packages/UmbraUtils:Networking into its package path and name
+func splitLabel(label string) (string, string) {
+	label = strings.TrimPrefix(label, "//")
+	if idx := strings.Index(label, ":"); idx >= 0 {
+		return label[:idx], label[idx+1:]
+	}
+	return label, filepath.Base(label)
+}
+
+// labelPath returns the file in the workspace that a source file label refers to
+func (a *DependencyAnalyzer) labelPath(label string) string {
+	packagePath, name := splitLabel(label)
+	return filepath.Join(a.WorkspaceRoot, filepath.FromSlash(packagePath), filepath.FromSlash(name))
+}
+
+// packageSwiftFiles lists the Swift files belonging to the package rooted at dir, excluding nested packages
+func packageSwiftFiles(dir string) ([]string, error) {
+	files := []string{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (fileExists(filepath.Join(path, "BUILD")) || fileExists(filepath.Join(path, "BUILD.bazel"))) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".swift") {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// fileExists checks if a regular file exists
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// printNameMismatches reports target name mismatches and returns false if any were found
+func printNameMismatches(mismatches []NameMismatch) bool {
+	if len(mismatches) == 0 {
+		fmt.Println("✅ All umbra_swift_library targets match their Swift module names.")
+		return true
+	}
+
+	for _, mismatch := range mismatches {
+		fmt.Printf("❌ NAME MISMATCH: %s in %s\n", mismatch.TargetName, mismatch.BuildFile)
+		fmt.Printf("   Expected a Swift module named %s, but no Swift file imports it\n", mismatch.ExpectedModuleName)
+		if len(mismatch.ActualSwiftFiles) > 0 {
+			fmt.Printf("   Swift files in package: %s\n", strings.Join(mismatch.ActualSwiftFiles, ", "))
+		}
+	}
+	fmt.Printf("❌ Found %d target name mismatches.\n", len(mismatches))
+
+	return false
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// plantUMLTierColors are the component background colors of tiers 0, 1, 2 and so on, repeating after the last
+var plantUMLTierColors = []string{"LightGreen", "LightYellow", "LightCoral", "LightBlue", "Lavender", "Wheat"}
+
+// GeneratePlantUML writes the dependency graph as a PlantUML component diagram, e.g. for the Confluence
+// PlantUML macro. Packages are grouped by tier; invalid dependencies use error arrows.
+func (a *DependencyAnalyzer) GeneratePlantUML(outputFile string) error {
+	result, err := a.Analyze()
+	if err != nil {
+		return err
+	}
+
+	if len(result.Packages) == 0 {
+		return fmt.Errorf("no targets found in packages directory")
+	}
+
+	if err := ioutil.WriteFile(outputFile, []byte(a.plantUMLDiagram(result)), 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", outputFile, err)
+	}
+
+	fmt.Printf("PlantUML dependency diagram written to %s\n", outputFile)
+	return nil
+}
+
+// plantUMLDiagram renders an analysis result as a PlantUML component diagram
+func (a *DependencyAnalyzer) plantUMLDiagram(result *AnalysisResult) string {
+	tiers := packageTiers(a.ValidDeps)
+	packagesByTier := make(map[int][]string)
+	for _, pkg := range result.Packages {
+		packagesByTier[tiers[pkg]] = append(packagesByTier[tiers[pkg]], pkg)
+	}
+	tierNumbers := []int{}
+	for tier := range packagesByTier {
+		tierNumbers = append(tierNumbers, tier)
+	}
+	sort.Ints(tierNumbers)
+
+	var sb strings.Builder
+	sb.WriteString("@startuml\n")
+	sb.WriteString("title Alpha Dot Five package dependencies\n\n")
+
+	for _, tier := range tierNumbers {
+		sb.WriteString(fmt.Sprintf("skinparam componentBackgroundColor<<Tier%d>> %s\n", tier, plantUMLTierColors[tier%len(plantUMLTierColors)]))
+	}
+	sb.WriteString("\n")
+
+	for _, tier := range tierNumbers {
+		sb.WriteString(fmt.Sprintf("package \"Tier %d\" {\n", tier))
+		for _, pkg := range packagesByTier[tier] {
+			sb.WriteString(fmt.Sprintf("  [%s] <<Tier%d>>\n", pkg, tier))
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	for _, edge := range result.Edges {
+		arrow := "-->"
+		if !edge.Valid {
+			arrow = "--x"
+		}
+		sb.WriteString(fmt.Sprintf("[%s] %s [%s]\n", edge.Source, arrow, edge.Target))
+	}
+
+	sb.WriteString("@enduml\n")
+	return sb.String()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/alphapb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MarshalProto encodes the snapshot as a DependencySnapshot message, with sorted packages, edges and metrics
+func (s DependencySnapshot) MarshalProto() ([]byte, error) {
+	s = s.sorted()
+
+	message := &alphapb.DependencySnapshot{
+		Version:       s.Version,
+		WorkspaceRoot: s.WorkspaceRoot,
+		Packages:      s.Packages,
+		Metrics:       make(map[string]*alphapb.PackageMetrics, len(s.Metrics)),
+	}
+	if !s.CapturedAt.IsZero() {
+		message.CapturedAt = timestamppb.New(s.CapturedAt)
+	}
+	for _, edge := range s.Edges {
+		message.Edges = append(message.Edges, &alphapb.DepEdge{Source: edge.Source, Target: edge.Target, Valid: edge.Valid})
+	}
+	for pkg, metrics := range s.Metrics {
+		message.Metrics[pkg] = &alphapb.PackageMetrics{
+			PackageName:  metrics.PackageName,
+			Afferent:     int64(metrics.Afferent),
+			Efferent:     int64(metrics.Efferent),
+			Instability:  metrics.Instability,
+			Abstractness: metrics.Abstractness,
+			Distance:     metrics.Distance,
+			FanIn:        int64(metrics.FanIn),
+			FanOut:       int64(metrics.FanOut),
+			FileCount:    int64(metrics.FileCount),
+			Loc:          int64(metrics.LOC),
+			Grade:        metrics.Grade,
+		}
+	}
+
+	// Deterministic output sorts the metrics map, so equal snapshots give identical files
+	content, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding snapshot: %v", err)
+	}
+	return content, nil
+}
+
+// GenerateReport writes a snapshot of the dependency graph and package metrics for later pipeline stages,
+// as JSON or as a binary DependencySnapshot message
+func (a *DependencyAnalyzer) GenerateReport(outputFile, format string) error {
+	snapshot, err := a.CaptureSnapshot()
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	switch format {
+	case "", "json":
+		content, err = json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding snapshot: %v", err)
+		}
+		content = append(content, '\n')
+	case "proto":
+		content, err = snapshot.MarshalProto()
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown report format %q (expected json or proto)", format)
+	}
+
+	if err := ioutil.WriteFile(outputFile, content, 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", outputFile, err)
+	}
+
+	fmt.Printf("Dependency report written to %s\n", outputFile)
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// throttleWarningThreshold is how long a query may wait for the rate limiter before a warning is logged
+const throttleWarningThreshold = 5 * time.Second
+
+// RateLimiter is a token bucket that limits how often Bazel is invoked. The bucket holds up to Burst
+// tokens and refills at QueriesPerSecond; each invocation takes one token.
+type RateLimiter struct {
+	QueriesPerSecond float64
+	Burst            int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a rate limiter with a full bucket
+func NewRateLimiter(queriesPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		QueriesPerSecond: queriesPerSecond,
+		Burst:            burst,
+		tokens:           float64(burst),
+		last:             time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token, possibly one not yet refilled, and returns how long to wait until it is
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.QueriesPerSecond <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.QueriesPerSecond
+	if l.tokens > float64(l.Burst) {
+		l.tokens = float64(l.Burst)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.QueriesPerSecond * float64(time.Second))
+}
+
+// cancel returns a token taken by reserve whose wait was abandoned
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// RenderGraph pipes a DOT file to Graphviz's dot to produce outputFile in the given format, e.g. png, svg or
+// pdf. If dot is not installed, it prints the command to run by hand instead of failing.
+func RenderGraph(dotFile, outputFile, format string) error {
+	dotPath, err := exec.LookPath("dot")
+	if err != nil {
+		fmt.Printf("⚠️ Warning: Graphviz dot was not found in PATH, so %s was not rendered\n", outputFile)
+		fmt.Printf("To render it after installing Graphviz: dot -T%s -o %s %s\n", format, outputFile, dotFile)
+		return nil
+	}
+
+	input, err := os.Open(dotFile)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %v", dotFile, err)
+	}
+	defer input.Close()
+
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := io.Copy(writer, input)
+		writer.CloseWithError(err)
+	}()
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(dotPath, "-T"+format, "-o", outputFile)
+	cmd.Stdin = reader
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		reader.CloseWithError(err)
+		return fmt.Errorf("error rendering %s with %s: %v: %s", outputFile, dotVersion(dotPath), err, strings.TrimSpace(stderr.String()))
+	}
+
+	fmt.Printf("Dependency graph rendered to %s\n", outputFile)
+	return nil
+}
+
+// dotVersion returns the version line printed by dot -V, e.g. "dot - graphviz version 2.43.0 (0)"
+func dotVersion(dotPath string) string {
+	output, err := exec.Command(dotPath, "-V").CombinedOutput()
+	if err != nil {
+		return "dot (unknown version)"
+	}
+	return strings.TrimSpace(string(output))
+}
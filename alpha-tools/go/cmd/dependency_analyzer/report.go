@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"time"
+)
+
+//go:embed assets/d3.v3.min.js assets/report.html.tmpl
+var reportAssets embed.FS
+
+// reportNode is a package in the HTML report graph
+type reportNode struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// reportLink is a dependency in the HTML report graph; Source and Target index into the nodes
+type reportLink struct {
+	Source int  `json:"source"`
+	Target int  `json:"target"`
+	Valid  bool `json:"valid"`
+}
+
+// reportGraph is the graph embedded in the HTML report
+type reportGraph struct {
+	Nodes []reportNode `json:"nodes"`
+	Links []reportLink `json:"links"`
+}
+
+// reportDependency is one of a package's dependencies in the HTML report
+type reportDependency struct {
+	Target       string
+	Valid        bool
+	Alternatives []string // What the package may depend on instead, for invalid dependencies
+}
+
+// reportPackage is a row of the HTML report's package table and its expandable section
+type reportPackage struct {
+	Name         string
+	Color        string
+	Dependencies []reportDependency
+	Dependents   int
+	InvalidCount int
+}
+
+// reportPackages summarises each package's dependencies, in package order
+func (a *DependencyAnalyzer) reportPackages(result *AnalysisResult) []reportPackage {
+	packages := make([]reportPackage, len(result.Packages))
+	index := make(map[string]int)
+	for i, pkg := range result.Packages {
+		index[pkg] = i
+		packages[i] = reportPackage{Name: pkg, Color: packageColor(pkg), Dependencies: []reportDependency{}}
+	}
+	for _, edge := range result.Edges {
+		source := &packages[index[edge.Source]]
+		dependency := reportDependency{Target: edge.Target, Valid: edge.Valid}
+		if !edge.Valid {
+			dependency.Alternatives = a.GetValidDependenciesFor(edge.Source)
+			source.InvalidCount++
+		}
+		source.Dependencies = append(source.Dependencies, dependency)
+		packages[index[edge.Target]].Dependents++
+	}
+	return packages
+}
+
+// GenerateHTMLReport writes a self-contained HTML page with an interactive force-directed dependency graph, a
+// static SVG of the graph, a table of packages and an expandable list of each package's dependencies.
+// D3.js is inlined so the file can be opened or shared without a web server.
+func (a *DependencyAnalyzer) GenerateHTMLReport(outputPath string) error {
+	result, err := a.Analyze()
+	if err != nil {
+		return err
+	}
+
+	if len(result.Packages) == 0 {
+		return fmt.Errorf("no targets found in packages directory")
+	}
+
+	graph := reportGraph{Nodes: []reportNode{}, Links: []reportLink{}}
+	index := make(map[string]int)
+	for i, pkg := range result.Packages {
+		index[pkg] = i
+		graph.Nodes = append(graph.Nodes, reportNode{Name: pkg, Color: packageColor(pkg)})
+	}
+	for _, edge := range result.Edges {
+		graph.Links = append(graph.Links, reportLink{Source: index[edge.Source], Target: index[edge.Target], Valid: edge.Valid})
+	}
+
+	d3, err := reportAssets.ReadFile("assets/d3.v3.min.js")
+	if err != nil {
+		return fmt.Errorf("error reading embedded D3.js: %v", err)
+	}
+
+	tmpl, err := template.ParseFS(reportAssets, "assets/report.html.tmpl")
+	if err != nil {
+		return fmt.Errorf("error parsing report template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]interface{}{
+		"D3":           template.JS(d3),
+		"Graph":        graph,
+		"SVG":          template.HTML(RenderGraphSVG(result.Packages, result.Edges)),
+		"Packages":     a.reportPackages(result),
+		"PackageCount": len(result.Packages),
+		"EdgeCount":    len(result.Edges),
+		"InvalidCount": result.InvalidCount,
+		"GeneratedAt":  time.Now().UTC().Format("2006-01-02 15:04 MST"),
+	})
+	if err != nil {
+		return fmt.Errorf("error rendering report: %v", err)
+	}
+
+	if err := ioutil.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", outputPath, err)
+	}
+
+	fmt.Printf("HTML dependency report written to %s\n", outputPath)
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolutionStrategy is a suggested way to remove an invalid dependency
+type ResolutionStrategy struct {
+	Name        string
+	Description string
+	Package     string // Package the strategy redirects to, if any
+}
+
+// DependencyConflictResolver suggests how to resolve dependencies that violate the Alpha Dot Five rules
+type DependencyConflictResolver struct {
+	Analyzer *DependencyAnalyzer
+	Packages []string // Packages known to exist in the workspace
+}
+
+// NewDependencyConflictResolver creates a resolver for the analyzer's rules and the given packages
+func NewDependencyConflictResolver(analyzer *DependencyAnalyzer, packages []string) *DependencyConflictResolver {
+	return &DependencyConflictResolver{
+		Analyzer: analyzer,
+		Packages: packages,
+	}
+}
+
+// SuggestStrategies returns the strategies that could remove a dependency from source to target, most preferred first
+func (r *DependencyConflictResolver) SuggestStrategies(source, target string) []ResolutionStrategy {
+	strategies := []ResolutionStrategy{}
+
+	// Depend on the abstraction instead of the implementation
+	for _, pkg := range r.Packages {
+		if pkg != target && pkg != source && strings.HasSuffix(pkg, "Interfaces") &&
+			r.Analyzer.IsDependencyValid(source, pkg) && r.Analyzer.IsDependencyValid(target, pkg) {
+			strategies = append(strategies, ResolutionStrategy{
+				Name: "depend-on-interface",
+				Description: fmt.Sprintf("Define a protocol for what %s needs in %s, implement it in %s and depend on %s instead",
+					source, pkg, target, pkg),
+				Package: pkg,
+			})
+		}
+	}
+
+	// Move shared types down to a package both may depend on
+	for _, pkg := range r.Packages {
+		if pkg != target && pkg != source && !strings.HasSuffix(pkg, "Interfaces") &&
+			r.Analyzer.IsDependencyValid(source, pkg) && r.Analyzer.IsDependencyValid(target, pkg) {
+			strategies = append(strategies, ResolutionStrategy{
+				Name:        "extract-shared-types",
+				Description: fmt.Sprintf("Move the types %s uses from %s into %s, which both packages may depend on", source, target, pkg),
+				Package:     pkg,
+			})
+		}
+	}
+
+	// Reverse the dependency if the rules allow the other direction
+	if r.Analyzer.IsDependencyValid(target, source) {
+		strategies = append(strategies, ResolutionStrategy{
+			Name:        "invert-dependency",
+			Description: fmt.Sprintf("%s may depend on %s: declare the abstraction in %s and let %s provide it", target, source, source, target),
+		})
+	}
+
+	// Accept the dependency explicitly
+	strategies = append(strategies, ResolutionStrategy{
+		Name:        "add-rule",
+		Description: fmt.Sprintf("If %s -> %s is intended, add a rule for it (or a rule group in the --config file) and record the decision in an ADR", source, target),
+	})
+
+	return strategies
+}
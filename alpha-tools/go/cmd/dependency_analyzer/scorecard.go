@@ -0,0 +1,243 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// typeDeclPattern matches Swift type declarations; the first group is the declaration kind
+var typeDeclPattern = regexp.MustCompile(`(?m)^\s*(?:(?:public|open|internal|fileprivate|private|final|indirect)\s+)*(protocol|class|struct|enum|actor)\s+\w+`)
+
+// PackageHealthCard combines the metrics of a package into a single score and grade
+type PackageHealthCard struct {
+	Package       string   `json:"package"`
+	FanIn         int      `json:"fanIn"`
+	FanOut        int      `json:"fanOut"`
+	Instability   float64  `json:"instability"`  // FanOut / (FanIn + FanOut)
+	Abstractness  float64  `json:"abstractness"` // Protocols / all type declarations
+	Distance      float64  `json:"distance"`     // |Abstractness + Instability - 1|, distance from the main sequence
+	Violations    int      `json:"violations"`   // Invalid outgoing dependencies
+	Score         float64  `json:"score"`        // 0 (worst) to 100 (best)
+	Grade         string   `json:"grade"`
+	PreviousScore *float64 `json:"previousScore,omitempty"`
+}
+
+// ScoringConfig holds the weights of each metric in the health score
+type ScoringConfig struct {
+	DistanceWeight  float64
+	CouplingWeight  float64
+	ViolationWeight float64
+	MaxCoupling     int // FanIn + FanOut at which the coupling penalty is maximal
+	MaxViolations   int // Violations at which the violation penalty is maximal
+}
+
+// DefaultScoringConfig returns the weights used by the scorecard subcommand
+func DefaultScoringConfig() ScoringConfig {
+	return ScoringConfig{
+		DistanceWeight:  1,
+		CouplingWeight:  1,
+		ViolationWeight: 2,
+		MaxCoupling:     10,
+		MaxViolations:   3,
+	}
+}
+
+// ScorePackage computes the health card of a package from the current dependency graph
+func (a *DependencyAnalyzer) ScorePackage(pkg string, config ScoringConfig) (PackageHealthCard, error) {
+	snapshot, err := a.CaptureSnapshot()
+	if err != nil {
+		return PackageHealthCard{}, err
+	}
+
+	if !contains(snapshot.Packages, pkg) {
+		return PackageHealthCard{}, fmt.Errorf("package %s not found in dependency graph", pkg)
+	}
+
+	return a.scoreSnapshotPackage(snapshot, pkg, config)
+}
+
+// scoreSnapshotPackage computes the health card of a package in a snapshot
+func (a *DependencyAnalyzer) scoreSnapshotPackage(snapshot DependencySnapshot, pkg string, config ScoringConfig) (PackageHealthCard, error) {
+	metrics := computePackageMetrics(snapshot)[pkg]
+	if err := addSourceMetrics(filepath.Join(a.PackagesDir, pkg), &metrics); err != nil {
+		return PackageHealthCard{}, err
+	}
+
+	card := PackageHealthCard{
+		Package:      pkg,
+		FanIn:        metrics.FanIn,
+		FanOut:       metrics.FanOut,
+		Instability:  metrics.Instability,
+		Abstractness: metrics.Abstractness,
+		Distance:     metrics.Distance,
+	}
+
+	for _, edge := range snapshot.Edges {
+		if edge.Source == pkg && !edge.Valid {
+			card.Violations++
+		}
+	}
+
+	// Each metric contributes a penalty between 0 and 1
+	couplingPenalty := ratioPenalty(metrics.FanIn+metrics.FanOut, config.MaxCoupling)
+	violationPenalty := ratioPenalty(card.Violations, config.MaxViolations)
+	totalWeight := config.DistanceWeight + config.CouplingWeight + config.ViolationWeight
+	if totalWeight > 0 {
+		penalty := config.DistanceWeight*card.Distance + config.CouplingWeight*couplingPenalty + config.ViolationWeight*violationPenalty
+		card.Score = math.Round(1000*(1-penalty/totalWeight)) / 10
+	} else {
+		card.Score = 100
+	}
+	card.Grade = scoreGrade(card.Score)
+
+	return card, nil
+}
+
+// ratioPenalty scales value against limit, capped at 1
+func ratioPenalty(value, limit int) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return math.Min(1, float64(value)/float64(limit))
+}
+
+// scoreGrade turns a score into a letter grade
+func scoreGrade(score float64) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+// scoreTrend returns an arrow showing how a card's score moved since the previous snapshot
+func scoreTrend(card PackageHealthCard) string {
+	if card.PreviousScore == nil {
+		return " "
+	}
+	switch delta := card.Score - *card.PreviousScore; {
+	case delta >= 0.5:
+		return "↑"
+	case delta <= -0.5:
+		return "↓"
+	default:
+		return "→"
+	}
+}
+
+// printHealthCards prints one line per health card
+func printHealthCards(cards []PackageHealthCard) {
+	fmt.Printf("%-25s %5s %6s %5s %5s %5s %7s %5s %5s\n", "Package", "Grade", "Score", "I", "A", "D", "In/Out", "Viol", "Trend")
+	for _, card := range cards {
+		trend := scoreTrend(card)
+		if card.PreviousScore != nil {
+			trend = fmt.Sprintf("%s %+.1f", trend, card.Score-*card.PreviousScore)
+		}
+		fmt.Printf("%-25s %5s %6.1f %5.2f %5.2f %5.2f %7s %5d  %s\n",
+			card.Package, card.Grade, card.Score, card.Instability, card.Abstractness, card.Distance,
+			fmt.Sprintf("%d/%d", card.FanIn, card.FanOut), card.Violations, trend)
+	}
+}
+
+// runScorecard implements the scorecard subcommand
+func runScorecard(args []string) error {
+	defaults := DefaultScoringConfig()
+
+	fs := flag.NewFlagSet("scorecard", flag.ExitOnError)
+	workspaceFlag := fs.String("workspace", "", "Workspace root directory")
+	workspaceAutoDetectFlag := fs.Bool("workspace-auto-detect", true, workspaceAutoDetectUsage)
+	packagesFlag := fs.String("packages", "packages", "Packages directory relative to workspace")
+	configFlag := fs.String("config", "", "YAML configuration file with additional dependency rules")
+	belowScoreFlag := fs.Float64("below-score", 0, "Only show packages scoring below this value (0 shows all)")
+	previousFlag := fs.String("previous", "", "Snapshot (from --update-baseline) to compare scores against")
+	distanceWeightFlag := fs.Float64("distance-weight", defaults.DistanceWeight, "Weight of the distance from the main sequence")
+	couplingWeightFlag := fs.Float64("coupling-weight", defaults.CouplingWeight, "Weight of the coupling (fan-in + fan-out)")
+	violationWeightFlag := fs.Float64("violation-weight", defaults.ViolationWeight, "Weight of invalid dependencies")
+	fs.Parse(args)
+
+	config := defaults
+	config.DistanceWeight = *distanceWeightFlag
+	config.CouplingWeight = *couplingWeightFlag
+	config.ViolationWeight = *violationWeightFlag
+
+	workspaceRoot, err := resolveWorkspaceRoot(*workspaceFlag, *workspaceAutoDetectFlag)
+	if err != nil {
+		return err
+	}
+
+	analyzer := NewDependencyAnalyzer(workspaceRoot, filepath.Join(workspaceRoot, *packagesFlag))
+	if *configFlag != "" {
+		analyzerConfig, err := LoadAnalyzerConfig(*configFlag)
+		if err != nil {
+			return err
+		}
+		analyzer.ApplyConfig(analyzerConfig)
+	}
+
+	snapshot, err := analyzer.CaptureSnapshot()
+	if err != nil {
+		return err
+	}
+
+	var previous *DependencySnapshot
+	if *previousFlag != "" {
+		loaded, err := LoadSnapshot(*previousFlag)
+		if err != nil {
+			return err
+		}
+		previous = &loaded
+	}
+
+	cards := []PackageHealthCard{}
+	for _, pkg := range snapshot.Packages {
+		card, err := analyzer.scoreSnapshotPackage(snapshot, pkg, config)
+		if err != nil {
+			return err
+		}
+
+		// Packages that did not exist in the previous snapshot have no trend. Snapshots only record
+		// edges, so the previous score reuses the current abstractness.
+		if previous != nil && contains(previous.Packages, pkg) {
+			previousCard, err := analyzer.scoreSnapshotPackage(*previous, pkg, config)
+			if err != nil {
+				return err
+			}
+			card.PreviousScore = &previousCard.Score
+		}
+
+		if *belowScoreFlag > 0 && card.Score >= *belowScoreFlag {
+			continue
+		}
+		cards = append(cards, card)
+	}
+
+	// Worst packages first
+	sort.SliceStable(cards, func(i, j int) bool {
+		if cards[i].Score != cards[j].Score {
+			return cards[i].Score < cards[j].Score
+		}
+		return cards[i].Package < cards[j].Package
+	})
+
+	if len(cards) == 0 {
+		fmt.Printf("✅ No packages score below %.0f\n", *belowScoreFlag)
+		return nil
+	}
+
+	printHealthCards(cards)
+	if previous != nil {
+		fmt.Printf("\nTrend compared with snapshot from %s\n", previous.CapturedAt.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/analyzerclient"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+)
+
+// rpcRequest represents a JSON-RPC 2.0 request
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// rpcResponse represents a JSON-RPC 2.0 response
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// rpcError represents a JSON-RPC 2.0 error object
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// AnalyzerServer exposes a DependencyAnalyzer over JSON-RPC 2.0. Each request is answered by a copy of
+// Analyzer, so the server applies the same rules, query settings and cache as a command-line run.
+type AnalyzerServer struct {
+	Analyzer *DependencyAnalyzer
+}
+
+// NewAnalyzerServer creates a new JSON-RPC server for a configured analyzer
+func NewAnalyzerServer(analyzer *DependencyAnalyzer) *AnalyzerServer {
+	return &AnalyzerServer{Analyzer: analyzer}
+}
+
+// ListenAndServe starts the JSON-RPC server on the given address. An address without a host, such
+// as :9876, binds localhost only; name a host, e.g. 0.0.0.0:9876, to accept remote connections.
+func (s *AnalyzerServer) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/", s)
+
+	addr = serveAddress(addr)
+	log.Printf("Serving JSON-RPC 2.0 for %s on %s", s.Analyzer.WorkspaceRoot, addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// serveAddress binds an address without a host to localhost
+func serveAddress(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("localhost", port)
+}
+
+// ServeHTTP handles a single JSON-RPC request
+func (s *AnalyzerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "JSON-RPC requests must use POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeRPCResponse(w, rpcResponse{Error: &rpcError{rpcParseError, fmt.Sprintf("parse error: %v", err)}})
+		return
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		writeRPCResponse(w, rpcResponse{Error: &rpcError{rpcInvalidRequest, "invalid JSON-RPC 2.0 request"}, ID: req.ID})
+		return
+	}
+
+	result, rpcErr := s.dispatch(req)
+
+	// Notifications do not get a response
+	if len(req.ID) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeRPCResponse(w, rpcResponse{Result: result, Error: rpcErr, ID: req.ID})
+}
+
+// dispatch runs the requested method and returns its result
+func (s *AnalyzerServer) dispatch(req rpcRequest) (interface{}, *rpcError) {
+	var params analyzerclient.Params
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{rpcInvalidParams, fmt.Sprintf("invalid params: %v", err)}
+		}
+	}
+
+	analyzer, err := s.analyzerFor(params)
+	if err != nil {
+		return nil, &rpcError{rpcInvalidParams, err.Error()}
+	}
+
+	switch req.Method {
+	case "analyze":
+		result, err := analyzer.Analyze()
+		if err != nil {
+			return nil, &rpcError{rpcInternalError, err.Error()}
+		}
+		return result, nil
+
+	case "getImpact":
+		if params.Package == "" {
+			return nil, &rpcError{rpcInvalidParams, "getImpact requires a package parameter"}
+		}
+		impacted, err := analyzer.GetImpactSet(params.Package)
+		if err != nil {
+			return nil, &rpcError{rpcInternalError, err.Error()}
+		}
+		return analyzerclient.ImpactResult{Package: params.Package, Impacted: impacted}, nil
+
+	case "findPath":
+		if params.From == "" || params.To == "" {
+			return nil, &rpcError{rpcInvalidParams, "findPath requires from and to parameters"}
+		}
+		path, err := analyzer.FindShortestPath(params.From, params.To)
+		if err != nil {
+			return nil, &rpcError{rpcInternalError, err.Error()}
+		}
+		return analyzerclient.PathResult{From: params.From, To: params.To, Path: path}, nil
+
+	case "snapshot":
+		snapshot, err := analyzer.CaptureSnapshot()
+		if err != nil {
+			return nil, &rpcError{rpcInternalError, err.Error()}
+		}
+		return snapshot, nil
+	}
+
+	return nil, &rpcError{rpcMethodNotFound, fmt.Sprintf("method %q not found", req.Method)}
+}
+
+// analyzerFor creates an analyzer for the packages directory named in the request, which must be inside
+// the workspace
+func (s *AnalyzerServer) analyzerFor(params analyzerclient.Params) (*DependencyAnalyzer, error) {
+	packagesDir := s.Analyzer.PackagesDir
+	if params.Packages != "" {
+		packages := filepath.Clean(params.Packages)
+		if filepath.IsAbs(packages) || packages == ".." || strings.HasPrefix(packages, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("packages must be a path inside the workspace, got %q", params.Packages)
+		}
+		packagesDir = filepath.Join(s.Analyzer.WorkspaceRoot, packages)
+	}
+	return s.Analyzer.withPackagesDir(packagesDir), nil
+}
+
+// writeRPCResponse encodes a JSON-RPC response
+func writeRPCResponse(w http.ResponseWriter, resp rpcResponse) {
+	resp.JSONRPC = "2.0"
+	if resp.ID == nil {
+		resp.ID = json.RawMessage("null")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Warning: Error writing JSON-RPC response: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/notify"
+)
+
+// analysisSlackPayload describes dependency violations for a Slack notification. newViolations are the
+// violations not in the baseline, or nil if no baseline was compared.
+func analysisSlackPayload(result *AnalysisResult, newViolations []DepEdge) notify.SlackPayload {
+	summary := fmt.Sprintf("❌ %d invalid dependencies across %d packages", result.InvalidCount, len(result.Packages))
+	if newViolations == nil {
+		return notify.NewSlackPayload(summary, violationList(result.Edges))
+	}
+
+	summary = fmt.Sprintf("❌ %d new dependency violations (%d in total)", len(newViolations), result.InvalidCount)
+	return notify.NewSlackPayload(summary, violationList(newViolations))
+}
+
+// violationList formats the invalid edges as a Markdown list
+func violationList(edges []DepEdge) string {
+	lines := []string{}
+	for _, edge := range edges {
+		if !edge.Valid {
+			lines = append(lines, fmt.Sprintf("• `%s` → `%s`", edge.Source, edge.Target))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NotifySlack posts the violations of the last analysis to a Slack webhook. With a baseline, only new
+// violations are reported; nothing is posted when there is nothing to report.
+func (a *DependencyAnalyzer) NotifySlack(webhookURL, baselinePath, reportURL string) error {
+	result := a.lastResult
+	if result == nil {
+		var err error
+		if result, err = a.Analyze(); err != nil {
+			return err
+		}
+	}
+
+	var newViolations []DepEdge
+	if baselinePath != "" {
+		baseline, err := LoadSnapshot(baselinePath)
+		if err != nil {
+			return err
+		}
+		current := DependencySnapshot{Packages: result.Packages, Edges: result.Edges}
+		newViolations = DiffSnapshots(baseline, current).NewViolations
+		if len(newViolations) == 0 {
+			return nil
+		}
+	} else if result.InvalidCount == 0 {
+		return nil
+	}
+
+	payload := analysisSlackPayload(result, newViolations).WithReportLink(reportURL)
+	return notify.NewSlackNotifier().Notify(webhookURL, payload)
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/analyzerclient"
+)
+
+// snapshotVersion is the version of the snapshot format written by this analyzer
+const snapshotVersion = "2"
+
+// DependencySnapshot captures the state of the package dependency graph at a point in time. It has the
+// fields of the snapshot JSON-RPC clients decode, plus the analyzer's encoding and comparison methods.
+type DependencySnapshot analyzerclient.DependencySnapshot
+
+// snapshotJSON has the fields of DependencySnapshot without its JSON methods
+type snapshotJSON DependencySnapshot
+
+// sorted returns a copy of the snapshot with packages and edges in a stable order
+func (s DependencySnapshot) sorted() DependencySnapshot {
+	s.Packages = append([]string{}, s.Packages...)
+	sort.Strings(s.Packages)
+
+	s.Edges = append([]DepEdge{}, s.Edges...)
+	sort.Slice(s.Edges, func(i, j int) bool {
+		if s.Edges[i].Source != s.Edges[j].Source {
+			return s.Edges[i].Source < s.Edges[j].Source
+		}
+		return s.Edges[i].Target < s.Edges[j].Target
+	})
+	return s
+}
+
+// MarshalJSON encodes the snapshot with sorted packages and edges, so equal graphs give identical files
+func (s DependencySnapshot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(snapshotJSON(s.sorted()))
+}
+
+// UnmarshalJSON decodes a snapshot, sorting packages and edges and computing metrics missing from
+// snapshots written before they were recorded
+func (s *DependencySnapshot) UnmarshalJSON(data []byte) error {
+	var decoded snapshotJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*s = DependencySnapshot(decoded).sorted()
+	if s.Metrics == nil {
+		s.Metrics = computePackageMetrics(*s)
+	}
+	return nil
+}
+
+// Equal checks if two snapshots describe the same graph. The capture time and workspace root are ignored,
+// so a snapshot can be compared with a golden file captured elsewhere.
+func (s DependencySnapshot) Equal(other DependencySnapshot) bool {
+	a, b := s.sorted(), other.sorted()
+	if len(a.Packages) != len(b.Packages) || len(a.Edges) != len(b.Edges) || len(a.Metrics) != len(b.Metrics) {
+		return false
+	}
+	for i := range a.Packages {
+		if a.Packages[i] != b.Packages[i] {
+			return false
+		}
+	}
+	for i := range a.Edges {
+		if a.Edges[i] != b.Edges[i] {
+			return false
+		}
+	}
+	for pkg, metrics := range a.Metrics {
+		if otherMetrics, exists := b.Metrics[pkg]; !exists || metrics != otherMetrics {
+			return false
+		}
+	}
+	return true
+}
+
+// Diff reports the edges and violations that changed from this snapshot to other
+func (s DependencySnapshot) Diff(other DependencySnapshot) SnapshotDiff {
+	return DiffSnapshots(s, other)
+}
+
+// SnapshotDiff describes how the dependency graph changed between two snapshots
+type SnapshotDiff struct {
+	AddedEdges         []DepEdge `json:"addedEdges"`
+	RemovedEdges       []DepEdge `json:"removedEdges"`
+	NewViolations      []DepEdge `json:"newViolations"`
+	ResolvedViolations []DepEdge `json:"resolvedViolations"` // Invalid edges that were removed or became valid
+}
+
+// HasChanges checks if the diff contains any added or removed edges
+func (d SnapshotDiff) HasChanges() bool {
+	return len(d.AddedEdges) > 0 || len(d.RemovedEdges) > 0
+}
+
+// CaptureSnapshot analyzes the workspace and records the resulting graph
+func (a *DependencyAnalyzer) CaptureSnapshot() (DependencySnapshot, error) {
+	result, err := a.Analyze()
+	if err != nil {
+		return DependencySnapshot{}, err
+	}
+
+	snapshot := DependencySnapshot{
+		Version:       snapshotVersion,
+		CapturedAt:    time.Now().UTC(),
+		WorkspaceRoot: a.WorkspaceRoot,
+		Packages:      result.Packages,
+		Edges:         result.Edges,
+	}
+	snapshot.Metrics = computePackageMetrics(snapshot)
+
+	return snapshot.sorted(), nil
+}
+
+// DiffSnapshots compares two snapshots and reports edges and violations that changed
+func DiffSnapshots(before, after DependencySnapshot) SnapshotDiff {
+	beforeEdges := edgeIndex(before.Edges)
+	afterEdges := edgeIndex(after.Edges)
+
+	diff := SnapshotDiff{
+		AddedEdges:         []DepEdge{},
+		RemovedEdges:       []DepEdge{},
+		NewViolations:      []DepEdge{},
+		ResolvedViolations: []DepEdge{},
+	}
+
+	for _, edge := range after.Edges {
+		old, existed := beforeEdges[edgeKey(edge)]
+		if !existed {
+			diff.AddedEdges = append(diff.AddedEdges, edge)
+		}
+		if !edge.Valid && (!existed || old.Valid) {
+			diff.NewViolations = append(diff.NewViolations, edge)
+		}
+	}
+
+	for _, edge := range before.Edges {
+		current, exists := afterEdges[edgeKey(edge)]
+		if !exists {
+			diff.RemovedEdges = append(diff.RemovedEdges, edge)
+		}
+		if !edge.Valid && (!exists || current.Valid) {
+			diff.ResolvedViolations = append(diff.ResolvedViolations, edge)
+		}
+	}
+
+	return diff
+}
+
+// LoadSnapshot reads a snapshot from a JSON file
+func LoadSnapshot(path string) (DependencySnapshot, error) {
+	var snapshot DependencySnapshot
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return snapshot, fmt.Errorf("error reading snapshot: %v", err)
+	}
+
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("error parsing snapshot %s: %v", path, err)
+	}
+
+	return snapshot, nil
+}
+
+// SaveSnapshot writes a snapshot to a JSON file
+func SaveSnapshot(path string, snapshot DependencySnapshot) error {
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding snapshot: %v", err)
+	}
+
+	if err := ioutil.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing snapshot %s: %v", path, err)
+	}
+
+	return nil
+}
+
+// CompareBaseline checks the current graph against a committed baseline snapshot.
+// It returns false if any edge appears that is not in the baseline.
+func (a *DependencyAnalyzer) CompareBaseline(baselinePath string) (bool, error) {
+	baseline, err := LoadSnapshot(baselinePath)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := a.CaptureSnapshot()
+	if err != nil {
+		return false, err
+	}
+
+	diff := baseline.Diff(current)
+
+	removed := edgeIndex(diff.RemovedEdges)
+	for _, edge := range diff.ResolvedViolations {
+		if _, gone := removed[edgeKey(edge)]; gone {
+			fmt.Printf("ℹ️ Resolved violation: %s no longer depends on %s\n", edge.Source, edge.Target)
+		} else {
+			fmt.Printf("ℹ️ Resolved violation: %s -> %s is no longer a violation\n", edge.Source, edge.Target)
+		}
+	}
+	for _, edge := range diff.RemovedEdges {
+		if !edge.Valid {
+			continue // Already reported as a resolved violation
+		}
+		fmt.Printf("ℹ️ Removed dependency: %s -> %s\n", edge.Source, edge.Target)
+	}
+
+	if len(diff.AddedEdges) == 0 {
+		fmt.Printf("✅ Dependency graph matches baseline %s.\n", baselinePath)
+		return true, nil
+	}
+
+	for _, edge := range diff.AddedEdges {
+		status := ""
+		if !edge.Valid {
+			status = " (INVALID)"
+		}
+		fmt.Printf("❌ NEW DEPENDENCY: %s -> %s%s\n", edge.Source, edge.Target, status)
+	}
+	fmt.Printf("❌ Found %d dependencies not in baseline %s.\n", len(diff.AddedEdges), baselinePath)
+	fmt.Println("   If this change is intentional, re-run with --update-baseline and commit the result.")
+
+	return false, nil
+}
+
+// edgeKey returns a unique key for an edge
+func edgeKey(edge DepEdge) string {
+	return edge.Source + " -> " + edge.Target
+}
+
+// edgeIndex indexes edges by their key
+func edgeIndex(edges []DepEdge) map[string]DepEdge {
+	index := make(map[string]DepEdge, len(edges))
+	for _, edge := range edges {
+		index[edgeKey(edge)] = edge
+	}
+	return index
+}
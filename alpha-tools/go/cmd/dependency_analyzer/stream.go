@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamDependencies analyzes the workspace and writes every package edge to out as a JSON line as soon as it is
+// found. Progress messages and warnings go to progress so that out stays machine-readable.
+// It returns false if any edge is invalid or, in strict mode, any warning was reported.
+func (a *DependencyAnalyzer) StreamDependencies(out, progress io.Writer) (bool, error) {
+	a.strictErrors = 0
+	a.messages = progress
+	defer func() { a.messages = nil }()
+
+	encoder := json.NewEncoder(out)
+	seen := make(map[string]bool)
+	edgeCount, invalidCount := 0, 0
+
+	emit := func(source, target string) error {
+		edge := DepEdge{Source: source, Target: target}
+		if seen[edgeKey(edge)] {
+			return nil
+		}
+		seen[edgeKey(edge)] = true
+
+		edge.Valid = a.IsDependencyValid(source, target)
+		if !edge.Valid {
+			invalidCount++
+		}
+		edgeCount++
+		if err := encoder.Encode(edge); err != nil {
+			return fmt.Errorf("error writing edge: %v", err)
+		}
+		return nil
+	}
+
+	if a.ResolveMacros {
+		// Macro expansion reads every rule in one query, so there is nothing to stream per target
+		fmt.Fprintln(progress, "Resolving macro-expanded rules...")
+		packageDeps, err := a.buildExpandedPackageGraph()
+		if err != nil {
+			return false, err
+		}
+		for _, edge := range graphEdges(packageDeps) {
+			if err := emit(edge.Source, edge.Target); err != nil {
+				return false, err
+			}
+		}
+	} else {
+		result, err := a.RunBazelQuery("//packages/...")
+		if err != nil {
+			return false, fmt.Errorf("error querying packages: %v", err)
+		}
+
+		for i, target := range result.Target {
+			sourcePkg := a.ParseTargetPackage(target.Name)
+			if sourcePkg == "" {
+				continue
+			}
+
+			fmt.Fprintf(progress, "[%d/%d] %s\n", i+1, len(result.Target), target.Name)
+			targetPkgs, err := a.targetPackageDeps(target.Name, sourcePkg)
+			if err != nil {
+				a.warn("Error querying dependencies for %s: %v", target.Name, err)
+				continue
+			}
+
+			for _, targetPkg := range targetPkgs {
+				if err := emit(sourcePkg, targetPkg); err != nil {
+					return false, err
+				}
+			}
+		}
+	}
+
+	fmt.Fprintf(progress, "Streamed %d edges, %d invalid.\n", edgeCount, invalidCount)
+	return invalidCount+a.strictErrors == 0, nil
+}
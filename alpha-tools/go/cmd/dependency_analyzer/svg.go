@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Sizes in pixels for the static SVG graph
+const (
+	svgNodeHeight = 32
+	svgCharWidth  = 8  // Approximate width of a 13px sans-serif character
+	svgNodePad    = 24 // Horizontal padding inside a node
+	svgNodeGap    = 32 // Between nodes in a rank
+	svgRankGap    = 80 // Between ranks
+	svgMargin     = 20
+)
+
+// svgNode is a package laid out in the static SVG graph; X and Y are its top-left corner
+type svgNode struct {
+	Name        string
+	Rank        int
+	X, Y, Width int
+}
+
+// layoutSVGGraph places the packages in rows by rank like dot's top-to-bottom layout, with each row centred.
+// A package's rank is its depth, so packages without dependencies are at the bottom. It returns the nodes by
+// name and the size of the drawing.
+func layoutSVGGraph(packages []string, edges []DepEdge) (map[string]*svgNode, int, int) {
+	ranks := packageDepths(packages, edges)
+	maxRank := 0
+	for _, r := range ranks {
+		if r > maxRank {
+			maxRank = r
+		}
+	}
+
+	rows := make([][]*svgNode, maxRank+1)
+	for _, pkg := range packages {
+		node := &svgNode{Name: pkg, Rank: ranks[pkg], Width: len(pkg)*svgCharWidth + svgNodePad}
+		rows[maxRank-node.Rank] = append(rows[maxRank-node.Rank], node)
+	}
+
+	rowWidths := make([]int, len(rows))
+	width := 0
+	for i, row := range rows {
+		for j, node := range row {
+			if j > 0 {
+				rowWidths[i] += svgNodeGap
+			}
+			rowWidths[i] += node.Width
+		}
+		if rowWidths[i] > width {
+			width = rowWidths[i]
+		}
+	}
+
+	nodes := make(map[string]*svgNode)
+	for i, row := range rows {
+		x := svgMargin + (width-rowWidths[i])/2
+		for _, node := range row {
+			node.X = x
+			node.Y = svgMargin + i*(svgNodeHeight+svgRankGap)
+			x += node.Width + svgNodeGap
+			nodes[node.Name] = node
+		}
+	}
+	height := len(rows)*svgNodeHeight + (len(rows)-1)*svgRankGap
+	return nodes, width + 2*svgMargin, height + 2*svgMargin
+}
+
+// RenderGraphSVG draws the package graph as a self-contained SVG document in the same style as the DOT
+// graph: packages are coloured boxes in ranks, dependencies point down, and invalid dependencies are red
+func RenderGraphSVG(packages []string, edges []DepEdge) string {
+	nodes, width, height := layoutSVGGraph(packages, edges)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="13">`+"\n", width, height, width, height)
+	b.WriteString(`<defs>` +
+		`<marker id="svg-arrow-valid" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="7" markerHeight="7" orient="auto"><path d="M0,0L10,5L0,10z" fill="#555"/></marker>` +
+		`<marker id="svg-arrow-invalid" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="7" markerHeight="7" orient="auto"><path d="M0,0L10,5L0,10z" fill="#d62728"/></marker>` +
+		"</defs>\n")
+
+	for _, edge := range edges {
+		source, target := nodes[edge.Source], nodes[edge.Target]
+		if source == nil || target == nil {
+			continue
+		}
+		x1, y1, x2, y2 := svgEdgeEnds(source, target)
+		stroke, marker := "#555", "svg-arrow-valid"
+		if !edge.Valid {
+			stroke, marker = "#d62728", "svg-arrow-invalid"
+		}
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="1.5" marker-end="url(#%s)"><title>%s</title></line>`+"\n",
+			x1, y1, x2, y2, stroke, marker, html.EscapeString(edge.Source+" -> "+edge.Target))
+	}
+
+	for _, pkg := range packages {
+		node := nodes[pkg]
+		fmt.Fprintf(&b, `<g><rect x="%d" y="%d" width="%d" height="%d" rx="4" fill="%s" stroke="#333"/>`,
+			node.X, node.Y, node.Width, svgNodeHeight, packageColor(pkg))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="central">%s</text></g>`+"\n",
+			node.X+node.Width/2, node.Y+svgNodeHeight/2, html.EscapeString(pkg))
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// svgEdgeEnds returns where an edge leaves its source and enters its target: the bottom and top of the boxes
+// when the target is lower, the top and bottom when it is higher, and their facing sides within a rank
+func svgEdgeEnds(source, target *svgNode) (int, int, int, int) {
+	sx, tx := source.X+source.Width/2, target.X+target.Width/2
+	switch {
+	case target.Y > source.Y:
+		return sx, source.Y + svgNodeHeight, tx, target.Y
+	case target.Y < source.Y:
+		return sx, source.Y, tx, target.Y + svgNodeHeight
+	case target.X > source.X:
+		return source.X + source.Width, source.Y + svgNodeHeight/2, target.X, target.Y + svgNodeHeight/2
+	default:
+		return source.X, source.Y + svgNodeHeight/2, target.X + target.Width, target.Y + svgNodeHeight/2
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ListedTarget is a target found by parsing a BUILD file
+type ListedTarget struct {
+	Label string `json:"label"`
+	Kind  string `json:"kind"`
+}
+
+// ListTargets parses the BUILD files under packagesDir, without Bazel, and returns their named targets
+// sorted by package and name. Labels start with packagesDir relative to the current directory, which should
+// be the workspace root.
+func ListTargets(packagesDir string) ([]ListedTarget, error) {
+	root, err := workspaceRelativePath(packagesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []ListedTarget{}
+	err = filepath.Walk(packagesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || (info.Name() != "BUILD" && info.Name() != "BUILD.bazel") {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading BUILD file: %v", err)
+		}
+
+		rel, err := filepath.Rel(packagesDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		packagePath := root
+		if rel != "." {
+			packagePath += "/" + filepath.ToSlash(rel)
+		}
+
+		for _, rule := range ParseBuildRules(string(content)) {
+			if rule.Name == "" {
+				continue // load(), package() and similar calls
+			}
+			targets = append(targets, ListedTarget{Label: fmt.Sprintf("//%s:%s", packagePath, rule.Name), Kind: rule.Kind})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s: %v", packagesDir, err)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		packageI, nameI := splitLabel(targets[i].Label)
+		packageJ, nameJ := splitLabel(targets[j].Label)
+		if packageI != packageJ {
+			return packageI < packageJ
+		}
+		return nameI < nameJ
+	})
+	return targets, nil
+}
+
+// workspaceRelativePath returns dir relative to the current directory with forward slashes
+func workspaceRelativePath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("error getting absolute path: %v", err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("error getting current directory: %v", err)
+	}
+	rel, err := filepath.Rel(cwd, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside the current directory; run list-targets from the workspace root", dir)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// runListTargets implements the list-targets subcommand
+func runListTargets(args []string) error {
+	fs := flag.NewFlagSet("list-targets", flag.ExitOnError)
+	packagesDirFlag := fs.String("packages-dir", "packages", "Packages directory, relative to the workspace root")
+	filterFlag := fs.String("filter", "", "Only list targets whose label contains this substring")
+	kindFlag := fs.String("kind", "", "Only list targets of this rule kind, e.g. umbra_swift_library")
+	formatFlag := fs.String("format", "text", "Output format: text (one label per line) or json")
+	fs.Parse(args)
+
+	targets, err := ListTargets(*packagesDirFlag)
+	if err != nil {
+		return err
+	}
+
+	matching := []ListedTarget{}
+	for _, target := range targets {
+		if strings.Contains(target.Label, *filterFlag) && (*kindFlag == "" || target.Kind == *kindFlag) {
+			matching = append(matching, target)
+		}
+	}
+
+	switch *formatFlag {
+	case "text":
+		for _, target := range matching {
+			fmt.Println(target.Label)
+		}
+	case "json":
+		content, err := json.MarshalIndent(matching, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding targets: %v", err)
+		}
+		fmt.Println(string(content))
+	default:
+		return fmt.Errorf("unknown format %q (expected text or json)", *formatFlag)
+	}
+	return nil
+}
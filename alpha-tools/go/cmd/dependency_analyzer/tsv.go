@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PrintTSVReport prints every package dependency as a tab-separated SOURCE, TARGET, STATUS, VIOLATION_MESSAGE row
+// for processing with awk, cut and sort. It returns false if any dependency is invalid.
+func (a *DependencyAnalyzer) PrintTSVReport(headers bool) (bool, error) {
+	// Warnings go to stderr so they do not end up among the rows
+	a.messages = os.Stderr
+	defer func() { a.messages = nil }()
+
+	result, err := a.Analyze()
+	if err != nil {
+		return false, err
+	}
+
+	if headers {
+		fmt.Println(strings.Join([]string{"SOURCE", "TARGET", "STATUS", "VIOLATION_MESSAGE"}, "\t"))
+	}
+	for _, edge := range result.Edges {
+		status, message := "VALID", ""
+		if !edge.Valid {
+			status = "INVALID"
+			message = fmt.Sprintf("%s depends on %s, which violates the Alpha Dot Five dependency rules", edge.Source, edge.Target)
+		}
+		fmt.Println(strings.Join([]string{edge.Source, edge.Target, status, message}, "\t"))
+	}
+
+	return result.InvalidCount+a.strictErrors == 0, nil
+}
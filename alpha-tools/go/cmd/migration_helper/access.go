@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// topLevelDeclPattern matches top-level Swift declarations with internal (explicit or implicit) or fileprivate access
+var topLevelDeclPattern = regexp.MustCompile(`(?m)^(?:@\w+\s+)*(?:(internal|fileprivate)\s+)?(?:final\s+)?(class|struct|enum|protocol|func|var|let|typealias)\s+(\w+)`)
+
+// AccessWarning describes a non-public symbol that is referenced across a boundary the migration removed
+type AccessWarning struct {
+	Symbol               string `json:"symbol"`
+	DefinedIn            string `json:"definedIn"`
+	UsedIn               string `json:"usedIn"`
+	SuggestedAccessLevel string `json:"suggestedAccessLevel"`
+}
+
+// swiftSymbol is a non-public top-level declaration found in a migrated file
+type swiftSymbol struct {
+	name        string
+	accessLevel string
+	file        string
+}
+
+// CheckAccessLevels scans the Swift files migrated into targetPackage for internal and fileprivate symbols that are
+// referenced from files which came from a different source module, or from a different file for fileprivate symbols.
+// Source modules are taken from the migration journal.
+func (m *MigrationHelper) CheckAccessLevels(targetPackage string) ([]AccessWarning, error) {
+	packageDir := m.TargetModulePath(targetPackage)
+	if !dirExists(packageDir) {
+		return nil, fmt.Errorf("target package %s not found at %s", targetPackage, packageDir)
+	}
+
+	origins, err := m.fileOrigins()
+	if err != nil {
+		return nil, err
+	}
+
+	// Read every Swift file in the package
+	contents := make(map[string]string)
+	err = filepath.Walk(packageDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".swift") {
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", path, err)
+		}
+		contents[path] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s: %v", packageDir, err)
+	}
+
+	files := sortedFiles(contents)
+
+	// Collect non-public top-level declarations
+	symbols := []swiftSymbol{}
+	for _, file := range files {
+		for _, match := range topLevelDeclPattern.FindAllStringSubmatch(contents[file], -1) {
+			accessLevel := match[1]
+			if accessLevel == "" {
+				accessLevel = "internal"
+			}
+			symbols = append(symbols, swiftSymbol{name: match[3], accessLevel: accessLevel, file: file})
+		}
+	}
+
+	warnings := []AccessWarning{}
+	for _, symbol := range symbols {
+		reference := regexp.MustCompile(`\b` + regexp.QuoteMeta(symbol.name) + `\b`)
+
+		crossUses := []string{}
+		usedBySameOrigin := false
+		for _, file := range files {
+			if file == symbol.file || !reference.MatchString(contents[file]) {
+				continue
+			}
+
+			definedOrigin, usedOrigin := origins[symbol.file], origins[file]
+			switch {
+			case symbol.accessLevel == "fileprivate":
+				crossUses = append(crossUses, file)
+			case definedOrigin != "" && usedOrigin != "" && definedOrigin != usedOrigin:
+				crossUses = append(crossUses, file)
+			default:
+				usedBySameOrigin = true
+			}
+		}
+
+		// fileprivate symbols must be widened to compile; internal symbols that were only visible to
+		// their own file can be narrowed to restore the original boundary
+		suggested := "internal"
+		if symbol.accessLevel == "internal" && !usedBySameOrigin {
+			suggested = "fileprivate"
+		}
+
+		for _, file := range crossUses {
+			warnings = append(warnings, AccessWarning{
+				Symbol:               symbol.name,
+				DefinedIn:            m.relativeToTarget(symbol.file),
+				UsedIn:               m.relativeToTarget(file),
+				SuggestedAccessLevel: suggested,
+			})
+		}
+	}
+
+	return warnings, nil
+}
+
+// fileOrigins maps migrated file paths to the source module they were most recently migrated from
+func (m *MigrationHelper) fileOrigins() (map[string]string, error) {
+	entries, err := readJournal(journalPath(m.TargetDir))
+	if err != nil {
+		return nil, err
+	}
+
+	origins := make(map[string]string)
+	for _, entry := range entries {
+		if !entry.Success {
+			continue
+		}
+		for _, file := range entry.Files {
+			origins[filepath.Join(m.TargetDir, filepath.FromSlash(file))] = entry.Module
+		}
+	}
+
+	return origins, nil
+}
+
+// relativeToTarget returns path relative to the target directory where possible
+func (m *MigrationHelper) relativeToTarget(path string) string {
+	if rel, err := filepath.Rel(m.TargetDir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// sortedFiles returns the keys of a file content map in sorted order
+func sortedFiles(contents map[string]string) []string {
+	files := make([]string, 0, len(contents))
+	for file := range contents {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/workspace"
+)
+
+// publicDeclPattern matches public Swift declarations
+var publicDeclPattern = regexp.MustCompile(`\bpublic\s+(class|struct|enum|protocol|func|var|let|typealias)\s+(\w+)`)
+
+// APIDiff describes a change to a single public declaration
+type APIDiff struct {
+	Kind     string `json:"kind"` // "added", "removed" or "unchanged"
+	DeclName string `json:"declName"`
+	File     string `json:"file"`
+}
+
+// ComparePublicAPIs compares the public declarations of moduleName under sourceDir with those of the
+// migrated module directory targetDir
+func ComparePublicAPIs(sourceDir, targetDir, moduleName string) ([]APIDiff, error) {
+	sourceDecls, err := collectPublicDecls(filepath.Join(sourceDir, moduleName))
+	if err != nil {
+		return nil, err
+	}
+
+	targetDecls, err := collectPublicDecls(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []APIDiff{}
+	for decl, file := range targetDecls {
+		kind := "added"
+		if _, exists := sourceDecls[decl]; exists {
+			kind = "unchanged"
+		}
+		diffs = append(diffs, APIDiff{Kind: kind, DeclName: decl, File: file})
+	}
+	for decl, file := range sourceDecls {
+		if _, exists := targetDecls[decl]; !exists {
+			diffs = append(diffs, APIDiff{Kind: "removed", DeclName: decl, File: file})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind < diffs[j].Kind
+		}
+		return diffs[i].DeclName < diffs[j].DeclName
+	})
+
+	return diffs, nil
+}
+
+// collectPublicDecls maps each public declaration ("kind name") in a directory to the first file declaring it
+func collectPublicDecls(dir string) (map[string]string, error) {
+	decls := make(map[string]string)
+
+	module, err := workspace.NewWorkspaceScanner().ScanModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range module.SwiftFiles {
+		// Skip tests, as migration does
+		if workspace.IsTestPath(file) {
+			continue
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range publicDeclPattern.FindAllStringSubmatch(string(content), -1) {
+			decl := match[1] + " " + match[2]
+			if _, exists := decls[decl]; !exists {
+				decls[decl] = path
+			}
+		}
+	}
+
+	return decls, nil
+}
+
+// apiChanges filters out unchanged declarations
+func apiChanges(diffs []APIDiff) []APIDiff {
+	changes := []APIDiff{}
+	for _, diff := range diffs {
+		if diff.Kind != "unchanged" {
+			changes = append(changes, diff)
+		}
+	}
+	return changes
+}
+
+// verifyPublicAPI compares the public API of a module migrated to targetPackage with its source and records
+// the changes in the module's result, which fails if there are any. It returns false if the API changed.
+func (m *MigrationHelper) verifyPublicAPI(moduleName, targetPackage string) (bool, error) {
+	diffs, err := ComparePublicAPIs(m.FindModuleSourceDir(moduleName), m.TargetModulePath(targetPackage), moduleName)
+	if err != nil {
+		return false, fmt.Errorf("error comparing public APIs: %v", err)
+	}
+
+	changes := apiChanges(diffs)
+	result := &m.Results[len(m.Results)-1]
+	result.APIChanges = changes
+	if len(changes) == 0 {
+		fmt.Printf("✅ Public API unchanged (%d declarations)\n", len(diffs))
+		return true, nil
+	}
+
+	for _, change := range changes {
+		fmt.Printf("❌ Public API %s: %s (%s)\n", change.Kind, change.DeclName, change.File)
+	}
+	result.Success = false
+	return false, nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/merkle"
+)
+
+// unknownOperator groups journal entries recorded without an operator
+const unknownOperator = "unknown"
+
+var (
+	operatorOnce sync.Once
+	operator     string
+)
+
+// journalOperator returns the git user.email of whoever runs the migration helper, looked up once per run.
+// It is empty if git has no user configured.
+func journalOperator() string {
+	operatorOnce.Do(func() {
+		output, err := exec.Command("git", "config", "user.email").Output()
+		if err == nil {
+			operator = strings.TrimSpace(string(output))
+		}
+	})
+	return operator
+}
+
+// AuditTrail is a tamper-evident record of the migration journal: RootHash is the Merkle root of the
+// entries in journal order, so changing, removing, adding or reordering any entry changes it
+type AuditTrail struct {
+	GeneratedAt time.Time        `json:"generatedAt"`
+	Journal     string           `json:"journal"`
+	RootHash    string           `json:"rootHash"`
+	Operators   map[string][]int `json:"operators"` // Indexes into Entries by operator
+	Entries     []JournalEntry   `json:"entries"`
+}
+
+// NewAuditTrail builds the audit trail of a journal's entries
+func NewAuditTrail(journalPath string, entries []JournalEntry) (*AuditTrail, error) {
+	rootHash, err := auditRootHash(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	trail := &AuditTrail{
+		GeneratedAt: time.Now().UTC(),
+		Journal:     journalPath,
+		RootHash:    rootHash,
+		Operators:   auditOperators(entries),
+		Entries:     entries,
+	}
+	return trail, nil
+}
+
+// auditRootHash returns the hex Merkle root over the JSON encoding of each entry
+func auditRootHash(entries []JournalEntry) (string, error) {
+	records := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		record, err := json.Marshal(entry)
+		if err != nil {
+			return "", fmt.Errorf("error encoding journal entry: %v", err)
+		}
+		records = append(records, record)
+	}
+	return merkle.RootHex(records), nil
+}
+
+// auditOperators groups entry indexes by operator
+func auditOperators(entries []JournalEntry) map[string][]int {
+	operators := make(map[string][]int)
+	for i, entry := range entries {
+		name := entry.Operator
+		if name == "" {
+			name = unknownOperator
+		}
+		operators[name] = append(operators[name], i)
+	}
+	return operators
+}
+
+// Verify recomputes the root hash and operator groups of the trail's entries and reports any mismatch
+func (t *AuditTrail) Verify() error {
+	rootHash, err := auditRootHash(t.Entries)
+	if err != nil {
+		return err
+	}
+	if rootHash != t.RootHash {
+		return fmt.Errorf("root hash mismatch: recorded %s, entries hash to %s", t.RootHash, rootHash)
+	}
+
+	expected := auditOperators(t.Entries)
+	if len(expected) != len(t.Operators) {
+		return fmt.Errorf("operator groups do not match the entries")
+	}
+	for name, indexes := range expected {
+		if fmt.Sprint(indexes) != fmt.Sprint(t.Operators[name]) {
+			return fmt.Errorf("operator group %s does not match the entries", name)
+		}
+	}
+	return nil
+}
+
+// Save writes the audit trail to path as JSON
+func (t *AuditTrail) Save(path string) error {
+	content, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding audit trail: %v", err)
+	}
+	if err := ioutil.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", path, err)
+	}
+	return nil
+}
+
+// LoadAuditTrail reads an audit trail written by Save
+func LoadAuditTrail(path string) (*AuditTrail, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading audit trail: %v", err)
+	}
+	var trail AuditTrail
+	if err := json.Unmarshal(content, &trail); err != nil {
+		return nil, fmt.Errorf("error parsing audit trail %s: %v", path, err)
+	}
+	return &trail, nil
+}
+
+// runAuditTrail implements the audit-trail subcommand
+func runAuditTrail(args []string) error {
+	fs := flag.NewFlagSet("audit-trail", flag.ExitOnError)
+	journalFlag := fs.String("journal", journalPath("packages"), "Migration journal to record")
+	outputFlag := fs.String("output", "audit.json", "File to write the audit trail to")
+	verifyFlag := fs.String("verify", "", "Check that this audit trail has not been modified instead of writing one")
+	fs.Parse(args)
+
+	if *verifyFlag != "" {
+		trail, err := LoadAuditTrail(*verifyFlag)
+		if err != nil {
+			return err
+		}
+		if err := trail.Verify(); err != nil {
+			fmt.Printf("❌ %s has been modified: %v\n", *verifyFlag, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ %s is intact: %d entries, root hash %s\n", *verifyFlag, len(trail.Entries), trail.RootHash)
+		return nil
+	}
+
+	if _, err := os.Stat(*journalFlag); err != nil {
+		return fmt.Errorf("error reading journal: %v", err)
+	}
+	entries, err := readJournal(*journalFlag)
+	if err != nil {
+		return err
+	}
+
+	trail, err := NewAuditTrail(*journalFlag, entries)
+	if err != nil {
+		return err
+	}
+	if err := trail.Save(*outputFlag); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(trail.Operators))
+	for name := range trail.Operators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %s: %d entries\n", name, len(trail.Operators[name]))
+	}
+	fmt.Printf("✅ Audit trail of %d entries written to %s (root hash %s)\n", len(entries), *outputFlag, trail.RootHash)
+	return nil
+}
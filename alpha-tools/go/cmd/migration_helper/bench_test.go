@@ -0,0 +1,184 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixtureFiles sets the size of the synthetic module, e.g. go test -bench . -args -fixture-files 500
+var fixtureFiles = flag.Int("fixture-files", 100, "Number of Swift files in the synthetic benchmark module")
+
+// benchModule is the source module of the synthetic workspace
+const benchModule = "BenchModule"
+
+// benchImports are the imports at the top of every synthetic Swift file; most are mapped to new packages
+var benchImports = []string{
+	"import Foundation",
+	"import CoreDTOs",
+	"import KeyManagementTypes",
+	"@preconcurrency import SecurityTypes",
+	"import ErrorHandling",
+	"import UmbraLogging",
+}
+
+// benchFixture is a synthetic workspace with one source module
+type benchFixture struct {
+	root       string
+	sourcesDir string
+	swiftFile  string // Content of every Swift file in the module
+}
+
+var fixture *benchFixture
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	var err error
+	fixture, err = newBenchFixture(*fixtureFiles)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error creating benchmark fixture: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	os.RemoveAll(fixture.root)
+	os.Exit(code)
+}
+
+// newBenchFixture writes a workspace whose source module has files Swift files to a temporary directory
+func newBenchFixture(files int) (*benchFixture, error) {
+	root, err := ioutil.TempDir("", "migration-helper-bench")
+	if err != nil {
+		return nil, err
+	}
+
+	var content strings.Builder
+	for _, line := range benchImports {
+		content.WriteString(line + "\n")
+	}
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&content, "\npublic struct Value%d {\n    public let id: Int = %d\n}\n", i, i)
+	}
+
+	f := &benchFixture{
+		root:       root,
+		sourcesDir: filepath.Join(root, "Sources"),
+		swiftFile:  content.String(),
+	}
+	for i := 0; i < files; i++ {
+		// Spread the files over a few subdirectories, as real modules do
+		dir := filepath.Join(f.sourcesDir, benchModule, fmt.Sprintf("Group%d", i%5))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		path := filepath.Join(dir, fmt.Sprintf("File%04d.swift", i))
+		if err := ioutil.WriteFile(path, []byte(f.swiftFile), 0644); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// silenceStdout discards what the benchmark prints to stdout
+func silenceStdout(b *testing.B) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	b.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+}
+
+func BenchmarkMigrateModule(b *testing.B) {
+	targetDir := filepath.Join(fixture.root, "packages")
+	silenceStdout(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Start from an empty target so the journal does not grow between iterations
+		b.StopTimer()
+		if err := os.RemoveAll(targetDir); err != nil {
+			b.Fatal(err)
+		}
+		helper := NewMigrationHelper([]string{fixture.sourcesDir}, targetDir, fixture.root)
+		b.StartTimer()
+
+		success, err := helper.MigrateModule(benchModule, "UmbraCoreTypes/"+benchModule, true)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if !success {
+			b.Fatal("migration copied no files")
+		}
+	}
+}
+
+// parallelBenchFiles is the size of the module BenchmarkMigrateModuleParallelism migrates
+const parallelBenchFiles = 200
+
+func BenchmarkMigrateModuleParallelism(b *testing.B) {
+	f, err := newBenchFixture(parallelBenchFiles)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(f.root)
+
+	targetDir := filepath.Join(f.root, "packages")
+	silenceStdout(b)
+	for _, parallelism := range []int{1, 2, 4, defaultParallelism} {
+		b.Run(fmt.Sprintf("workers=%d", parallelism), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				if err := os.RemoveAll(targetDir); err != nil {
+					b.Fatal(err)
+				}
+				helper := NewMigrationHelper([]string{f.sourcesDir}, targetDir, f.root)
+				helper.Parallelism = parallelism
+				b.StartTimer()
+
+				success, err := helper.MigrateModule(benchModule, "UmbraCoreTypes/"+benchModule, true)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if !success {
+					b.Fatal("migration copied no files")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUpdateImports(b *testing.B) {
+	path := filepath.Join(fixture.root, "UpdateImports.swift")
+	helper := NewMigrationHelper([]string{fixture.sourcesDir}, filepath.Join(fixture.root, "packages"), fixture.root)
+	moduleMapping := make(map[string]string)
+	for _, mapping := range helper.EffectiveMappings() {
+		moduleMapping[mapping.SourceModule] = mapping.ImportModuleAs
+	}
+
+	silenceStdout(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Restore the original imports, which the previous iteration rewrote
+		b.StopTimer()
+		if err := ioutil.WriteFile(path, []byte(fixture.swiftFile), 0644); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		if err := helper.UpdateImports(path, moduleMapping); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
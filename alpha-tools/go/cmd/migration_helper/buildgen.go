@@ -0,0 +1,297 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// labelListPattern matches a deps or srcs list of labels in a BUILD file
+var labelListPattern = regexp.MustCompile(`(?m)^([ \t]*)(deps|srcs)\s*=\s*\[([^\]]*)\]`)
+
+// defaultExcludePatterns are left out of the srcs glob of generated BUILD files
+var defaultExcludePatterns = []string{
+	"**/Tests/**",
+	"**/*Test.swift",
+	"**/*.generated.swift",
+}
+
+// BuildSpec describes the umbra_swift_library target of a generated BUILD file
+type BuildSpec struct {
+	PackageName     string            // Top-level package, e.g. UmbraCoreTypes
+	SubpackagePath  string            // Path under Sources/; empty for the package's main BUILD file
+	TargetName      string            // Defaults to the last subpackage path element, or the package name
+	GlobPattern     string            // srcs glob pattern; defaults to one **/*.<ext> pattern per extension, under Sources/ for a package
+	Extensions      []string          // File extensions of the default glob patterns, without dots; defaults to swift
+	ExcludePatterns []string          // Defaults to defaultExcludePatterns
+	Deps            []string          // Bazel labels
+	Visibility      []string          // Defaults to the package's subpackages, or public for a package
+	ExtraAttrs      map[string]string // Additional attributes as Starlark expressions, e.g. resources
+}
+
+// reservedBuildAttrs are the attributes the generator always writes itself
+var reservedBuildAttrs = []string{"name", "srcs", "deps", "visibility"}
+
+// BuildFileGenerator renders BUILD files for umbra_swift_library targets
+type BuildFileGenerator struct{}
+
+// NewBuildFileGenerator creates a new BUILD file generator
+func NewBuildFileGenerator() *BuildFileGenerator {
+	return &BuildFileGenerator{}
+}
+
+// Generate returns the Starlark content of the BUILD file described by spec
+func (g *BuildFileGenerator) Generate(spec BuildSpec) (string, error) {
+	if spec.PackageName == "" {
+		return "", fmt.Errorf("build spec must set PackageName")
+	}
+
+	targetName := spec.TargetName
+	if targetName == "" {
+		targetName = spec.PackageName
+		if spec.SubpackagePath != "" {
+			parts := strings.Split(spec.SubpackagePath, "/")
+			targetName = parts[len(parts)-1]
+		}
+	}
+
+	globPatterns := []string{spec.GlobPattern}
+	if spec.GlobPattern == "" {
+		extensions := spec.Extensions
+		if len(extensions) == 0 {
+			extensions = []string{"swift"}
+		}
+		globPatterns = []string{}
+		for _, ext := range extensions {
+			if spec.SubpackagePath != "" {
+				globPatterns = append(globPatterns, "**/*."+ext)
+			} else {
+				globPatterns = append(globPatterns, "Sources/**/*."+ext)
+			}
+		}
+	}
+
+	excludePatterns := spec.ExcludePatterns
+	if excludePatterns == nil {
+		excludePatterns = defaultExcludePatterns
+	}
+
+	visibility := spec.Visibility
+	if len(visibility) == 0 {
+		visibility = []string{"//visibility:public"}
+		if spec.SubpackagePath != "" {
+			visibility = []string{fmt.Sprintf("//packages/%s:__subpackages__", spec.PackageName)}
+		}
+	}
+
+	// Format dependencies for Starlark
+	depsStr := ""
+	if len(spec.Deps) > 0 {
+		depsStr = fmt.Sprintf("\n    deps = [\n%s,\n    ],", strings.Join(quoteAll(spec.Deps, "        "), ",\n"))
+	}
+
+	// Extra attributes follow deps in name order
+	extraStr := ""
+	for _, name := range sortedAttrNames(spec.ExtraAttrs) {
+		if contains(reservedBuildAttrs, name) {
+			return "", fmt.Errorf("extra attribute %s is set by the generator", name)
+		}
+		extraStr += fmt.Sprintf("\n    %s = %s,", name, spec.ExtraAttrs[name])
+	}
+
+	excludeStr := ""
+	if len(excludePatterns) > 0 {
+		excludeStr = fmt.Sprintf("\n        exclude = [\n%s,\n        ],", strings.Join(quoteAll(excludePatterns, "            "), ",\n"))
+	}
+
+	return fmt.Sprintf(`load("//bazel:swift_rules.bzl", "umbra_swift_library")
+
+umbra_swift_library(
+    name = "%s",
+    srcs = glob(
+        [
+%s,
+        ],
+        allow_empty = False,%s
+        exclude_directories = 1,
+    ),%s%s
+    visibility = [%s],
+)
+`, targetName, strings.Join(quoteAll(globPatterns, "            "), ",\n"), excludeStr, depsStr, extraStr, strings.Join(quoteAll(visibility, ""), ", ")), nil
+}
+
+// GenerateFilegroup returns the Starlark content of a BUILD file with a filegroup target, for a subpackage
+// level without sources of its own. The spec's Deps are the filegroup's srcs.
+func (g *BuildFileGenerator) GenerateFilegroup(spec BuildSpec) (string, error) {
+	if spec.PackageName == "" {
+		return "", fmt.Errorf("build spec must set PackageName")
+	}
+	if len(spec.Deps) == 0 {
+		return "", fmt.Errorf("filegroup for %s has no srcs", spec.SubpackagePath)
+	}
+
+	targetName := spec.TargetName
+	if targetName == "" {
+		parts := strings.Split(spec.SubpackagePath, "/")
+		targetName = parts[len(parts)-1]
+	}
+
+	visibility := spec.Visibility
+	if len(visibility) == 0 {
+		visibility = []string{fmt.Sprintf("//packages/%s:__subpackages__", spec.PackageName)}
+	}
+
+	return fmt.Sprintf(`filegroup(
+    name = "%s",
+    srcs = [
+%s,
+    ],
+    visibility = [%s],
+)
+`, targetName, strings.Join(quoteAll(spec.Deps, "        "), ",\n"), strings.Join(quoteAll(visibility, ""), ", ")), nil
+}
+
+// AddBuildLabel adds label to the deps list of existing BUILD file content, or to its srcs list if it has no deps
+// list, keeping the labels and comments already there. It reports whether the content changed; a label listed
+// with an explicit target of the same name counts as present.
+func AddBuildLabel(content, label string) (string, bool, error) {
+	var list []int
+	for _, match := range labelListPattern.FindAllStringSubmatchIndex(content, -1) {
+		if content[match[4]:match[5]] == "deps" {
+			list = match
+			break
+		}
+		if list == nil {
+			list = match
+		}
+	}
+	if list == nil {
+		return "", false, fmt.Errorf("no deps or srcs list to add %s to", label)
+	}
+
+	for _, existing := range quotedStrings(content[list[6]:list[7]]) {
+		if canonicalLabel(existing) == canonicalLabel(label) {
+			return content, false, nil
+		}
+	}
+
+	indent := content[list[2]:list[3]]
+	entries := strings.TrimRight(content[list[6]:list[7]], " \t\n")
+	if entries != "" && !strings.HasSuffix(entries, ",") {
+		entries += ","
+	}
+	entries += fmt.Sprintf("\n%s    \"%s\",\n%s", indent, label, indent)
+	return content[:list[6]] + entries + content[list[7]:], true, nil
+}
+
+// canonicalLabel drops the target name of a label when it repeats the package's last path element
+func canonicalLabel(label string) string {
+	pkg, target, found := strings.Cut(label, ":")
+	if found && target == pkg[strings.LastIndex(pkg, "/")+1:] {
+		return pkg
+	}
+	return label
+}
+
+// quoteAll returns each value as an indented Starlark string literal
+func quoteAll(values []string, indent string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%s\"%s\"", indent, value)
+	}
+	return quoted
+}
+
+// sortedAttrNames returns the names of extra attributes in sorted order
+func sortedAttrNames(attrs map[string]string) []string {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// attrsFlag collects the values of a repeatable name=expression flag
+type attrsFlag map[string]string
+
+// String returns the attributes as a comma-separated list
+func (a attrsFlag) String() string {
+	pairs := []string{}
+	for _, name := range sortedAttrNames(a) {
+		pairs = append(pairs, name+"="+a[name])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set adds an attribute
+func (a attrsFlag) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return fmt.Errorf("expected name=expression, got %q", value)
+	}
+	a[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	return nil
+}
+
+// splitList splits a comma-separated flag value, ignoring empty entries
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// runGenerateBuild implements the generate-build subcommand
+func runGenerateBuild(args []string) error {
+	fs := flag.NewFlagSet("generate-build", flag.ExitOnError)
+	packageFlag := fs.String("package", "", "Top-level package (e.g., UmbraCoreTypes)")
+	subpackageFlag := fs.String("subpackage", "", "Subpackage path under Sources/; omit for the package's main BUILD file")
+	nameFlag := fs.String("name", "", "Target name (defaults to the subpackage or package name)")
+	globFlag := fs.String("glob", "", "srcs glob pattern (defaults to **/*.swift, or Sources/**/*.swift without --subpackage)")
+	excludeFlag := fs.String("exclude", strings.Join(defaultExcludePatterns, ","), "Comma-separated glob exclude patterns")
+	depsFlag := fs.String("deps", "", "Comma-separated dependency labels")
+	visibilityFlag := fs.String("visibility", "", "Comma-separated visibility labels (defaults to the package's subpackages, or public)")
+	extraAttrs := attrsFlag{}
+	fs.Var(extraAttrs, "attr", "Extra attribute as name=Starlark expression (e.g., resources=glob([\"Resources/**\"])); repeatable")
+	outputFlag := fs.String("output", "", "Write the BUILD file to this path instead of stdout")
+	fs.Parse(args)
+
+	content, err := NewBuildFileGenerator().Generate(BuildSpec{
+		PackageName:     *packageFlag,
+		SubpackagePath:  *subpackageFlag,
+		TargetName:      *nameFlag,
+		GlobPattern:     *globFlag,
+		ExcludePatterns: append([]string{}, splitList(*excludeFlag)...),
+		Deps:            splitList(*depsFlag),
+		Visibility:      splitList(*visibilityFlag),
+		ExtraAttrs:      extraAttrs,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *outputFlag == "" {
+		fmt.Print(content)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(*outputFlag), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+	if err := ioutil.WriteFile(*outputFlag, []byte(content), 0644); err != nil {
+		return fmt.Errorf("error writing BUILD file: %v", err)
+	}
+	fmt.Printf("Wrote BUILD file to %s\n", *outputFlag)
+	return nil
+}
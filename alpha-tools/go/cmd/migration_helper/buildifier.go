@@ -0,0 +1,13 @@
+package main
+
+import "os/exec"
+
+// formatBuildFile formats a BUILD file in place, in-process if useLibrary is set and the buildtools library
+// was compiled in, and with the buildifier binary otherwise
+func formatBuildFile(path string, useLibrary bool) error {
+	if useLibrary && buildifierLibraryAvailable {
+		return formatWithLibrary(path)
+	}
+
+	return exec.Command("buildifier", path).Run()
+}
@@ -0,0 +1,31 @@
+//go:build buildtools
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/bazelbuild/buildtools/build"
+)
+
+// buildifierLibraryAvailable reports whether the buildtools library was compiled in (-tags buildtools)
+const buildifierLibraryAvailable = true
+
+// formatWithLibrary formats a BUILD file in place with the buildtools library that buildifier is built on
+func formatWithLibrary(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading BUILD file: %v", err)
+	}
+
+	file, err := build.ParseBuild(path, content)
+	if err != nil {
+		return fmt.Errorf("error parsing BUILD file: %v", err)
+	}
+
+	if err := ioutil.WriteFile(path, build.Format(file), 0644); err != nil {
+		return fmt.Errorf("error writing BUILD file: %v", err)
+	}
+	return nil
+}
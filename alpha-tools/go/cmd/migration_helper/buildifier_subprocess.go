@@ -0,0 +1,13 @@
+//go:build !buildtools
+
+package main
+
+import "fmt"
+
+// buildifierLibraryAvailable reports whether the buildtools library was compiled in (-tags buildtools)
+const buildifierLibraryAvailable = false
+
+// formatWithLibrary is unavailable without the buildtools tag; formatBuildFile runs buildifier instead
+func formatWithLibrary(path string) error {
+	return fmt.Errorf("built without the buildtools library (build with -tags buildtools)")
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MigrateAll migrates every mapped module that has not been migrated yet, each after the modules it depends
+// on, and returns a report of the outcome. Modules whose sources are not found are skipped, as are modules
+// depending on a module that failed. A dependency cycle aborts the migration before anything is written. In
+// dry-run mode the plan is printed, nothing is migrated and the report is nil.
+func (m *MigrationHelper) MigrateAll(skipDependencyCheck bool) (*MigrationReport, error) {
+	entries, err := readJournal(journalPath(m.TargetDir))
+	if err != nil {
+		return nil, err
+	}
+	migrated := make(map[string]bool)
+	for _, entry := range entries {
+		migrated[filepath.Join(entry.SourceDir, entry.Module)] = entry.Success
+	}
+
+	mappings := []PackageMapping{}
+	skipped := []string{}
+	for _, mapping := range m.EffectiveMappings() {
+		sourceDir := m.FindModuleSourceDir(mapping.SourceModule)
+		if sourceDir == "" {
+			fmt.Printf("ℹ️ Skipping %s: source module not found in %s\n", mapping.SourceModule, strings.Join(m.SourceDirs, ", "))
+			skipped = append(skipped, mapping.SourceModule)
+			continue
+		}
+		if migrated[filepath.Join(sourceDir, mapping.SourceModule)] {
+			continue
+		}
+		mappings = append(mappings, mapping)
+	}
+	if len(mappings) == 0 {
+		fmt.Println("✅ Every mapped module in the source directories has been migrated")
+		return NewMigrationReport(m.WorkspaceRoot, nil, skipped, GraphStats{}), nil
+	}
+
+	// The order needs every module's dependencies, even when the per-module dependency check is skipped
+	stats := GraphStats{Modules: len(mappings)}
+	deps := make(map[string][]string)
+	for _, mapping := range mappings {
+		moduleDeps, err := m.moduleDependencies(mapping.SourceModule)
+		if err != nil {
+			return nil, err
+		}
+		sourcePackage, _ := splitTargetPackage(mapping.TargetPackage)
+		for _, dep := range moduleDeps {
+			depMapping := m.GetTargetMapping(dep)
+			if depMapping == nil {
+				continue
+			}
+			deps[mapping.SourceModule] = append(deps[mapping.SourceModule], dep)
+			stats.Dependencies++
+			if depPackage, _ := splitTargetPackage(depMapping.TargetPackage); depPackage != sourcePackage {
+				stats.CrossPackageDependencies++
+				if !m.ValidDeps.Contains(sourcePackage, depPackage) {
+					stats.InvalidDependencies++
+				}
+			}
+		}
+	}
+
+	ordered, cycle := dependencyOrder(mappings, deps)
+	if cycle != nil {
+		fmt.Printf("❌ Dependency cycle: %s\n", strings.Join(cycle, " -> "))
+		return nil, fmt.Errorf("cannot order modules with a dependency cycle between %s", strings.Join(cycle[1:], ", "))
+	}
+
+	if m.DryRun {
+		fmt.Printf("Migration plan (%d modules):\n", len(ordered))
+		for i, mapping := range ordered {
+			fmt.Printf("[%d/%d] %s -> %s\n", i+1, len(ordered), mapping.SourceModule, mapping.TargetPackage)
+		}
+		return nil, nil
+	}
+
+	firstResult := len(m.Results)
+	failed := make(map[string]bool)
+	for i, mapping := range ordered {
+		if dep := firstFailed(deps[mapping.SourceModule], failed); dep != "" {
+			fmt.Printf("⚠️ [%d/%d] Skipping %s: its dependency %s failed\n", i+1, len(ordered), mapping.SourceModule, dep)
+			skipped = append(skipped, mapping.SourceModule)
+			failed[mapping.SourceModule] = true
+			continue
+		}
+
+		fmt.Printf("\n[%d/%d] Migrating %s...\n", i+1, len(ordered), mapping.SourceModule)
+		success, err := m.MigrateModule(mapping.SourceModule, mapping.TargetPackage, skipDependencyCheck)
+		if err != nil {
+			fmt.Printf("❌ Error migrating %s: %v\n", mapping.SourceModule, err)
+			failed[mapping.SourceModule] = true
+			continue
+		}
+
+		// A changed API fails the module, but its dependents still build, so they are not skipped
+		if m.VerifyAPI && success {
+			if _, err := m.verifyPublicAPI(mapping.SourceModule, mapping.TargetPackage); err != nil {
+				fmt.Printf("❌ Error verifying the public API of %s: %v\n", mapping.SourceModule, err)
+			}
+		}
+	}
+
+	return NewMigrationReport(m.WorkspaceRoot, m.Results[firstResult:], skipped, stats), nil
+}
+
+// firstFailed returns the first of deps that failed, or "" if none did
+func firstFailed(deps []string, failed map[string]bool) string {
+	for _, dep := range deps {
+		if failed[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+// TopologicalMigrationOrder returns the effective mappings in an order that is safe to migrate them in. A
+// module comes after the modules it depends on, and after the modules of every other package its package may
+// depend on under ValidDeps. Module dependencies come from ModuleDependencies if it is set, and from Bazel
+// otherwise. A dependency cycle is an error.
+func (m *MigrationHelper) TopologicalMigrationOrder() ([]PackageMapping, error) {
+	mappings := m.EffectiveMappings()
+	deps := make(map[string][]string)
+	for _, mapping := range mappings {
+		moduleDeps, err := m.moduleDependencies(mapping.SourceModule)
+		if err != nil {
+			return nil, err
+		}
+		deps[mapping.SourceModule] = append(deps[mapping.SourceModule], moduleDeps...)
+
+		sourcePackage, _ := splitTargetPackage(mapping.TargetPackage)
+		for _, other := range mappings {
+			if otherPackage, _ := splitTargetPackage(other.TargetPackage); otherPackage != sourcePackage && m.ValidDeps.Contains(sourcePackage, otherPackage) {
+				deps[mapping.SourceModule] = append(deps[mapping.SourceModule], other.SourceModule)
+			}
+		}
+	}
+
+	ordered, cycle := dependencyOrder(mappings, deps)
+	if cycle != nil {
+		return nil, fmt.Errorf("dependency cycle between modules: %s", strings.Join(cycle, " -> "))
+	}
+	return ordered, nil
+}
+
+// moduleDependencies returns the modules a source module depends on, from ModuleDependencies if it is set
+func (m *MigrationHelper) moduleDependencies(moduleName string) ([]string, error) {
+	if m.ModuleDependencies != nil {
+		return m.ModuleDependencies[moduleName], nil
+	}
+	return m.GetModuleDependencies(moduleName)
+}
+
+// dependencyOrder sorts mappings topologically with Kahn's algorithm, so every module comes after the modules
+// it depends on. Among modules that are ready, the first in mapping order goes next. Dependencies outside
+// mappings are ignored. If the modules cannot be ordered, it returns a dependency cycle instead, as a path
+// that starts and ends with the same module.
+func dependencyOrder(mappings []PackageMapping, deps map[string][]string) ([]PackageMapping, []string) {
+	index := make(map[string]int)
+	for i, mapping := range mappings {
+		index[mapping.SourceModule] = i
+	}
+
+	// Edges run from each dependency to its dependents
+	dependents := make([][]int, len(mappings))
+	inDegree := make([]int, len(mappings))
+	for i, mapping := range mappings {
+		seen := make(map[int]bool)
+		for _, dep := range deps[mapping.SourceModule] {
+			j, mapped := index[dep]
+			if !mapped || j == i || seen[j] {
+				continue
+			}
+			seen[j] = true
+			dependents[j] = append(dependents[j], i)
+			inDegree[i]++
+		}
+	}
+
+	ready := []int{}
+	for i := range mappings {
+		if inDegree[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	ordered := []PackageMapping{}
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, mappings[next])
+		for _, dependent := range dependents[next] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(ordered) < len(mappings) {
+		pending := make(map[string]bool)
+		for i, mapping := range mappings {
+			pending[mapping.SourceModule] = inDegree[i] > 0
+		}
+		return nil, findCycle(mappings, deps, pending)
+	}
+	return ordered, nil
+}
+
+// findCycle follows pending dependencies from the first pending module until a module repeats. Every
+// pending module has a pending dependency, so the walk always finds a cycle.
+func findCycle(mappings []PackageMapping, deps map[string][]string, pending map[string]bool) []string {
+	var start string
+	for _, mapping := range mappings {
+		if pending[mapping.SourceModule] {
+			start = mapping.SourceModule
+			break
+		}
+	}
+
+	path := []string{}
+	position := make(map[string]int)
+	for module := start; ; {
+		if i, seen := position[module]; seen {
+			return append(path[i:], module)
+		}
+		position[module] = len(path)
+		path = append(path, module)
+		for _, dep := range deps[module] {
+			if pending[dep] && dep != module {
+				module = dep
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ExportMappingsAsBzl writes the package mappings and valid dependencies as Starlark constants
+func (m *MigrationHelper) ExportMappingsAsBzl(outputPath string) error {
+	var sb strings.Builder
+	sb.WriteString("\"\"\"Alpha Dot Five package mapping constants.\n\n")
+	sb.WriteString("Generated by migration_helper -export-bzl. Do not edit by hand; update the\n")
+	sb.WriteString("mappings in the migration helper and regenerate this file instead.\n")
+	sb.WriteString("\"\"\"\n\n")
+
+	// Source module -> target package
+	sb.WriteString("PACKAGE_MAPPINGS = {\n")
+	mappings := m.EffectiveMappings()
+	for _, mapping := range mappings {
+		sb.WriteString(fmt.Sprintf("    %q: %q,\n", mapping.SourceModule, mapping.TargetPackage))
+	}
+	sb.WriteString("}\n\n")
+
+	// Allowed (source, target) package dependencies
+	sb.WriteString("VALID_DEPS = [\n")
+	for _, validDep := range m.ValidDeps {
+		sb.WriteString(fmt.Sprintf("    (%q, %q),\n", validDep.Source, validDep.Target))
+	}
+	sb.WriteString("]\n")
+
+	// Create parent directories if needed
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+
+	if err := ioutil.WriteFile(outputPath, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", outputPath, err)
+	}
+
+	fmt.Printf("Exported %d mappings and %d valid dependencies to %s\n", len(mappings), len(m.ValidDeps), outputPath)
+	return nil
+}
+
+// errNotDictLiteral is returned by bzlDictBody when the constant is assigned something other than a {...} literal
+var errNotDictLiteral = errors.New("not a dict literal")
+
+// ParseBzlMappings reads the dict constant constName from a .bzl file as {SourceModule: TargetPackage} pairs.
+// Only string literal entries are understood; entries using other expressions are skipped with a warning.
+func ParseBzlMappings(bzlPath, constName string) ([]PackageMapping, error) {
+	content, err := ioutil.ReadFile(bzlPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", bzlPath, err)
+	}
+
+	body, err := bzlDictBody(string(content), constName)
+	if err == errNotDictLiteral {
+		// Computed constants (dict(...), merges of other dicts) need a real Starlark interpreter
+		log.Printf("WARN: %s in %s is not a dict literal; keeping the default mappings", constName, bzlPath)
+		return []PackageMapping{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", bzlPath, err)
+	}
+
+	mappings := []PackageMapping{}
+	for _, entry := range splitTopLevel(body, ',') {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := splitTopLevel(entry, ':')
+		if len(parts) != 2 {
+			log.Printf("WARN: Skipping entry in %s %s: not a key: value pair: %s", bzlPath, constName, entry)
+			continue
+		}
+
+		source, sourceErr := starlarkString(parts[0])
+		target, targetErr := starlarkString(parts[1])
+		if sourceErr != nil || targetErr != nil {
+			log.Printf("WARN: Skipping entry in %s %s: only string literals are supported: %s", bzlPath, constName, entry)
+			continue
+		}
+
+		mappings = append(mappings, PackageMapping{
+			SourceModule:   source,
+			TargetPackage:  target,
+			ImportModuleAs: source,
+		})
+	}
+
+	return mappings, nil
+}
+
+// bzlDictBody returns the text between the braces of a top-level dict constant
+func bzlDictBody(content, constName string) (string, error) {
+	assignment := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(constName) + `\s*=\s*`)
+	loc := assignment.FindStringIndex(content)
+	if loc == nil {
+		return "", fmt.Errorf("constant %s not found", constName)
+	}
+
+	rest := content[loc[1]:]
+	if !strings.HasPrefix(rest, "{") {
+		return "", errNotDictLiteral
+	}
+
+	depth := 0
+	var quote byte
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			// Skip comments up to the end of the line
+			for i < len(rest) && rest[i] != '\n' {
+				i++
+			}
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			depth--
+			if depth == 0 {
+				return stripBzlComments(rest[1:i]), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("constant %s has an unterminated dict", constName)
+}
+
+// stripBzlComments removes # comments that are outside string literals
+func stripBzlComments(text string) string {
+	var sb strings.Builder
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(text) {
+				sb.WriteByte(c)
+				i++
+				c = text[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+			c = '\n'
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// splitTopLevel splits text on sep, ignoring separators inside strings and brackets
+func splitTopLevel(text string, sep byte) []string {
+	parts := []string{}
+	depth := 0
+	start := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// starlarkString returns the value of a single Starlark string literal
+func starlarkString(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if len(expr) < 2 {
+		return "", fmt.Errorf("not a string literal: %s", expr)
+	}
+	if expr[0] == '\'' && expr[len(expr)-1] == '\'' {
+		// strconv only understands double-quoted strings
+		expr = `"` + strings.ReplaceAll(expr[1:len(expr)-1], `"`, `\"`) + `"`
+	}
+	if expr[0] != '"' {
+		return "", fmt.Errorf("not a string literal: %s", expr)
+	}
+	return strconv.Unquote(expr)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/deprules"
+)
+
+// Config modes: how a config file's lists combine with the built-in defaults
+const (
+	configModeMerge   = "merge"   // Config mappings replace defaults for the same module; config rules are added
+	configModeReplace = "replace" // Each list the config sets replaces the defaults
+)
+
+// HelperConfig is a JSON file with package mappings and dependency rules, so the module structure can change
+// without rebuilding the migration helper
+type HelperConfig struct {
+	PackageMappings   []PackageMapping                `json:"packageMappings"`
+	ValidDependencies deprules.ValidDependencyRuleSet `json:"validDependencies"`
+}
+
+// LoadHelperConfig reads and validates a config file
+func LoadHelperConfig(path string) (*HelperConfig, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %v", err)
+	}
+
+	var config HelperConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("error parsing config %s: %v", path, err)
+	}
+	if err := checkMappings(config.PackageMappings, path); err != nil {
+		return nil, err
+	}
+	if len(config.PackageMappings) == 0 && len(config.ValidDependencies) == 0 {
+		return nil, fmt.Errorf("no packageMappings or validDependencies found in %s", path)
+	}
+
+	return &config, nil
+}
+
+// ApplyConfig combines the config's mappings and rules with the helper's according to mode. In replace
+// mode a list the config leaves out keeps its defaults.
+func (m *MigrationHelper) ApplyConfig(config *HelperConfig, mode string) error {
+	switch mode {
+	case configModeMerge:
+		m.MergeMappings(config.PackageMappings)
+		m.ValidDeps.Merge(config.ValidDependencies)
+	case configModeReplace:
+		if len(config.PackageMappings) > 0 {
+			m.DefaultMappings = nil
+			m.MergeMappings(config.PackageMappings)
+		}
+		if len(config.ValidDependencies) > 0 {
+			m.ValidDeps = config.ValidDependencies
+		}
+	default:
+		return fmt.Errorf("unknown config mode %q (expected %s or %s)", mode, configModeMerge, configModeReplace)
+	}
+	return nil
+}
+
+// loadConfigFile loads the config at path, if any, into the helper
+func (m *MigrationHelper) loadConfigFile(path, mode string) error {
+	if path == "" {
+		return nil
+	}
+	config, err := LoadHelperConfig(path)
+	if err != nil {
+		return err
+	}
+	return m.ApplyConfig(config, mode)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Overwrite modes for destination files that already exist with different content
+const (
+	overwriteAbort = ""      // Abort the migration, listing the conflicting files
+	overwriteForce = "force" // Replace them
+	overwriteSkip  = "skip"  // Keep them and do not migrate their sources
+	overwriteAsk   = "ask"   // Ask for each file
+)
+
+// overwriteModes are the values -overwrite accepts
+var overwriteModes = []string{overwriteForce, overwriteSkip, overwriteAsk}
+
+// migratedContent returns what migrating a job writes to its target: the source, with its imports or header
+// paths rewritten unless it is a resource
+func (m *MigrationHelper) migratedContent(job fileJob, moduleMapping, headerMapping map[string]string) ([]byte, error) {
+	content, err := m.readFile(job.source)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case job.resource:
+		return content, nil
+	case job.modulemap:
+		rewritten, _ := rewriteModulemapHeaders(string(content), headerMapping)
+		return []byte(rewritten), nil
+	case job.objc:
+		rewritten, _ := rewriteObjCImports(string(content), headerMapping)
+		return []byte(rewritten), nil
+	}
+	rewritten, _ := m.importRewriter(moduleMapping).RewriteAll(string(content))
+	return []byte(rewritten), nil
+}
+
+// detectConflicts returns the jobs whose destination already exists with content other than the source's
+// or the migrated copy's, compared by SHA-256. Destinations a previous run migrated are not conflicts.
+func (m *MigrationHelper) detectConflicts(jobs []fileJob, moduleMapping, headerMapping map[string]string) ([]fileJob, error) {
+	conflicts := []fileJob{}
+	for _, job := range jobs {
+		if _, err := os.Stat(job.target); os.IsNotExist(err) {
+			continue
+		}
+		targetChecksum, err := fileChecksum(job.target)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", job.target, err)
+		}
+		sourceChecksum, err := fileChecksum(job.source)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", job.source, err)
+		}
+		if targetChecksum == sourceChecksum {
+			continue
+		}
+		migrated, err := m.migratedContent(job, moduleMapping, headerMapping)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", job.source, err)
+		}
+		if sum := sha256.Sum256(migrated); hex.EncodeToString(sum[:]) == targetChecksum {
+			continue
+		}
+		conflicts = append(conflicts, job)
+	}
+	return conflicts, nil
+}
+
+// resolveConflicts checks the jobs for conflicting destinations before anything is written, and returns the
+// jobs to run. Unless the Overwrite mode says what to do with them, conflicts abort the migration.
+func (m *MigrationHelper) resolveConflicts(jobs []fileJob, moduleMapping, headerMapping map[string]string) ([]fileJob, error) {
+	conflicts, err := m.detectConflicts(jobs, moduleMapping, headerMapping)
+	if err != nil || len(conflicts) == 0 {
+		return jobs, err
+	}
+
+	keep := make(map[string]bool) // Conflicting targets left as they are
+	switch m.Overwrite {
+	case overwriteForce:
+		for _, job := range conflicts {
+			fmt.Printf("ℹ️ Overwriting %s, which already exists with different content\n", relativeTo(m.TargetDir, job.target))
+		}
+	case overwriteSkip:
+		for _, job := range conflicts {
+			keep[job.target] = true
+			fmt.Printf("ℹ️ Skipping %s, which already exists with different content\n", relativeTo(m.TargetDir, job.target))
+		}
+	case overwriteAsk:
+		for _, job := range conflicts {
+			fmt.Printf("⚠️ %s already exists with different content. Overwrite it? (y/n): ", relativeTo(m.TargetDir, job.target))
+			var response string
+			fmt.Scanln(&response)
+			if strings.ToLower(response) != "y" {
+				keep[job.target] = true
+			}
+		}
+	default:
+		paths := make([]string, len(conflicts))
+		for i, job := range conflicts {
+			paths[i] = relativeTo(m.TargetDir, job.target)
+		}
+		return nil, fmt.Errorf("%d destination files already exist with different content:\n  %s\nRerun with -overwrite=force to replace them, -overwrite=skip to keep them or -overwrite=ask to choose for each file",
+			len(paths), strings.Join(paths, "\n  "))
+	}
+
+	remaining := []fileJob{}
+	for _, job := range jobs {
+		if !keep[job.target] {
+			remaining = append(remaining, job)
+		}
+	}
+	return remaining, nil
+}
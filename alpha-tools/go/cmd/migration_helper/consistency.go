@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ConflictWarning describes two or more package mappings that contradict each other
+type ConflictWarning struct {
+	Kind     string // "duplicate-source" or "duplicate-target"
+	Value    string
+	Mappings []PackageMapping
+}
+
+// String describes the conflict for logging
+func (c ConflictWarning) String() string {
+	entries := make([]string, len(c.Mappings))
+	for i, mapping := range c.Mappings {
+		entries[i] = fmt.Sprintf("%s -> %s", mapping.SourceModule, mapping.TargetPackage)
+	}
+
+	switch c.Kind {
+	case "duplicate-source":
+		return fmt.Sprintf("source module %s is mapped %d times (%s); only the first mapping is used", c.Value, len(c.Mappings), strings.Join(entries, ", "))
+	case "duplicate-target":
+		return fmt.Sprintf("target package %s is the destination of %d modules (%s)", c.Value, len(c.Mappings), strings.Join(entries, ", "))
+	}
+	return fmt.Sprintf("%s conflict on %s", c.Kind, c.Value)
+}
+
+// duplicateSourceModules returns the source modules listed more than once for the same effective period
+func duplicateSourceModules(mappings []PackageMapping) []string {
+	bySource := make(map[string][]PackageMapping)
+	duplicates := []string{}
+	for _, mapping := range mappings {
+		bySource[mapping.SourceModule] = append(bySource[mapping.SourceModule], mapping)
+		if len(bySource[mapping.SourceModule]) > 1 && !contains(duplicates, mapping.SourceModule) &&
+			len(ambiguousMappings(bySource[mapping.SourceModule])) > 1 {
+			duplicates = append(duplicates, mapping.SourceModule)
+		}
+	}
+	return duplicates
+}
+
+// checkDefaultMappings exits if a source module is mapped twice in the built-in mappings, since the first
+// mapping would silently shadow the other
+func checkDefaultMappings(mappings []PackageMapping) {
+	if duplicates := duplicateSourceModules(mappings); len(duplicates) > 0 {
+		log.Fatalf("Duplicate SourceModule in default package mappings: %s", strings.Join(duplicates, ", "))
+	}
+}
+
+// ValidateMappingConsistency detects mappings that share a source module or a target package
+func (m *MigrationHelper) ValidateMappingConsistency() []ConflictWarning {
+	bySource := make(map[string][]PackageMapping)
+	byTarget := make(map[string][]PackageMapping)
+	sourceOrder := []string{}
+	targetOrder := []string{}
+
+	for _, mapping := range m.DefaultMappings {
+		if _, seen := bySource[mapping.SourceModule]; !seen {
+			sourceOrder = append(sourceOrder, mapping.SourceModule)
+		}
+		bySource[mapping.SourceModule] = append(bySource[mapping.SourceModule], mapping)
+
+		if _, seen := byTarget[mapping.TargetPackage]; !seen {
+			targetOrder = append(targetOrder, mapping.TargetPackage)
+		}
+		byTarget[mapping.TargetPackage] = append(byTarget[mapping.TargetPackage], mapping)
+	}
+
+	conflicts := []ConflictWarning{}
+	for _, source := range sourceOrder {
+		// Phased mappings are fine; a later EffectiveFrom supersedes the earlier mapping
+		if ambiguous := ambiguousMappings(bySource[source]); len(ambiguous) > 1 {
+			conflicts = append(conflicts, ConflictWarning{Kind: "duplicate-source", Value: source, Mappings: ambiguous})
+		}
+	}
+	for _, target := range targetOrder {
+		// Different source modules landing in the same destination
+		distinct := []PackageMapping{}
+		for _, mapping := range byTarget[target] {
+			duplicate := false
+			for _, existing := range distinct {
+				if existing.SourceModule == mapping.SourceModule {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				distinct = append(distinct, mapping)
+			}
+		}
+		if ambiguous := ambiguousMappings(distinct); len(ambiguous) > 1 {
+			conflicts = append(conflicts, ConflictWarning{Kind: "duplicate-target", Value: target, Mappings: ambiguous})
+		}
+	}
+
+	for _, conflict := range conflicts {
+		log.Printf("WARN: Conflicting package mappings: %s", conflict)
+	}
+
+	return conflicts
+}
+
+// ambiguousMappings returns the mappings that are in effect at the same time as another mapping
+// in the list and do not supersede it with a different EffectiveFrom
+func ambiguousMappings(mappings []PackageMapping) []PackageMapping {
+	ambiguous := []PackageMapping{}
+	for i, mapping := range mappings {
+		for j, other := range mappings {
+			if i != j && periodsOverlap(mapping, other) && sameTime(mapping.EffectiveFrom, other.EffectiveFrom) {
+				ambiguous = append(ambiguous, mapping)
+				break
+			}
+		}
+	}
+	return ambiguous
+}
+
+// periodsOverlap checks if two mappings are ever effective at the same time
+func periodsOverlap(a, b PackageMapping) bool {
+	if a.EffectiveUntil != nil && b.EffectiveFrom != nil && !a.EffectiveUntil.After(*b.EffectiveFrom) {
+		return false
+	}
+	if b.EffectiveUntil != nil && a.EffectiveFrom != nil && !b.EffectiveUntil.After(*a.EffectiveFrom) {
+		return false
+	}
+	return true
+}
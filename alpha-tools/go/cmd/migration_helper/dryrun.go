@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// dryRunContext is the number of unchanged lines shown around each change in the dry-run report
+const dryRunContext = 3
+
+// dryRunWrite is a file a dry run would have written
+type dryRunWrite struct {
+	path    string
+	content []byte
+	origin  string // File it would have been copied from, or "" if it was generated
+}
+
+// readFile reads a file, seeing what a dry run would have written to it
+func (m *MigrationHelper) readFile(path string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.dryRunWrites) - 1; i >= 0; i-- {
+		if m.dryRunWrites[i].path == path {
+			return m.dryRunWrites[i].content, nil
+		}
+	}
+	return readFileFunc(path)
+}
+
+// writeFile writes a file, creating its directory. In dry-run mode nothing is written; the content is kept
+// for the dry-run report instead.
+func (m *MigrationHelper) writeFile(path string, content []byte) error {
+	if m.DryRun {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i := range m.dryRunWrites {
+			if m.dryRunWrites[i].path == path {
+				m.dryRunWrites[i].content = content
+				return nil
+			}
+		}
+		m.dryRunWrites = append(m.dryRunWrites, dryRunWrite{path: path, content: content})
+		return nil
+	}
+
+	// Record the write first, so a failed migration can be rolled back
+	if m.manifest != nil {
+		m.mu.Lock()
+		err := m.manifest.recordFile(path)
+		m.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+	if err := writeFileFunc(path, content, 0644); err != nil {
+		return err
+	}
+	m.recordWrite(path)
+	return nil
+}
+
+// copyFile copies a file from src to dst
+func (m *MigrationHelper) copyFile(src, dst string) error {
+	input, err := m.readFile(src)
+	if err != nil {
+		return err
+	}
+	if err := m.writeFile(dst, input); err != nil {
+		return err
+	}
+	if m.DryRun {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i := range m.dryRunWrites {
+			if m.dryRunWrites[i].path == dst {
+				m.dryRunWrites[i].origin = src
+			}
+		}
+	}
+	return nil
+}
+
+// printDryRunReport prints the files a dry run would have written as a unified diff, sorted by path, and
+// forgets them.
+// Copied files are compared with the file they were copied from, so only rewritten lines show up; other
+// files are compared with what is on disk.
+func (m *MigrationHelper) printDryRunReport() {
+	sort.SliceStable(m.dryRunWrites, func(i, j int) bool { return m.dryRunWrites[i].path < m.dryRunWrites[j].path })
+	for _, write := range m.dryRunWrites {
+		oldName, oldContent := "/dev/null", ""
+		if write.origin != "" {
+			if content, err := ioutil.ReadFile(write.origin); err == nil {
+				oldName, oldContent = write.origin, string(content)
+			}
+		} else if content, err := ioutil.ReadFile(write.path); err == nil {
+			oldName, oldContent = write.path, string(content)
+		}
+
+		fmt.Printf("--- %s\n+++ %s\n", oldName, write.path)
+		fmt.Print(unifiedDiffHunks(oldContent, string(write.content), dryRunContext))
+	}
+	m.dryRunWrites = nil
+}
+
+// unifiedDiffHunks returns the @@ hunks of a line diff between two texts, with context unchanged lines
+// around each change
+func unifiedDiffHunks(oldText, newText string, context int) string {
+	a := splitDiffLines(oldText)
+	b := splitDiffLines(newText)
+
+	// Longest common subsequence table, filled from the end
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	// Edit script: ' ' keeps, '-' removes and '+' adds a line
+	type edit struct {
+		op         byte
+		text       string
+		oldN, newN int // 1-based line numbers before the edit
+	}
+	edits := []edit{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i], i + 1, j + 1})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{'-', a[i], i + 1, j + 1})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j], i + 1, j + 1})
+			j++
+		}
+	}
+
+	changes := []int{}
+	for k, e := range edits {
+		if e.op != ' ' {
+			changes = append(changes, k)
+		}
+	}
+
+	var sb strings.Builder
+	for k := 0; k < len(changes); {
+		// A hunk runs from context lines before a change to context lines after the last change that is
+		// close enough to share them
+		first := changes[k] - context
+		if first < 0 {
+			first = 0
+		}
+		last := changes[k]
+		for k++; k < len(changes) && changes[k]-last <= 2*context; k++ {
+			last = changes[k]
+		}
+		end := last + context + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		oldCount, newCount := 0, 0
+		for _, e := range edits[first:end] {
+			if e.op != '+' {
+				oldCount++
+			}
+			if e.op != '-' {
+				newCount++
+			}
+		}
+		oldStart, newStart := edits[first].oldN, edits[first].newN
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount))
+		for _, e := range edits[first:end] {
+			sb.WriteString(fmt.Sprintf("%c%s\n", e.op, e.text))
+		}
+	}
+	return sb.String()
+}
+
+// splitDiffLines splits text into lines, without an empty last line for a trailing newline
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// progressf prints a progress message about a file the migration writes. Dry runs stay quiet, since their
+// report shows every change.
+func (m *MigrationHelper) progressf(format string, args ...interface{}) {
+	if !m.DryRun {
+		fmt.Printf(format, args...)
+	}
+}
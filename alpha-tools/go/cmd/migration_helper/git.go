@@ -0,0 +1,121 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// recordWrite remembers a file the current migration wrote, so it can be staged with git
+func (m *MigrationHelper) recordWrite(path string) {
+	if !m.GitStage {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !contains(m.writtenFiles, path) {
+		m.writtenFiles = append(m.writtenFiles, path)
+	}
+}
+
+// runGit runs git with args in dir and returns an error with git's output if it fails
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// stageMigration runs git add for every file the migration wrote and, if the sources were removed from
+// sourceDir, git rm --cached for them. Staging is best effort: if git is missing or fails, it warns and the
+// migration stands.
+func (m *MigrationHelper) stageMigration(sourceDir string, removedSources []string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		m.warn("git was not found in PATH, so the migrated files were not staged")
+		return
+	}
+
+	written := append([]string{}, m.writtenFiles...)
+	sort.Strings(written)
+	if len(written) > 0 {
+		if err := runGit(m.TargetDir, append([]string{"add", "--"}, written...)...); err != nil {
+			m.warn("Error staging the migrated files: %v", err)
+		} else {
+			fmt.Printf("Staged %d files with git add\n", len(written))
+		}
+	}
+
+	if len(removedSources) > 0 {
+		// Sources that were never committed are not in the index, which is fine
+		args := append([]string{"rm", "--cached", "--quiet", "--ignore-unmatch", "--"}, removedSources...)
+		if err := runGit(sourceDir, args...); err != nil {
+			m.warn("Error unstaging the removed sources: %v", err)
+		} else {
+			fmt.Printf("Staged the removal of %d source files with git rm --cached\n", len(removedSources))
+		}
+	}
+}
+
+// removeSources deletes the source files of a migrated module, then the directories they leave empty. A source
+// is only deleted once its target is re-read and its SHA-256 digest matches the migrated content, so a copy
+// that is incomplete never loses its source.
+func (m *MigrationHelper) removeSources(jobs []fileJob, sourceModulePath string, moduleMapping, headerMapping map[string]string) ([]string, error) {
+	removed := []string{}
+	kept := 0
+	for _, job := range jobs {
+		if !m.verifyCopy(job, moduleMapping, headerMapping) {
+			m.warn("Kept %s: its copy %s does not match the migrated content", job.source, job.target)
+			kept++
+			continue
+		}
+		if err := os.Remove(job.source); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("error removing %s: %v", job.source, err)
+		}
+		removed = append(removed, job.source)
+	}
+
+	// Deepest directories first, so a parent is only checked once its children are gone
+	dirs := []string{}
+	filepath.Walk(sourceModulePath, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := ioutil.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			if err := os.RemoveAll(dirs[i]); err != nil {
+				return removed, fmt.Errorf("error removing %s: %v", dirs[i], err)
+			}
+		}
+	}
+
+	if kept > 0 {
+		fmt.Printf("Removed %d source files from %s, kept %d whose copies do not match\n", len(removed), sourceModulePath, kept)
+	} else {
+		fmt.Printf("Removed %d source files from %s\n", len(removed), sourceModulePath)
+	}
+	return removed, nil
+}
+
+// verifyCopy checks that a job's target has the content migrating its source writes, by SHA-256
+func (m *MigrationHelper) verifyCopy(job fileJob, moduleMapping, headerMapping map[string]string) bool {
+	expected, err := m.migratedContent(job, moduleMapping, headerMapping)
+	if err != nil {
+		return false
+	}
+	targetChecksum, err := fileChecksum(job.target)
+	if err != nil {
+		return false
+	}
+	sum := sha256.Sum256(expected)
+	return hex.EncodeToString(sum[:]) == targetChecksum
+}
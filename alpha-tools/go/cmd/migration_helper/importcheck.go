@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MixedImport is a Swift file that imports a module under both its old and its new name
+type MixedImport struct {
+	File      string
+	OldModule string // SourceModule of the mapping
+	NewModule string // ImportModuleAs of the mapping
+}
+
+// CheckImportConsistency finds the Swift files under targetDir that import both a mapped module's
+// SourceModule name and its ImportModuleAs name, which happens when a partial migration rewrote some imports
+// but not others. It prints each conflicting pair by file and returns the offending files.
+func (m *MigrationHelper) CheckImportConsistency(targetDir string) ([]string, error) {
+	mixed, err := m.findMixedImports(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, mixedImport := range mixed {
+		if len(files) == 0 || files[len(files)-1] != mixedImport.File {
+			files = append(files, mixedImport.File)
+			fmt.Printf("❌ %s\n", relativeTo(targetDir, mixedImport.File))
+		}
+		fmt.Printf("   imports both %s (old) and %s (new)\n", mixedImport.OldModule, mixedImport.NewModule)
+	}
+	if len(files) == 0 {
+		fmt.Println("✅ No Swift file imports a module under both its old and new name")
+	} else {
+		fmt.Printf("Found %d files with mixed old and new imports\n", len(files))
+	}
+	return files, nil
+}
+
+// findMixedImports returns the mixed imports of the Swift files under targetDir, sorted by file
+func (m *MigrationHelper) findMixedImports(targetDir string) ([]MixedImport, error) {
+	renamed := []PackageMapping{}
+	for _, mapping := range m.EffectiveMappings() {
+		if mapping.ImportModuleAs != "" && mapping.ImportModuleAs != mapping.SourceModule {
+			renamed = append(renamed, mapping)
+		}
+	}
+
+	files := []string{}
+	err := filepath.Walk(targetDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(path, ".swift") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s: %v", targetDir, err)
+	}
+	sort.Strings(files)
+
+	mixed := []MixedImport{}
+	for _, file := range files {
+		content, err := m.readFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", file, err)
+		}
+		imported := importedModules(string(content))
+		for _, mapping := range renamed {
+			if imported[mapping.SourceModule] && imported[mapping.ImportModuleAs] {
+				mixed = append(mixed, MixedImport{File: file, OldModule: mapping.SourceModule, NewModule: mapping.ImportModuleAs})
+			}
+		}
+	}
+	return mixed, nil
+}
+
+// importedModules returns the modules a Swift file imports, whatever their attributes, ignoring imports
+// inside block comments
+func importedModules(content string) map[string]bool {
+	modules := make(map[string]bool)
+	commentDepth := 0
+	for _, line := range strings.Split(content, "\n") {
+		inComment := commentDepth > 0
+		commentDepth = blockCommentDepth(line, commentDepth)
+		if inComment {
+			continue
+		}
+		if match := swiftImportLinePattern.FindStringSubmatch(line); match != nil {
+			modules[match[4]] = true
+		}
+	}
+	return modules
+}
@@ -0,0 +1,484 @@
+//go:build integration
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/querycache"
+)
+
+// integrationModules is a synthetic source tree: module name to files relative to the module directory
+var integrationModules = map[string]map[string]string{
+	"CoreDTOs": {
+		"BackupDTO.swift":                "import Foundation\n\npublic struct BackupDTO {\n    public let id: String\n}\n",
+		"Repository/RepositoryDTO.swift": "import Foundation\n\npublic struct RepositoryDTO {\n    public let path: String\n}\n",
+	},
+	"ErrorHandlingInterfaces": {
+		"ErrorProtocol.swift": "import Foundation\nimport CoreDTOs\n\npublic protocol UmbraError: Error {\n    var backup: BackupDTO? { get }\n}\n",
+	},
+	"LoggingWrapper": {
+		"Logger.swift":                        "import Foundation\nimport ErrorHandlingInterfaces\n@preconcurrency import LoggingWrapperInterfaces\n\npublic final class Logger {\n    public init() {}\n}\n",
+		"Formatters/JSON/JSONFormatter.swift": "#if canImport(FileSystemTypes)\nimport FileSystemTypes\n#endif\nimport CoreDTOs // Backup metadata\n\nstruct JSONFormatter {}\n",
+		"Formatters/PlainFormatter.swift":     "/*\n import ErrorHandlingInterfaces\n*/\nstruct PlainFormatter {}\n",
+		"LoggerTest.swift":                    "import XCTest\n",
+		"Tests/LoggerTests.swift":             "@testable import LoggingWrapper\n",
+	},
+}
+
+// writeIntegrationWorkspace creates the synthetic source tree under root
+func writeIntegrationWorkspace(t *testing.T, root string) string {
+	sourcesDir := filepath.Join(root, "Sources")
+	for module, files := range integrationModules {
+		for relPath, content := range files {
+			path := filepath.Join(sourcesDir, module, relPath)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return sourcesDir
+}
+
+func TestMigrateModuleIntegration(t *testing.T) {
+	root := t.TempDir()
+	sourcesDir := writeIntegrationWorkspace(t, root)
+	targetDir := filepath.Join(root, "packages")
+
+	helper := NewMigrationHelper([]string{sourcesDir}, targetDir, root)
+	migrations := []struct {
+		module        string
+		targetPackage string
+	}{
+		{"CoreDTOs", "UmbraCoreTypes/CoreDTOs"},
+		{"ErrorHandlingInterfaces", "UmbraErrorKit/Interfaces"},
+		{"LoggingWrapper", "UmbraImplementations/LoggingImpl"},
+	}
+	for _, migration := range migrations {
+		// Bazel is not available, so skip the dependency check
+		success, err := helper.MigrateModule(migration.module, migration.targetPackage, true)
+		if err != nil || !success {
+			t.Fatalf("MigrateModule(%s): success %v, error %v", migration.module, success, err)
+		}
+	}
+
+	// Migrated files, with imports rewritten
+	expectedFiles := map[string]string{
+		"UmbraCoreTypes/Sources/CoreDTOs/BackupDTO.swift":                integrationModules["CoreDTOs"]["BackupDTO.swift"],
+		"UmbraCoreTypes/Sources/CoreDTOs/Repository/RepositoryDTO.swift": integrationModules["CoreDTOs"]["Repository/RepositoryDTO.swift"],
+		"UmbraErrorKit/Sources/Interfaces/ErrorProtocol.swift":           integrationModules["ErrorHandlingInterfaces"]["ErrorProtocol.swift"],
+		"UmbraImplementations/Sources/LoggingImpl/Logger.swift": strings.NewReplacer(
+			"import ErrorHandlingInterfaces", "import ErrorInterfaces",
+			"import LoggingWrapperInterfaces", "import LoggingInterfaces",
+		).Replace(integrationModules["LoggingWrapper"]["Logger.swift"]),
+		"UmbraImplementations/Sources/LoggingImpl/Formatters/JSON/JSONFormatter.swift": strings.Replace(
+			integrationModules["LoggingWrapper"]["Formatters/JSON/JSONFormatter.swift"],
+			"\nimport FileSystemTypes\n", "\nimport FileSystemInterfaces\n", 1),
+		"UmbraImplementations/Sources/LoggingImpl/Formatters/PlainFormatter.swift": integrationModules["LoggingWrapper"]["Formatters/PlainFormatter.swift"],
+	}
+	for relPath, expected := range expectedFiles {
+		content, err := ioutil.ReadFile(filepath.Join(targetDir, relPath))
+		if err != nil {
+			t.Errorf("expected migrated file %s: %v", relPath, err)
+			continue
+		}
+		if string(content) != expected {
+			t.Errorf("%s: got content:\n%s\nwant:\n%s", relPath, content, expected)
+		}
+	}
+
+	// Tests are not migrated
+	for _, relPath := range []string{
+		"UmbraImplementations/Sources/LoggingImpl/LoggerTest.swift",
+		"UmbraImplementations/Sources/LoggingImpl/Tests/LoggerTests.swift",
+	} {
+		if fileExists(filepath.Join(targetDir, relPath)) {
+			t.Errorf("test file %s was migrated", relPath)
+		}
+	}
+
+	// BUILD files
+	expectedBuildFiles := map[string][]string{
+		"UmbraCoreTypes/Sources/CoreDTOs/BUILD.bazel": {
+			`name = "CoreDTOs"`,
+			`"//packages/UmbraCoreTypes:__subpackages__"`,
+		},
+		"UmbraErrorKit/Sources/Interfaces/BUILD.bazel": {
+			`name = "Interfaces"`,
+			`"//packages/UmbraErrorKit:__subpackages__"`,
+		},
+		"UmbraImplementations/Sources/LoggingImpl/BUILD.bazel": {
+			`load("//bazel:swift_rules.bzl", "umbra_swift_library")`,
+			`name = "LoggingImpl"`,
+			`"//packages/UmbraImplementations:__subpackages__"`,
+		},
+	}
+	for relPath, fragments := range expectedBuildFiles {
+		content, err := ioutil.ReadFile(filepath.Join(targetDir, relPath))
+		if err != nil {
+			t.Errorf("expected BUILD file %s: %v", relPath, err)
+			continue
+		}
+		for _, fragment := range fragments {
+			if !strings.Contains(string(content), fragment) {
+				t.Errorf("%s does not contain %s:\n%s", relPath, fragment, content)
+			}
+		}
+	}
+
+	// Journal
+	journal := NewMigrationJournal("")
+	if err := journal.Load(journalPath(targetDir)); err != nil {
+		t.Fatal(err)
+	}
+	if journal.SkippedLines != 0 {
+		t.Errorf("journal has %d unreadable lines", journal.SkippedLines)
+	}
+	entries, found := journal.Find("LoggingWrapper")
+	if !found || len(entries) != 1 {
+		t.Fatalf("got %d journal entries for LoggingWrapper, want 1", len(entries))
+	}
+	entry := entries[0]
+	if !entry.Success || entry.Error != "" {
+		t.Errorf("journal entry is not a success: %+v", entry)
+	}
+	if entry.TargetPackage != "UmbraImplementations/LoggingImpl" || entry.SourceDir != sourcesDir {
+		t.Errorf("journal entry has source %s and target %s", entry.SourceDir, entry.TargetPackage)
+	}
+	if len(entry.Files) != 3 || !contains(entry.Files, "UmbraImplementations/Sources/LoggingImpl/Formatters/JSON/JSONFormatter.swift") {
+		t.Errorf("journal entry has files %v", entry.Files)
+	}
+
+	// Every migrated file is in a srcs glob
+	orphans, err := DetectGlobOrphans(targetDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 0 {
+		t.Errorf("files not matched by any srcs glob: %v", orphans)
+	}
+
+	// Only imports differ from the sources
+	for _, migration := range migrations {
+		diffs, err := helper.CompareSources(migration.module, migration.targetPackage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, diff := range diffs {
+			t.Errorf("%s differs from %s beyond imports:\n%s", diff.TargetPath, diff.SourcePath, diff.Diff)
+		}
+	}
+}
+
+// failWritesAfter makes the nth and later file writes fail until the test ends
+func failWritesAfter(t *testing.T, n int) {
+	writes := 0
+	writeFileFunc = func(path string, content []byte, perm os.FileMode) error {
+		writes++
+		if writes >= n {
+			return fmt.Errorf("injected write error for %s", filepath.Base(path))
+		}
+		return ioutil.WriteFile(path, content, perm)
+	}
+	t.Cleanup(func() { writeFileFunc = ioutil.WriteFile })
+}
+
+// failReadsUnder makes reads of files under dir fail until the test ends
+func failReadsUnder(t *testing.T, dir string) {
+	readFileFunc = func(path string) ([]byte, error) {
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return nil, fmt.Errorf("injected read error for %s", filepath.Base(path))
+		}
+		return ioutil.ReadFile(path)
+	}
+	t.Cleanup(func() { readFileFunc = ioutil.ReadFile })
+}
+
+func TestMigrateModuleRollback(t *testing.T) {
+	tests := []struct {
+		name     string
+		inject   func(t *testing.T, targetDir string)
+		expected string // Substring of the migration error
+	}{
+		// LoggingWrapper has three files to migrate; the second write fails
+		{name: "copy write error", inject: func(t *testing.T, targetDir string) { failWritesAfter(t, 2) }, expected: "injected write error"},
+		// The copies are written, but their imports cannot be read back to be rewritten
+		{name: "import rewrite read error", inject: failReadsUnder, expected: "error updating imports"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			sourcesDir := writeIntegrationWorkspace(t, root)
+			targetDir := filepath.Join(root, "packages")
+
+			helper := NewMigrationHelper([]string{sourcesDir}, targetDir, root)
+			helper.Parallelism = 1
+			test.inject(t, targetDir)
+
+			success, err := helper.MigrateModule("LoggingWrapper", "UmbraImplementations/LoggingImpl", true)
+			if err == nil || success || !strings.Contains(err.Error(), test.expected) {
+				t.Fatalf("MigrateModule: success %v, error %v; want an error containing %q", success, err, test.expected)
+			}
+
+			// Only the journal recording the failure is left
+			entries, err := ioutil.ReadDir(targetDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, entry := range entries {
+				if entry.Name() != journalFileName {
+					t.Errorf("rollback left %s in the target directory", entry.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateModuleRollbackRestoresModifiedFiles(t *testing.T) {
+	root := t.TempDir()
+	sourcesDir := writeIntegrationWorkspace(t, root)
+	targetDir := filepath.Join(root, "packages")
+
+	helper := NewMigrationHelper([]string{sourcesDir}, targetDir, root)
+	if success, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err != nil || !success {
+		t.Fatalf("MigrateModule: success %v, error %v", success, err)
+	}
+	migratedPath := filepath.Join(targetDir, "UmbraCoreTypes/Sources/CoreDTOs/BackupDTO.swift")
+	migrated, err := ioutil.ReadFile(migratedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Migrating again overwrites the two files, then fails writing the BUILD file
+	if err := ioutil.WriteFile(filepath.Join(sourcesDir, "CoreDTOs/BackupDTO.swift"), []byte("struct Changed {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	helper.Parallelism = 1
+	helper.Overwrite = overwriteForce
+	failWritesAfter(t, 3)
+	if _, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err == nil {
+		t.Fatal("MigrateModule succeeded despite the injected write error")
+	}
+
+	content, err := ioutil.ReadFile(migratedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(migrated) {
+		t.Errorf("BackupDTO.swift was not restored: got\n%s\nwant\n%s", content, migrated)
+	}
+	if !fileExists(filepath.Join(targetDir, "UmbraCoreTypes/Sources/CoreDTOs/BUILD.bazel")) {
+		t.Error("rollback removed the BUILD file of the earlier migration")
+	}
+}
+
+func TestMigrateAllStrictCountsOwnWarnings(t *testing.T) {
+	root := t.TempDir()
+	sourcesDir := writeIntegrationWorkspace(t, root)
+	fakeBuildifierFailingFor(t, "CoreDTOs")
+
+	// CoreDTOs is migrated first and warns; LoggingWrapper only depends on ErrorHandlingInterfaces
+	helper := NewMigrationHelper([]string{sourcesDir}, filepath.Join(root, "packages"), root)
+	helper.Strict = true
+	helper.QueryCache = &querycache.BazelQueryCache{
+		Dir: t.TempDir(),
+		Execute: func(workspaceRoot string, args ...string) ([]byte, error) {
+			if strings.Contains(args[len(args)-1], "//Sources/LoggingWrapper:") {
+				return []byte(`{"target": [{"name": "//Sources/ErrorHandlingInterfaces:ErrorHandlingInterfaces"}]}`), nil
+			}
+			return []byte(`{"target": []}`), nil
+		},
+	}
+	report, err := helper.MigrateAll(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Succeeded != 2 || report.Failed != 1 {
+		t.Errorf("got %d succeeded and %d failed, want only CoreDTOs to fail", report.Succeeded, report.Failed)
+	}
+	for _, module := range report.SkippedModules {
+		if module == "LoggingWrapper" || module == "ErrorHandlingInterfaces" {
+			t.Errorf("skipped %s after CoreDTOs warned", module)
+		}
+	}
+}
+
+func TestMigratePackageTierVerifyAPI(t *testing.T) {
+	root := t.TempDir()
+	sourcesDir := writeIntegrationWorkspace(t, root)
+	targetDir := filepath.Join(root, "packages")
+
+	// A declaration already in the target package is not part of CoreDTOs' source API
+	extraPath := filepath.Join(targetDir, "UmbraCoreTypes/Sources/CoreDTOs/LegacyDTO.swift")
+	if err := os.MkdirAll(filepath.Dir(extraPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(extraPath, []byte("public struct LegacyDTO {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	helper := NewMigrationHelper([]string{sourcesDir}, targetDir, root)
+	helper.VerifyAPI = true
+	report, err := helper.MigratePackageTier("UmbraCoreTypes", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Failed != 1 || len(report.ModuleResults) != 1 {
+		t.Fatalf("got %d results and %d failed, want CoreDTOs to fail", len(report.ModuleResults), report.Failed)
+	}
+	changes := report.ModuleResults[0].APIChanges
+	if len(changes) != 1 || changes[0].Kind != "added" || changes[0].DeclName != "struct LegacyDTO" {
+		t.Errorf("got API changes %v, want LegacyDTO added", changes)
+	}
+
+	html, err := report.ToHTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), "<td>added struct LegacyDTO ("+extraPath+")</td>") {
+		t.Errorf("HTML report does not list the API change:\n%s", html)
+	}
+}
+
+func TestMigrateModuleRemoveSourceKeepsIncompleteCopies(t *testing.T) {
+	root := t.TempDir()
+	sourcesDir := writeIntegrationWorkspace(t, root)
+	targetDir := filepath.Join(root, "packages")
+
+	// A partial copy: the write of BackupDTO.swift reports success but only writes half the file
+	writeFileFunc = func(path string, content []byte, perm os.FileMode) error {
+		if filepath.Base(path) == "BackupDTO.swift" {
+			content = content[:len(content)/2]
+		}
+		return ioutil.WriteFile(path, content, perm)
+	}
+	t.Cleanup(func() { writeFileFunc = ioutil.WriteFile })
+
+	helper := NewMigrationHelper([]string{sourcesDir}, targetDir, root)
+	helper.RemoveSource = true
+	if success, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err != nil || !success {
+		t.Fatalf("MigrateModule: success %v, error %v", success, err)
+	}
+
+	if !fileExists(filepath.Join(sourcesDir, "CoreDTOs/BackupDTO.swift")) {
+		t.Error("the source of the incomplete copy was deleted")
+	}
+	if fileExists(filepath.Join(sourcesDir, "CoreDTOs/Repository/RepositoryDTO.swift")) {
+		t.Error("the source of the verified copy was not deleted")
+	}
+	if dirExists(filepath.Join(sourcesDir, "CoreDTOs/Repository")) {
+		t.Error("the emptied source directory was not removed")
+	}
+}
+
+// git runs git in dir and returns its trimmed output
+func git(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestMigrateModuleGitStage(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	sourcesDir := writeIntegrationWorkspace(t, root)
+	targetDir := filepath.Join(root, "packages")
+	git(t, root, "init", "--quiet")
+	git(t, root, "add", "Sources")
+	git(t, root, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "Sources")
+
+	helper := NewMigrationHelper([]string{sourcesDir}, targetDir, root)
+	helper.GitStage = true
+	helper.RemoveSource = true
+	if success, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err != nil || !success {
+		t.Fatalf("MigrateModule: success %v, error %v", success, err)
+	}
+
+	staged := git(t, root, "diff", "--cached", "--name-status", "--no-renames")
+	expected := strings.Join([]string{
+		"D\tSources/CoreDTOs/BackupDTO.swift",
+		"D\tSources/CoreDTOs/Repository/RepositoryDTO.swift",
+		"A\tpackages/UmbraCoreTypes/Sources/CoreDTOs/BUILD.bazel",
+		"A\tpackages/UmbraCoreTypes/Sources/CoreDTOs/BackupDTO.swift",
+		"A\tpackages/UmbraCoreTypes/Sources/CoreDTOs/Repository/RepositoryDTO.swift",
+	}, "\n")
+	if staged != expected {
+		t.Errorf("staged changes:\n%s\nwant:\n%s", staged, expected)
+	}
+
+	// Nothing the migration wrote or removed is left unstaged
+	if unstaged := git(t, root, "diff", "--name-status"); unstaged != "" {
+		t.Errorf("unstaged changes after migration:\n%s", unstaged)
+	}
+	if dirExists(filepath.Join(sourcesDir, "CoreDTOs")) {
+		t.Error("the emptied source module directory was not removed")
+	}
+}
+
+func TestMigrateModuleOverwrite(t *testing.T) {
+	root := t.TempDir()
+	sourcesDir := writeIntegrationWorkspace(t, root)
+	targetDir := filepath.Join(root, "packages")
+	migratedPath := filepath.Join(targetDir, "UmbraCoreTypes/Sources/CoreDTOs/BackupDTO.swift")
+	edited := "struct EditedAfterMigration {}\n"
+
+	helper := NewMigrationHelper([]string{sourcesDir}, targetDir, root)
+	if success, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err != nil || !success {
+		t.Fatalf("MigrateModule: success %v, error %v", success, err)
+	}
+
+	// Migrating again over an unchanged copy is not a conflict
+	if success, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err != nil || !success {
+		t.Fatalf("rerun: success %v, error %v", success, err)
+	}
+
+	if err := ioutil.WriteFile(migratedPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertContent := func(expected string) {
+		t.Helper()
+		content, err := ioutil.ReadFile(migratedPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expected {
+			t.Errorf("BackupDTO.swift: got\n%s\nwant\n%s", content, expected)
+		}
+	}
+
+	_, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true)
+	if err == nil || !strings.Contains(err.Error(), "UmbraCoreTypes/Sources/CoreDTOs/BackupDTO.swift") || strings.Contains(err.Error(), "RepositoryDTO") {
+		t.Fatalf("expected a conflict error naming only BackupDTO.swift, got %v", err)
+	}
+	assertContent(edited)
+
+	helper.Overwrite = overwriteSkip
+	if success, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err != nil || !success {
+		t.Fatalf("-overwrite=skip: success %v, error %v", success, err)
+	}
+	assertContent(edited)
+
+	helper.Overwrite = overwriteForce
+	if success, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err != nil || !success {
+		t.Fatalf("-overwrite=force: success %v, error %v", success, err)
+	}
+	assertContent(integrationModules["CoreDTOs"]["BackupDTO.swift"])
+}
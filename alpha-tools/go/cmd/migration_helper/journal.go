@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// journalFileName is the name of the migration journal kept in the target directory
+const journalFileName = ".migration_journal.jsonl"
+
+// JournalEntry records a single migration run
+type JournalEntry struct {
+	Module        string            `json:"module"`
+	SourceDir     string            `json:"sourceDir"`
+	TargetPackage string            `json:"targetPackage"`
+	Files         []string          `json:"files"`               // Relative to the target directory
+	Checksums     map[string]string `json:"checksums,omitempty"` // SHA-256 of each of Files when it was migrated
+	Success       bool              `json:"success"`
+	Error         string            `json:"error,omitempty"`
+	Timestamp     time.Time         `json:"timestamp"`
+	Operator      string            `json:"operator,omitempty"` // git user.email of whoever ran the migration
+}
+
+// MigrationJournal is an append-only JSON Lines record of migration runs. Each entry is written with a
+// single synchronous append, so a crash can at worst leave one incomplete last line, which Load skips.
+// A journal is safe for concurrent use.
+type MigrationJournal struct {
+	Path         string
+	Entries      []JournalEntry
+	SkippedLines int // Incomplete or corrupt lines skipped by the last Load
+
+	mu sync.Mutex
+}
+
+// journalPath returns the location of the migration journal for a target directory
+func journalPath(targetDir string) string {
+	return filepath.Join(targetDir, journalFileName)
+}
+
+// NewMigrationJournal creates an empty journal that appends to path
+func NewMigrationJournal(path string) *MigrationJournal {
+	return &MigrationJournal{Path: path, Entries: []JournalEntry{}}
+}
+
+// Load reads the entries of the journal at path, which becomes the journal's path. A missing journal has
+// no entries; lines that are not complete JSON objects, such as a write cut short by a crash, are skipped.
+func (j *MigrationJournal) Load(path string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.Path = path
+	j.Entries = []JournalEntry{}
+	j.SkippedLines = 0
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error opening journal: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			j.SkippedLines++
+			continue
+		}
+		j.Entries = append(j.Entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading journal: %v", err)
+	}
+
+	if j.SkippedLines > 0 {
+		fmt.Printf("Warning: Skipped %d incomplete lines in journal %s\n", j.SkippedLines, path)
+	}
+
+	return nil
+}
+
+// Append writes an entry to the end of the journal file and adds it to the loaded entries
+func (j *MigrationJournal) Append(entry JournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding journal entry: %v", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(j.Path), 0755); err != nil {
+		return fmt.Errorf("error creating journal directory: %v", err)
+	}
+
+	file, err := os.OpenFile(j.Path, os.O_CREATE|os.O_RDWR|os.O_APPEND|os.O_SYNC, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening journal: %v", err)
+	}
+	defer file.Close()
+
+	// Terminate a line left incomplete by a crash so it does not swallow this entry
+	if terminated, err := endsWithNewline(file); err != nil {
+		return fmt.Errorf("error reading journal: %v", err)
+	} else if !terminated {
+		line = append([]byte{'\n'}, line...)
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing journal: %v", err)
+	}
+
+	j.Entries = append(j.Entries, entry)
+	return nil
+}
+
+// endsWithNewline checks if a file is empty or ends with a newline
+func endsWithNewline(file *os.File) (bool, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() == 0 {
+		return true, nil
+	}
+
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil && err != io.EOF {
+		return false, err
+	}
+	return last[0] == '\n', nil
+}
+
+// Find returns the entries for a module, oldest first, and whether there are any
+func (j *MigrationJournal) Find(moduleName string) ([]JournalEntry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := []JournalEntry{}
+	for _, entry := range j.Entries {
+		if entry.Module == moduleName {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, len(entries) > 0
+}
+
+// MarkFailed appends an entry recording that a module's migration failed, e.g. when a migrated module is
+// later found to be broken. The source and target are taken from the module's latest entry.
+func (j *MigrationJournal) MarkFailed(moduleName string, err error) error {
+	entry := JournalEntry{Module: moduleName, Files: []string{}, Timestamp: time.Now().UTC(), Operator: journalOperator()}
+	if entries, found := j.Find(moduleName); found {
+		latest := entries[len(entries)-1]
+		entry.SourceDir = latest.SourceDir
+		entry.TargetPackage = latest.TargetPackage
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return j.Append(entry)
+}
+
+// Export writes the loaded entries to path as json, jsonl or csv
+func (j *MigrationJournal) Export(path string, format string) error {
+	j.mu.Lock()
+	entries := append([]JournalEntry{}, j.Entries...)
+	j.mu.Unlock()
+
+	var buf bytes.Buffer
+	switch format {
+	case "json":
+		content, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding journal: %v", err)
+		}
+		buf.Write(append(content, '\n'))
+	case "jsonl":
+		for _, entry := range entries {
+			line, err := json.Marshal(entry)
+			if err != nil {
+				return fmt.Errorf("error encoding journal entry: %v", err)
+			}
+			buf.Write(append(line, '\n'))
+		}
+	case "csv":
+		writer := csv.NewWriter(&buf)
+		writer.Write([]string{"module", "sourceDir", "targetPackage", "files", "success", "error", "timestamp"})
+		for _, entry := range entries {
+			writer.Write([]string{
+				entry.Module,
+				entry.SourceDir,
+				entry.TargetPackage,
+				strings.Join(entry.Files, " "),
+				fmt.Sprint(entry.Success),
+				entry.Error,
+				entry.Timestamp.Format(time.RFC3339),
+			})
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("error encoding journal: %v", err)
+		}
+	default:
+		return fmt.Errorf("unknown journal export format %q (expected json, jsonl or csv)", format)
+	}
+
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", path, err)
+	}
+	return nil
+}
+
+// readJournal reads all entries from a journal file; a missing journal has no entries
+func readJournal(path string) ([]JournalEntry, error) {
+	journal := NewMigrationJournal(path)
+	if err := journal.Load(path); err != nil {
+		return nil, err
+	}
+	return journal.Entries, nil
+}
+
+// runJournal implements the journal subcommand
+func runJournal(args []string) error {
+	fs := flag.NewFlagSet("journal", flag.ExitOnError)
+	targetFlag := fs.String("target", "packages", "Target directory containing the migration journal")
+	moduleFlag := fs.String("module", "", "Only show the entries of this module")
+	markFailedFlag := fs.String("mark-failed", "", "Record that this module's migration failed")
+	reasonFlag := fs.String("reason", "marked as failed manually", "Reason recorded with -mark-failed")
+	exportFlag := fs.String("export", "", "Write the journal to this file")
+	formatFlag := fs.String("format", "json", "Format for -export: json, jsonl or csv")
+	fs.Parse(args)
+
+	journal := NewMigrationJournal("")
+	if err := journal.Load(journalPath(*targetFlag)); err != nil {
+		return err
+	}
+
+	if *markFailedFlag != "" {
+		if err := journal.MarkFailed(*markFailedFlag, fmt.Errorf("%s", *reasonFlag)); err != nil {
+			return err
+		}
+		fmt.Printf("Marked %s as failed in %s\n", *markFailedFlag, journal.Path)
+		return nil
+	}
+
+	if *exportFlag != "" {
+		if err := journal.Export(*exportFlag, *formatFlag); err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d journal entries to %s\n", len(journal.Entries), *exportFlag)
+		return nil
+	}
+
+	entries := journal.Entries
+	if *moduleFlag != "" {
+		var found bool
+		if entries, found = journal.Find(*moduleFlag); !found {
+			return fmt.Errorf("no journal entries for %s", *moduleFlag)
+		}
+	}
+	for _, entry := range entries {
+		status := "✅"
+		if !entry.Success {
+			status = "❌"
+		}
+		fmt.Printf("%s %s %s -> %s (%d files)", status, entry.Timestamp.Format(time.RFC3339), entry.Module, entry.TargetPackage, len(entry.Files))
+		if entry.Error != "" {
+			fmt.Printf(": %s", entry.Error)
+		}
+		fmt.Println()
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// visibilityPattern matches the visibility list of a rule in a BUILD file
+	visibilityPattern = regexp.MustCompile(`visibility\s*=\s*\[([^\]]*)\]`)
+	// visibilityLabelPattern matches a label in a visibility list
+	visibilityLabelPattern = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// leakableDeclKinds are the declarations whose internal access CheckSwiftAccess reports
+var leakableDeclKinds = map[string]bool{"struct": true, "class": true, "enum": true, "func": true}
+
+// AccessLeak is an internal Swift symbol of a mapped module whose target package is visible to every package
+type AccessLeak struct {
+	Symbol        string `json:"symbol"`
+	Kind          string `json:"kind"`
+	File          string `json:"file"`
+	SourceModule  string `json:"sourceModule"`
+	TargetPackage string `json:"targetPackage"`
+	BuildFile     string `json:"buildFile"`
+	Visibility    string `json:"visibility"`
+}
+
+// CheckSwiftAccess finds internal struct, class, enum and func declarations in the modules migrated under
+// packagesDir whose target package's BUILD file is //visibility:public. Merging a module into such a package
+// exposes its internal symbols to every file of the package, not just those of the original module.
+func (m *MigrationHelper) CheckSwiftAccess(packagesDir string) ([]AccessLeak, error) {
+	leaks := []AccessLeak{}
+	for _, mapping := range m.EffectiveMappings() {
+		packageName, subpackage := splitTargetPackage(mapping.TargetPackage)
+		moduleDir := filepath.Join(packagesDir, packageName, "Sources", subpackage)
+		if !dirExists(moduleDir) {
+			continue // Not migrated yet
+		}
+
+		buildFile := owningBuildFile(moduleDir, packagesDir)
+		if buildFile == "" {
+			continue
+		}
+		visibility, err := buildFileVisibility(buildFile)
+		if err != nil {
+			return nil, err
+		}
+		if !contains(visibility, "//visibility:public") {
+			continue
+		}
+
+		files, err := swiftFilesIn(moduleDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s: %v", file, err)
+			}
+			for _, match := range topLevelDeclPattern.FindAllStringSubmatch(string(content), -1) {
+				if match[1] == "fileprivate" || !leakableDeclKinds[match[2]] {
+					continue
+				}
+				leaks = append(leaks, AccessLeak{
+					Symbol:        match[3],
+					Kind:          match[2],
+					File:          relativeTo(packagesDir, file),
+					SourceModule:  mapping.SourceModule,
+					TargetPackage: mapping.TargetPackage,
+					BuildFile:     relativeTo(packagesDir, buildFile),
+					Visibility:    strings.Join(visibility, ", "),
+				})
+			}
+		}
+	}
+
+	return leaks, nil
+}
+
+// owningBuildFile returns the BUILD file of the Bazel package containing dir, looking no higher than root
+func owningBuildFile(dir, root string) string {
+	for {
+		for _, name := range []string{"BUILD.bazel", "BUILD"} {
+			if path := filepath.Join(dir, name); fileExists(path) {
+				return path
+			}
+		}
+		if rel, err := filepath.Rel(root, dir); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return ""
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// buildFileVisibility returns the visibility labels of every rule in a BUILD file
+func buildFileVisibility(buildFile string) ([]string, error) {
+	content, err := ioutil.ReadFile(buildFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading BUILD file: %v", err)
+	}
+
+	visibility := []string{}
+	for _, match := range visibilityPattern.FindAllStringSubmatch(string(content), -1) {
+		for _, label := range visibilityLabelPattern.FindAllStringSubmatch(match[1], -1) {
+			if !contains(visibility, label[1]) {
+				visibility = append(visibility, label[1])
+			}
+		}
+	}
+	return visibility, nil
+}
+
+// swiftFilesIn returns the Swift files under dir in sorted order, leaving out subdirectories with their own
+// BUILD file, which are other Bazel packages
+func swiftFilesIn(dir string) ([]string, error) {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && path != dir && (fileExists(filepath.Join(path, "BUILD")) || fileExists(filepath.Join(path, "BUILD.bazel"))) {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && strings.HasSuffix(path, ".swift") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s: %v", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// relativeTo returns path relative to base where possible
+func relativeTo(base, path string) string {
+	if rel, err := filepath.Rel(base, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// runCheckSwiftAccess implements the check-swift-access subcommand
+func runCheckSwiftAccess(args []string) error {
+	fs := flag.NewFlagSet("check-swift-access", flag.ExitOnError)
+	var sourceFlag sourceDirsFlag
+	fs.Var(&sourceFlag, "source", "Source directory containing old modules (default Sources); repeatable")
+	targetFlag := fs.String("target", "packages", "Target directory for new packages")
+	mappingsFileFlag := fs.String("mappings-file", "", "JSON file with additional package mappings")
+	fs.Parse(args)
+
+	sourceDirs, err := absoluteSourceDirs(sourceFlag)
+	if err != nil {
+		return err
+	}
+	targetDir, err := filepath.Abs(*targetFlag)
+	if err != nil {
+		return fmt.Errorf("error getting absolute path: %v", err)
+	}
+
+	migrator := NewMigrationHelper(sourceDirs, targetDir, filepath.Dir(sourceDirs[0]))
+	if *mappingsFileFlag != "" {
+		mappings, err := LoadMappingsFile(*mappingsFileFlag)
+		if err != nil {
+			return err
+		}
+		migrator.MergeMappings(mappings)
+	}
+
+	leaks, err := migrator.CheckSwiftAccess(targetDir)
+	if err != nil {
+		return err
+	}
+
+	if len(leaks) == 0 {
+		fmt.Println("✅ No internal symbols are exposed by public target packages.")
+		return nil
+	}
+
+	for _, leak := range leaks {
+		fmt.Printf("⚠️ %s %s in %s (from %s) is internal, but %s is visible to %s\n",
+			leak.Kind, leak.Symbol, leak.File, leak.SourceModule, leak.BuildFile, leak.Visibility)
+	}
+	fmt.Printf("❌ Found %d internal symbols in public target packages. Restrict the packages' visibility or make the symbols private or fileprivate.\n", len(leaks))
+	os.Exit(1)
+	return nil
+}
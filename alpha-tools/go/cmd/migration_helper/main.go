@@ -7,17 +7,27 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/deprules"
+	"github.com/mpy/umbracore/alpha-tools/pkg/notify"
+	"github.com/mpy/umbracore/alpha-tools/pkg/querycache"
+	"github.com/mpy/umbracore/alpha-tools/pkg/workspace"
 )
 
 // PackageMapping maps source modules to target packages
 type PackageMapping struct {
-	SourceModule   string
-	TargetPackage  string
-	ImportModuleAs string // What the module should be imported as in the new structure
+	SourceModule     string
+	TargetPackage    string
+	ImportModuleAs   string     // What the module should be imported as in the new structure
+	ObjCHeaderPrefix string     // Header path prefix for Objective-C imports (defaults to ImportModuleAs)
+	EffectiveFrom    *time.Time `json:",omitempty"` // First moment the mapping applies (nil: always applied)
+	EffectiveUntil   *time.Time `json:",omitempty"` // Moment the mapping stops applying (nil: never retired)
 }
 
 // BazelTarget represents a target returned by Bazel query
@@ -34,101 +44,161 @@ type BazelQueryResult struct {
 	Target []BazelTarget `json:"target"`
 }
 
-// ValidDependency represents a valid dependency between packages
-type ValidDependency struct {
-	Source string
-	Target string
-}
-
 // MigrationHelper helps migrate modules to the new package structure
 type MigrationHelper struct {
-	SourceDir       string
-	TargetDir       string
-	WorkspaceRoot   string
-	DefaultMappings []PackageMapping
-	ValidDeps       []ValidDependency
+	SourceDirs         []string // Searched in order for each module's sources
+	TargetDir          string
+	WorkspaceRoot      string
+	DefaultMappings    []PackageMapping
+	ValidDeps          deprules.ValidDependencyRuleSet
+	Results            []MigrationResult
+	Conflicts          []ConflictWarning
+	Strict             bool                        // Treat warnings as errors
+	IncludeObjC        bool                        // Also migrate Objective-C .m and .h files
+	FileExtensions     []string                    // Extensions of the source files to migrate, without dots (nil: swift)
+	Overwrite          string                      // Destination files with different content: force, skip or ask (empty: abort)
+	MigrateResources   bool                        // Also migrate files in Resources/ and Assets/ directories
+	AsOf               time.Time                   // Date at which mappings are evaluated (zero: now)
+	QueryCache         *querycache.BazelQueryCache // Caches query output between runs; queries always run if nil
+	Executor           querycache.Executor         // Runs bazelisk when there is no query cache; BazeliskExecutor if nil
+	NoCache            bool                        // Run every query, even one this helper has already run
+	ImportRules        []Rule                      // Extra import rewrite rules, tried before the package mappings
+	FormatWithLibrary  bool                        // Format BUILD files in-process with the buildtools library (needs -tags buildtools)
+	DryRun             bool                        // Report the files and BUILD files a migration would write without writing them
+	Parallelism        int                         // Number of files MigrateModule migrates at once
+	NoRollback         bool                        // Leave a failed migration's files in place instead of undoing it
+	StateFile          string                      // JSON file recording copied files, so an interrupted migration can resume
+	ModuleDependencies map[string][]string         // Dependencies of each source module; queried from Bazel when nil
+	GitStage           bool                        // git add the files a successful migration wrote
+	RemoveSource       bool                        // Delete a successfully migrated module's source files
+	VerifyAPI          bool                        // Fail a module of -tier or -all whose public API differs from its source
+
+	mu               sync.Mutex // Guards strictErrors, dryRunWrites and manifest, which MigrateModule's workers update
+	strictErrors     int
+	journal          *MigrationJournal  // Journal of this run's migrations, opened on first use
+	dryRunWrites     []dryRunWrite      // Files the current dry run would have written
+	destinationLocks pathLocks          // Keeps MigrateModule's workers from writing the same file at once
+	manifest         *migrationManifest // What the current migration wrote, for rollback
+	state            *MigrationState    // Contents of StateFile during a migration
+	writtenFiles     []string           // Files the current migration wrote, for GitStage
+
+	results     map[string]*BazelQueryResult // RunBazelQuery results, by query
+	resultsMu   sync.RWMutex
+	cacheHits   int64 // RunBazelQuery calls answered from results
+	cacheMisses int64 // RunBazelQuery calls that ran the query
 }
 
 // NewMigrationHelper creates a new migration helper
-func NewMigrationHelper(sourceDir, targetDir, workspaceRoot string) *MigrationHelper {
-	// Define valid dependencies according to Alpha Dot Five structure
-	validDeps := []ValidDependency{
-		{"UmbraErrorKit", "UmbraCoreTypes"},
-		{"UmbraInterfaces", "UmbraCoreTypes"},
-		{"UmbraInterfaces", "UmbraErrorKit"},
-		{"UmbraUtils", "UmbraCoreTypes"},
-		{"UmbraImplementations", "UmbraInterfaces"},
-		{"UmbraImplementations", "UmbraCoreTypes"},
-		{"UmbraImplementations", "UmbraErrorKit"},
-		{"UmbraImplementations", "UmbraUtils"},
-		{"UmbraFoundationBridge", "UmbraCoreTypes"},
-		{"ResticKit", "UmbraInterfaces"},
-		{"ResticKit", "UmbraCoreTypes"},
-		{"ResticKit", "UmbraUtils"},
-	}
-
+func NewMigrationHelper(sourceDirs []string, targetDir, workspaceRoot string) *MigrationHelper {
 	// Define default package mappings
 	defaultMappings := []PackageMapping{
 		// Core Types
-		{"CoreDTOs", "UmbraCoreTypes/CoreDTOs", "CoreDTOs"},
-		{"KeyManagementTypes", "UmbraCoreTypes/KeyManagementTypes", "KeyManagementTypes"},
-		{"ResticTypes", "UmbraCoreTypes/ResticTypes", "ResticTypes"},
-		{"SecurityTypes", "UmbraCoreTypes/SecurityTypes", "SecurityTypes"},
-		{"ServiceTypes", "UmbraCoreTypes/ServiceTypes", "ServiceTypes"},
-		{"UmbraCoreTypes", "UmbraCoreTypes/Core", "UmbraCoreTypes"},
+		{SourceModule: "CoreDTOs", TargetPackage: "UmbraCoreTypes/CoreDTOs", ImportModuleAs: "CoreDTOs"},
+		{SourceModule: "KeyManagementTypes", TargetPackage: "UmbraCoreTypes/KeyManagementTypes", ImportModuleAs: "KeyManagementTypes"},
+		{SourceModule: "ResticTypes", TargetPackage: "UmbraCoreTypes/ResticTypes", ImportModuleAs: "ResticTypes"},
+		{SourceModule: "SecurityTypes", TargetPackage: "UmbraCoreTypes/SecurityTypes", ImportModuleAs: "SecurityTypes"},
+		{SourceModule: "ServiceTypes", TargetPackage: "UmbraCoreTypes/ServiceTypes", ImportModuleAs: "ServiceTypes"},
+		{SourceModule: "UmbraCoreTypes", TargetPackage: "UmbraCoreTypes/Core", ImportModuleAs: "UmbraCoreTypes"},
 
 		// Error Kit
-		{"ErrorHandling", "UmbraErrorKit/Implementation", "ErrorHandling"},
-		{"ErrorHandlingInterfaces", "UmbraErrorKit/Interfaces", "ErrorInterfaces"},
-		{"ErrorHandlingDomains", "UmbraErrorKit/Domains", "ErrorDomains"},
-		{"ErrorTypes", "UmbraErrorKit/Types", "ErrorTypes"},
-		{"UmbraErrors", "UmbraErrorKit/Core", "UmbraErrors"},
+		{SourceModule: "ErrorHandling", TargetPackage: "UmbraErrorKit/Implementation", ImportModuleAs: "ErrorHandling"},
+		{SourceModule: "ErrorHandlingInterfaces", TargetPackage: "UmbraErrorKit/Interfaces", ImportModuleAs: "ErrorInterfaces"},
+		{SourceModule: "ErrorHandlingDomains", TargetPackage: "UmbraErrorKit/Domains", ImportModuleAs: "ErrorDomains"},
+		{SourceModule: "ErrorTypes", TargetPackage: "UmbraErrorKit/Types", ImportModuleAs: "ErrorTypes"},
+		{SourceModule: "UmbraErrors", TargetPackage: "UmbraErrorKit/Core", ImportModuleAs: "UmbraErrors"},
 
 		// Interfaces
-		{"SecurityInterfaces", "UmbraInterfaces/SecurityInterfaces", "SecurityInterfaces"},
-		{"LoggingWrapperInterfaces", "UmbraInterfaces/LoggingInterfaces", "LoggingInterfaces"},
-		{"FileSystemTypes", "UmbraInterfaces/FileSystemInterfaces", "FileSystemInterfaces"},
-		{"XPCProtocolsCore", "UmbraInterfaces/XPCProtocolsCore", "XPCProtocolsCore"},
-		{"CryptoInterfaces", "UmbraInterfaces/CryptoInterfaces", "CryptoInterfaces"},
+		{SourceModule: "SecurityInterfaces", TargetPackage: "UmbraInterfaces/SecurityInterfaces", ImportModuleAs: "SecurityInterfaces"},
+		{SourceModule: "LoggingWrapperInterfaces", TargetPackage: "UmbraInterfaces/LoggingInterfaces", ImportModuleAs: "LoggingInterfaces"},
+		{SourceModule: "FileSystemTypes", TargetPackage: "UmbraInterfaces/FileSystemInterfaces", ImportModuleAs: "FileSystemInterfaces"},
+		{SourceModule: "XPCProtocolsCore", TargetPackage: "UmbraInterfaces/XPCProtocolsCore", ImportModuleAs: "XPCProtocolsCore"},
+		{SourceModule: "CryptoInterfaces", TargetPackage: "UmbraInterfaces/CryptoInterfaces", ImportModuleAs: "CryptoInterfaces"},
 
 		// Implementations
-		{"UmbraSecurity", "UmbraImplementations/SecurityImpl", "SecurityImpl"},
-		{"LoggingWrapper", "UmbraImplementations/LoggingImpl", "LoggingImpl"},
-		{"FileSystemService", "UmbraImplementations/FileSystemImpl", "FileSystemImpl"},
-		{"UmbraKeychainService", "UmbraImplementations/KeychainImpl", "KeychainImpl"},
-		{"UmbraCryptoService", "UmbraImplementations/CryptoImpl", "CryptoImpl"},
+		{SourceModule: "UmbraSecurity", TargetPackage: "UmbraImplementations/SecurityImpl", ImportModuleAs: "SecurityImpl"},
+		{SourceModule: "LoggingWrapper", TargetPackage: "UmbraImplementations/LoggingImpl", ImportModuleAs: "LoggingImpl"},
+		{SourceModule: "FileSystemService", TargetPackage: "UmbraImplementations/FileSystemImpl", ImportModuleAs: "FileSystemImpl"},
+		{SourceModule: "UmbraKeychainService", TargetPackage: "UmbraImplementations/KeychainImpl", ImportModuleAs: "KeychainImpl"},
+		{SourceModule: "UmbraCryptoService", TargetPackage: "UmbraImplementations/CryptoImpl", ImportModuleAs: "CryptoImpl"},
 
 		// Foundation Bridge
-		{"ObjCBridgingTypes", "UmbraFoundationBridge/ObjCBridging", "ObjCBridging"},
-		{"FoundationBridgeTypes", "UmbraFoundationBridge/CoreTypeBridges", "CoreTypeBridges"},
+		{SourceModule: "ObjCBridgingTypes", TargetPackage: "UmbraFoundationBridge/ObjCBridging", ImportModuleAs: "ObjCBridging"},
+		{SourceModule: "FoundationBridgeTypes", TargetPackage: "UmbraFoundationBridge/CoreTypeBridges", ImportModuleAs: "CoreTypeBridges"},
 
 		// Restic Kit
-		{"ResticCLIHelper", "ResticKit/CLIHelper", "CLIHelper"},
-		{"ResticCLIHelperModels", "ResticKit/CommandBuilder", "CommandBuilder"},
-		{"RepositoryManager", "ResticKit/RepositoryManager", "RepositoryManager"},
+		{SourceModule: "ResticCLIHelper", TargetPackage: "ResticKit/CLIHelper", ImportModuleAs: "CLIHelper"},
+		{SourceModule: "ResticCLIHelperModels", TargetPackage: "ResticKit/CommandBuilder", ImportModuleAs: "CommandBuilder"},
+		{SourceModule: "RepositoryManager", TargetPackage: "ResticKit/RepositoryManager", ImportModuleAs: "RepositoryManager"},
 
 		// Utils
-		{"DateTimeService", "UmbraUtils/DateUtils", "DateUtils"},
-		{"NetworkService", "UmbraUtils/Networking", "Networking"},
+		{SourceModule: "DateTimeService", TargetPackage: "UmbraUtils/DateUtils", ImportModuleAs: "DateUtils"},
+		{SourceModule: "NetworkService", TargetPackage: "UmbraUtils/Networking", ImportModuleAs: "Networking"},
 	}
 
-	return &MigrationHelper{
-		SourceDir:       sourceDir,
+	checkDefaultMappings(defaultMappings)
+
+	m := &MigrationHelper{
+		SourceDirs:      sourceDirs,
 		TargetDir:       targetDir,
 		WorkspaceRoot:   workspaceRoot,
 		DefaultMappings: defaultMappings,
-		ValidDeps:       validDeps,
+		ValidDeps:       deprules.Default(),
+		Parallelism:     defaultParallelism,
 	}
+
+	// Detect ambiguous mappings up front
+	m.Conflicts = m.ValidateMappingConsistency()
+
+	return m
 }
 
-// RunBazelQuery runs a Bazel query and returns the result
+// RunBazelQuery runs a Bazel query and returns the result. Results are kept for the helper's lifetime, so
+// running the same query again does not invoke Bazel, unless NoCache is set.
 func (m *MigrationHelper) RunBazelQuery(query string) (*BazelQueryResult, error) {
-	cmd := exec.Command("bazelisk", "query", "--output=json", query)
-	cmd.Dir = m.WorkspaceRoot
+	if !m.NoCache {
+		m.resultsMu.RLock()
+		result, cached := m.results[query]
+		m.resultsMu.RUnlock()
+		if cached {
+			atomic.AddInt64(&m.cacheHits, 1)
+			return result, nil
+		}
+	}
+	atomic.AddInt64(&m.cacheMisses, 1)
 
-	output, err := cmd.Output()
+	result, err := m.runBazelQuery(query)
+	if err != nil || m.NoCache {
+		return result, err
+	}
+	m.resultsMu.Lock()
+	defer m.resultsMu.Unlock()
+	if m.results == nil {
+		m.results = make(map[string]*BazelQueryResult)
+	}
+	m.results[query] = result
+	return result, nil
+}
+
+// CacheStats returns how many RunBazelQuery calls were answered from the in-memory cache and how many ran
+// the query
+func (m *MigrationHelper) CacheStats() (hits, misses int) {
+	return int(atomic.LoadInt64(&m.cacheHits)), int(atomic.LoadInt64(&m.cacheMisses))
+}
+
+// runBazelQuery runs a query, bypassing the in-memory cache
+func (m *MigrationHelper) runBazelQuery(query string) (*BazelQueryResult, error) {
+	var output []byte
+	var err error
+	switch {
+	case m.QueryCache != nil:
+		output, err = m.QueryCache.Query(m.WorkspaceRoot, "query", "--output=json", query)
+	case m.Executor != nil:
+		output, err = m.Executor(m.WorkspaceRoot, "query", "--output=json", query)
+	default:
+		output, err = querycache.BazeliskExecutor(m.WorkspaceRoot, "query", "--output=json", query)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("error running bazel query: %v", err)
+		return nil, err
 	}
 
 	var result BazelQueryResult
@@ -141,7 +211,15 @@ func (m *MigrationHelper) RunBazelQuery(query string) (*BazelQueryResult, error)
 
 // GetModuleDependencies gets dependencies of a module using bazelisk query
 func (m *MigrationHelper) GetModuleDependencies(moduleName string) ([]string, error) {
-	query := fmt.Sprintf("deps(//Sources/%s:*)", moduleName)
+	// Query the module in the first source directory that contains it
+	sourceDir := m.FindModuleSourceDir(moduleName)
+	if sourceDir == "" && len(m.SourceDirs) > 0 {
+		sourceDir = m.SourceDirs[0]
+	}
+	modulePackage := m.sourceLabelPrefix(sourceDir) + "/" + moduleName
+
+	// Test targets may use @testable imports and must not add dependencies to the library target
+	query := fmt.Sprintf("deps(%s:* except tests(%s:*))", modulePackage, modulePackage)
 	result, err := m.RunBazelQuery(query)
 	if err != nil {
 		return nil, fmt.Errorf("error querying dependencies: %v", err)
@@ -150,17 +228,21 @@ func (m *MigrationHelper) GetModuleDependencies(moduleName string) ([]string, er
 	deps := []string{}
 	for _, target := range result.Target {
 		name := target.Name
-		if strings.HasPrefix(name, "//Sources/") && strings.Contains(name, ":") {
-			// Extract module name from target
-			parts := strings.Split(name, "//Sources/")
-			if len(parts) < 2 {
+		if !strings.Contains(name, ":") {
+			continue
+		}
+		for _, dir := range m.SourceDirs {
+			prefix := m.sourceLabelPrefix(dir) + "/"
+			if !strings.HasPrefix(name, prefix) {
 				continue
 			}
-			parts = strings.Split(parts[1], ":")
-			module := parts[0]
+			// Extract module name from target
+			module := strings.Split(strings.TrimPrefix(name, prefix), ":")[0]
+			module = strings.Split(module, "/")[0]
 			if module != moduleName && !contains(deps, module) {
 				deps = append(deps, module)
 			}
+			break
 		}
 	}
 
@@ -185,6 +267,7 @@ func (m *MigrationHelper) CheckMigrationDependencies(moduleName, targetPackage s
 	}
 
 	missingDeps := []string{}
+	invalidDeps := []string{}
 	for _, dep := range deps {
 		// Skip dependencies that aren't mapped
 		targetMapping := m.GetTargetMapping(dep)
@@ -198,27 +281,11 @@ func (m *MigrationHelper) CheckMigrationDependencies(moduleName, targetPackage s
 
 		// Check if this dependency is valid according to Alpha Dot Five rules
 		if depTopLevelPackage != topLevelPackage {
-			isValid := false
-			for _, validDep := range m.ValidDeps {
-				if validDep.Source == topLevelPackage && validDep.Target == depTopLevelPackage {
-					isValid = true
-					break
-				}
-			}
-
-			if !isValid {
-				fmt.Printf("⚠️ Warning: %s depends on %s which maps to %s\n", moduleName, dep, depTargetPackage)
+			if !m.ValidDeps.Contains(topLevelPackage, depTopLevelPackage) {
+				invalidDeps = append(invalidDeps, fmt.Sprintf("%s -> %s", dep, depTargetPackage))
+				m.warn("%s depends on %s which maps to %s", moduleName, dep, depTargetPackage)
 				fmt.Printf("   This would create an invalid dependency from %s to %s\n", topLevelPackage, depTopLevelPackage)
-				fmt.Printf("   Valid dependencies for %s are: ", topLevelPackage)
-				for i, validDep := range m.ValidDeps {
-					if validDep.Source == topLevelPackage {
-						if i > 0 {
-							fmt.Printf(", ")
-						}
-						fmt.Printf("%s", validDep.Target)
-					}
-				}
-				fmt.Println()
+				fmt.Printf("   Valid dependencies for %s are: %s\n", topLevelPackage, strings.Join(m.ValidDeps.TargetsOf(topLevelPackage), ", "))
 			}
 		}
 
@@ -238,48 +305,89 @@ func (m *MigrationHelper) CheckMigrationDependencies(moduleName, targetPackage s
 		return false, missingDeps
 	}
 
+	// Invalid dependencies are only warnings unless running in strict mode
+	if m.Strict && len(invalidDeps) > 0 {
+		return false, invalidDeps
+	}
+
 	return true, nil
 }
 
 // GetTargetMapping gets the target mapping for a source module
 func (m *MigrationHelper) GetTargetMapping(sourceModule string) *PackageMapping {
+	asOf := m.asOf()
+
+	// Prefer the most recently effective mapping when phases overlap
+	var selected *PackageMapping
+	for i, mapping := range m.DefaultMappings {
+		if mapping.SourceModule != sourceModule || !mapping.EffectiveAt(asOf) {
+			continue
+		}
+		if selected == nil || (mapping.EffectiveFrom != nil &&
+			(selected.EffectiveFrom == nil || mapping.EffectiveFrom.After(*selected.EffectiveFrom))) {
+			selected = &m.DefaultMappings[i]
+		}
+	}
+	if selected == nil {
+		return nil
+	}
+
+	mapping := *selected
+	return &mapping
+}
+
+// EffectiveMappings returns the mapping in effect for each source module, in mapping order
+func (m *MigrationHelper) EffectiveMappings() []PackageMapping {
+	mappings := []PackageMapping{}
+	seen := make(map[string]bool)
 	for _, mapping := range m.DefaultMappings {
-		if mapping.SourceModule == sourceModule {
-			return &mapping
+		if seen[mapping.SourceModule] {
+			continue
+		}
+		seen[mapping.SourceModule] = true
+		if effective := m.GetTargetMapping(mapping.SourceModule); effective != nil {
+			mappings = append(mappings, *effective)
 		}
 	}
-	return nil
+	return mappings
+}
+
+// EffectiveAt checks if the mapping applies at time t
+func (p PackageMapping) EffectiveAt(t time.Time) bool {
+	if p.EffectiveFrom != nil && t.Before(*p.EffectiveFrom) {
+		return false
+	}
+	if p.EffectiveUntil != nil && !t.Before(*p.EffectiveUntil) {
+		return false
+	}
+	return true
+}
+
+// asOf returns the time at which mappings are evaluated
+func (m *MigrationHelper) asOf() time.Time {
+	if m.AsOf.IsZero() {
+		return time.Now()
+	}
+	return m.AsOf
 }
 
 // UpdateImports updates import statements in a Swift file
 func (m *MigrationHelper) UpdateImports(filePath string, moduleMapping map[string]string) error {
-	content, err := ioutil.ReadFile(filePath)
+	content, err := m.readFile(filePath)
 	if err != nil {
 		return fmt.Errorf("error reading file: %v", err)
 	}
 
-	fileContent := string(content)
-
-	// Find all import statements
-	importPattern := regexp.MustCompile(`import\s+(\w+)`)
-	matches := importPattern.FindAllStringSubmatch(fileContent, -1)
-
-	// Replace imports according to mapping
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
-		}
-
-		oldImport := match[1]
-		if newImport, exists := moduleMapping[oldImport]; exists && newImport != oldImport {
-			oldImportPattern := regexp.MustCompile(fmt.Sprintf(`import\s+%s\b`, oldImport))
-			fileContent = oldImportPattern.ReplaceAllString(fileContent, fmt.Sprintf("import %s", newImport))
-			fmt.Printf("Updated import: %s -> %s\n", oldImport, newImport)
+	// Replace imports according to mapping, keeping attributes such as @testable
+	fileContent, changes := m.importRewriter(moduleMapping).RewriteAll(string(content))
+	if !m.DryRun {
+		for _, change := range changes {
+			fmt.Printf("Updated import: %s -> %s\n", strings.TrimSpace(change.Line), strings.TrimSpace(change.Rewritten))
 		}
 	}
 
 	// Write updated content back to file
-	if err := ioutil.WriteFile(filePath, []byte(fileContent), 0644); err != nil {
+	if err := m.writeFile(filePath, []byte(fileContent)); err != nil {
 		return fmt.Errorf("error writing file: %v", err)
 	}
 
@@ -287,15 +395,53 @@ func (m *MigrationHelper) UpdateImports(filePath string, moduleMapping map[strin
 }
 
 // MigrateModule migrates a module from the old structure to the new package structure
-func (m *MigrationHelper) MigrateModule(moduleName, targetPackage string, skipDependencyCheck bool) (bool, error) {
-	sourceModulePath := filepath.Join(m.SourceDir, moduleName)
-	if !dirExists(sourceModulePath) {
-		return false, fmt.Errorf("source module %s not found at %s", moduleName, sourceModulePath)
+func (m *MigrationHelper) MigrateModule(moduleName, targetPackage string, skipDependencyCheck bool) (success bool, err error) {
+	// Record the outcome of this migration however it ends
+	migratedFiles := []string{}
+	testableImports := []string{}
+	defer func() {
+		m.recordResult(moduleName, targetPackage, migratedFiles, success, err)
+		m.Results[len(m.Results)-1].TestableImports = testableImports
+	}()
+
+	m.writtenFiles = nil
+
+	// In strict mode only this module's warnings fail it, not those of modules migrated before it
+	m.mu.Lock()
+	strictErrorsBefore := m.strictErrors
+	m.mu.Unlock()
+
+	// Undo everything this migration wrote if it fails, before the failure is journaled
+	if !m.DryRun && !m.NoRollback {
+		manifest := newMigrationManifest()
+		m.manifest = manifest
+		defer func() {
+			m.manifest = nil
+			if err != nil {
+				if rollbackErr := rollbackMigration(manifest); rollbackErr != nil {
+					m.warn("Error rolling back the migration of %s: %v", moduleName, rollbackErr)
+				}
+			}
+		}()
+	}
+
+	sourceDir := m.FindModuleSourceDir(moduleName)
+	if sourceDir == "" {
+		return false, fmt.Errorf("source module %s not found in %s", moduleName, strings.Join(m.SourceDirs, ", "))
+	}
+	sourceModulePath := filepath.Join(sourceDir, moduleName)
+
+	// Refuse to split a module across packages
+	if err := m.checkForSplit(moduleName, targetPackage); err != nil {
+		return false, err
 	}
 
 	// Check dependencies unless skipped
 	if !skipDependencyCheck {
 		depsOk, _ := m.CheckMigrationDependencies(moduleName, targetPackage)
+		if !depsOk && m.Strict {
+			return false, fmt.Errorf("migration aborted due to dependency check failure (strict mode)")
+		}
 		if !depsOk {
 			fmt.Printf("⚠️ Dependency check failed for %s\n", moduleName)
 			fmt.Print("Do you want to continue anyway? (y/n): ")
@@ -308,37 +454,37 @@ func (m *MigrationHelper) MigrateModule(moduleName, targetPackage string, skipDe
 	}
 
 	// Split target package into package name and subpackage path
-	parts := strings.SplitN(targetPackage, "/", 2)
-	packageName := parts[0]
-	subpackage := ""
-	if len(parts) > 1 {
-		subpackage = parts[1]
-	}
+	packageName, subpackage := splitTargetPackage(targetPackage)
 
 	// Create target directory
-	targetModulePath := filepath.Join(m.TargetDir, packageName, "Sources")
-	if subpackage != "" {
-		targetModulePath = filepath.Join(targetModulePath, subpackage)
-	}
+	targetModulePath := m.TargetModulePath(targetPackage)
 
-	if err := os.MkdirAll(targetModulePath, 0755); err != nil {
-		return false, fmt.Errorf("error creating target directory: %v", err)
+	if !m.DryRun {
+		if err := m.mkdirAll(targetModulePath); err != nil {
+			return false, fmt.Errorf("error creating target directory: %v", err)
+		}
 	}
+	defer func() {
+		if m.DryRun {
+			m.printDryRunReport()
+		}
+	}()
 
 	// Prepare module mapping for import updates
 	moduleMapping := make(map[string]string)
-	for _, mapping := range m.DefaultMappings {
+	for _, mapping := range m.EffectiveMappings() {
 		moduleMapping[mapping.SourceModule] = mapping.ImportModuleAs
 	}
+	headerMapping := m.objcHeaderMapping()
 
-	// Copy Swift files, excluding tests
-	filesCopied := 0
-	err := filepath.Walk(sourceModulePath, func(path string, info os.FileInfo, err error) error {
+	// Collect the source files (Swift, and the other file extensions if requested) to copy, excluding tests
+	jobs := []fileJob{}
+	err = filepath.Walk(sourceModulePath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip tests and non-Swift files
+		// Skip tests and files that are not sources
 		if info.IsDir() {
 			if strings.Contains(path, "Tests") {
 				return filepath.SkipDir
@@ -346,70 +492,117 @@ func (m *MigrationHelper) MigrateModule(moduleName, targetPackage string, skipDe
 			return nil
 		}
 
-		if !strings.HasSuffix(path, ".swift") || strings.HasSuffix(path, "Test.swift") {
-			return nil
-		}
-
 		// Preserve subdirectory structure relative to the module
 		relPath, err := filepath.Rel(sourceModulePath, filepath.Dir(path))
 		if err != nil {
 			return err
 		}
 
-		var targetFilePath string
-		if relPath != "." {
-			targetDir := filepath.Join(targetModulePath, relPath)
-			if err := os.MkdirAll(targetDir, 0755); err != nil {
-				return err
-			}
-			targetFilePath = filepath.Join(targetDir, filepath.Base(path))
-		} else {
-			targetFilePath = filepath.Join(targetModulePath, filepath.Base(path))
-		}
-
-		// Copy the file
-		if err := copyFile(path, targetFilePath); err != nil {
-			return err
-		}
-
-		filesCopied++
-		fmt.Printf("Copied %s to %s\n", filepath.Base(path), targetFilePath)
-
-		// Update imports
-		if err := m.UpdateImports(targetFilePath, moduleMapping); err != nil {
-			fmt.Printf("Warning: Error updating imports in %s: %v\n", targetFilePath, err)
+		resourceFile := m.MigrateResources && isResourcePath(relPath)
+		sourceFile := m.isSourceFile(path)
+		objcFile := sourceFile && isObjCFile(path)
+		modulemapFile := m.IncludeObjC && strings.HasSuffix(path, ".modulemap")
+		if (!sourceFile && !modulemapFile && !resourceFile) || strings.HasSuffix(path, "Test.swift") {
+			return nil
 		}
 
+		jobs = append(jobs, fileJob{
+			module:        moduleName,
+			targetPackage: targetPackage,
+			source:        path,
+			target:        filepath.Join(targetModulePath, relPath, filepath.Base(path)),
+			resource:      resourceFile && !sourceFile,
+			objc:          objcFile,
+			modulemap:     modulemapFile,
+		})
 		return nil
 	})
+	if err != nil {
+		return false, fmt.Errorf("error copying files: %v", err)
+	}
 
+	// Before writing anything, check for destination files that exist with different content
+	if jobs, err = m.resolveConflicts(jobs, moduleMapping, headerMapping); err != nil {
+		return false, err
+	}
+
+	// Copy and rewrite the files in parallel, skipping those the state file shows are already migrated
+	if err := m.loadState(); err != nil {
+		return false, err
+	}
+	summary, err := m.migrateFiles(jobs, moduleMapping, headerMapping)
+	migratedFiles, testableImports = summary.migratedFiles, summary.testableImports
 	if err != nil {
 		return false, fmt.Errorf("error copying files: %v", err)
 	}
 
-	fmt.Printf("Migration complete: %d files copied\n", filesCopied)
+	if m.DryRun {
+		fmt.Printf("Dry run complete: %d files would be copied\n", summary.copied)
+	} else if summary.skipped > 0 {
+		fmt.Printf("Migration complete: %d files copied, %d unchanged files skipped\n", summary.copied, summary.skipped)
+	} else {
+		fmt.Printf("Migration complete: %d files copied\n", summary.copied)
+	}
+
+	// Tests are not migrated, so any @testable import left is in production code
+	if len(testableImports) > 0 {
+		sort.Strings(testableImports)
+		return false, fmt.Errorf("migrated non-test files use @testable import of %s", strings.Join(testableImports, ", "))
+	}
 
 	// Create or update BUILD file for the subpackage
 	if err := m.CreateOrUpdateBuildFile(packageName, subpackage); err != nil {
 		return false, fmt.Errorf("error creating BUILD file: %v", err)
 	}
 
-	return filesCopied > 0, nil
+	if warnings := m.strictErrors - strictErrorsBefore; m.Strict && warnings > 0 {
+		return false, fmt.Errorf("%d warnings treated as errors (strict mode)", warnings)
+	}
+
+	if len(migratedFiles) == 0 {
+		return false, nil
+	}
+
+	// Only a successful migration removes its sources and stages its files
+	if !m.DryRun {
+		removedSources := []string{}
+		if m.RemoveSource {
+			if removedSources, err = m.removeSources(jobs, sourceModulePath, moduleMapping, headerMapping); err != nil {
+				m.warn("%v", err)
+			}
+		}
+		if m.GitStage {
+			m.stageMigration(sourceDir, removedSources)
+		}
+	}
+
+	return true, nil
+}
+
+// splitTargetPackage splits a target package into its package name and subpackage path
+func splitTargetPackage(targetPackage string) (string, string) {
+	parts := strings.SplitN(targetPackage, "/", 2)
+	if len(parts) > 1 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// TargetModulePath returns the directory a module migrated to targetPackage is copied into
+func (m *MigrationHelper) TargetModulePath(targetPackage string) string {
+	packageName, subpackage := splitTargetPackage(targetPackage)
+	targetModulePath := filepath.Join(m.TargetDir, packageName, "Sources")
+	if subpackage != "" {
+		targetModulePath = filepath.Join(targetModulePath, subpackage)
+	}
+	return targetModulePath
 }
 
-// CreateOrUpdateBuildFile creates or updates a BUILD.bazel file for a package or subpackage
-func (m *MigrationHelper) CreateOrUpdateBuildFile(packageName, subpackage string) error {
-	var buildDir, targetName string
-	var visibility []string
+// defaultBuildDeps returns the standard dependencies of a package or subpackage BUILD file
+func defaultBuildDeps(packageName, subpackage string) []string {
 	var deps []string
 
 	if subpackage != "" {
-		// Subpackage BUILD file
-		buildDir = filepath.Join(m.TargetDir, packageName, "Sources", subpackage)
-		parts := strings.Split(subpackage, "/")
-		targetName = parts[len(parts)-1]
-		visibility = []string{fmt.Sprintf("//packages/%s:__subpackages__", packageName)}
-
 		// Determine dependencies based on package rules
 		if packageName == "UmbraErrorKit" {
 			if !strings.Contains(subpackage, "Interfaces") {
@@ -424,101 +617,223 @@ func (m *MigrationHelper) CreateOrUpdateBuildFile(packageName, subpackage string
 				deps = append(deps, "//packages/UmbraErrorKit/Sources/Interfaces")
 			}
 		}
+		return deps
+	}
+
+	// Add standard dependencies based on package type
+	if packageName == "UmbraErrorKit" {
+		deps = append(deps, "//packages/UmbraCoreTypes")
+	} else if packageName == "UmbraInterfaces" {
+		deps = append(deps, "//packages/UmbraCoreTypes")
+		deps = append(deps, "//packages/UmbraErrorKit")
+	} else if packageName == "UmbraImplementations" {
+		deps = append(deps, "//packages/UmbraInterfaces")
+		deps = append(deps, "//packages/UmbraCoreTypes")
+		deps = append(deps, "//packages/UmbraErrorKit")
+	} else if packageName == "UmbraFoundationBridge" {
+		deps = append(deps, "//packages/UmbraCoreTypes")
+	} else if packageName == "ResticKit" {
+		deps = append(deps, "//packages/UmbraInterfaces")
+		deps = append(deps, "//packages/UmbraCoreTypes")
+	} else if packageName == "UmbraUtils" {
+		deps = append(deps, "//packages/UmbraCoreTypes")
+	}
+	return deps
+}
+
+// CreateOrUpdateBuildFile creates or updates a BUILD.bazel file for a package or subpackage. A subpackage
+// more than one level deep also gets a BUILD file at each intermediate level, listing its child as a dep.
+func (m *MigrationHelper) CreateOrUpdateBuildFile(packageName, subpackage string) error {
+	var buildDir, targetName string
+	var visibility []string
+
+	if subpackage != "" {
+		// Subpackage BUILD file
+		buildDir = filepath.Join(m.TargetDir, packageName, "Sources", subpackage)
+		parts := strings.Split(subpackage, "/")
+		targetName = parts[len(parts)-1]
+		visibility = []string{fmt.Sprintf("//packages/%s:__subpackages__", packageName)}
 	} else {
 		// Main package BUILD file
 		buildDir = filepath.Join(m.TargetDir, packageName)
 		targetName = packageName
 		visibility = []string{"//visibility:public"}
-
-		// Add standard dependencies based on package type
-		if packageName == "UmbraErrorKit" {
-			deps = append(deps, "//packages/UmbraCoreTypes")
-		} else if packageName == "UmbraInterfaces" {
-			deps = append(deps, "//packages/UmbraCoreTypes")
-			deps = append(deps, "//packages/UmbraErrorKit")
-		} else if packageName == "UmbraImplementations" {
-			deps = append(deps, "//packages/UmbraInterfaces")
-			deps = append(deps, "//packages/UmbraCoreTypes")
-			deps = append(deps, "//packages/UmbraErrorKit")
-		} else if packageName == "UmbraFoundationBridge" {
-			deps = append(deps, "//packages/UmbraCoreTypes")
-		} else if packageName == "ResticKit" {
-			deps = append(deps, "//packages/UmbraInterfaces")
-			deps = append(deps, "//packages/UmbraCoreTypes")
-		} else if packageName == "UmbraUtils" {
-			deps = append(deps, "//packages/UmbraCoreTypes")
-		}
 	}
+	deps := defaultBuildDeps(packageName, subpackage)
 
 	buildPath := filepath.Join(buildDir, "BUILD.bazel")
 
 	// Only create the file if it doesn't exist or it's a subpackage (which gets recreated)
 	if !fileExists(buildPath) || subpackage != "" {
-		// Format dependencies for Starlark
-		depsStr := ""
-		if len(deps) > 0 {
-			formattedDeps := make([]string, len(deps))
-			for i, dep := range deps {
-				formattedDeps[i] = fmt.Sprintf("        \"%s\"", dep)
+		spec := BuildSpec{
+			PackageName:    packageName,
+			SubpackagePath: subpackage,
+			TargetName:     targetName,
+			Extensions:     m.FileExtensions,
+			Deps:           deps,
+			Visibility:     visibility,
+		}
+
+		// Add resources if any resource directories were migrated
+		if m.MigrateResources {
+			if globs := resourceGlobs(buildDir); len(globs) > 0 {
+				spec.ExtraAttrs = map[string]string{"resources": fmt.Sprintf("glob([%s])", strings.Join(globs, ", "))}
 			}
-			depsStr = fmt.Sprintf("\n    deps = [\n%s,\n    ],", strings.Join(formattedDeps, ",\n"))
-		}
-
-		// Format glob pattern based on whether this is a subpackage
-		globPattern := "\"*.swift\""
-		if subpackage == "" {
-			globPattern = "\"Sources/**/*.swift\""
-		}
-
-		// Format visibility for Starlark
-		visibilityStr := make([]string, len(visibility))
-		for i, v := range visibility {
-			visibilityStr[i] = fmt.Sprintf("\"%s\"", v)
-		}
-
-		// Create BUILD file content
-		buildContent := fmt.Sprintf(`load("//bazel:swift_rules.bzl", "umbra_swift_library")
-
-umbra_swift_library(
-    name = "%s",
-    srcs = glob(
-        [
-            %s,
-        ],
-        allow_empty = False,
-        exclude = [
-            "**/Tests/**",
-            "**/*Test.swift",
-            "**/*.generated.swift",
-        ],
-        exclude_directories = 1,
-    ),%s
-    visibility = [%s],
-)
-`, targetName, globPattern, depsStr, strings.Join(visibilityStr, ", "))
+		}
 
-		// Create parent directories if needed
-		if err := os.MkdirAll(filepath.Dir(buildPath), 0755); err != nil {
-			return fmt.Errorf("error creating directory: %v", err)
+		content, err := NewBuildFileGenerator().Generate(spec)
+		if err != nil {
+			return err
+		}
+		if err := m.writeBuildFile(buildPath, targetName, content); err != nil {
+			return err
 		}
+	}
 
-		// Write the BUILD file
-		if err := ioutil.WriteFile(buildPath, []byte(buildContent), 0644); err != nil {
-			return fmt.Errorf("error writing BUILD file: %v", err)
+	// Intermediate levels, deepest first, so each one lists the child just written
+	parts := strings.Split(subpackage, "/")
+	for level := len(parts) - 1; level > 0; level-- {
+		if err := m.createIntermediateBuildFile(packageName, strings.Join(parts[:level], "/"), parts[level]); err != nil {
+			return err
 		}
+	}
 
-		// Run buildifier to ensure proper formatting
-		cmd := exec.Command("buildifier", buildPath)
-		if err := cmd.Run(); err != nil {
-			fmt.Printf("Warning: Created BUILD file but buildifier formatting failed: %v\n", err)
-		} else {
-			fmt.Printf("Created and formatted BUILD file for %s\n", targetName)
+	return nil
+}
+
+// createIntermediateBuildFile writes the BUILD file of a subpackage level above a migrated subpackage. It lists
+// child, and any other child directory with a BUILD file, as deps of an umbra_swift_library if the level has
+// sources of its own, or as the srcs of a filegroup otherwise. An existing BUILD file is kept, and only gets
+// child added to its deps or srcs.
+func (m *MigrationHelper) createIntermediateBuildFile(packageName, subpackage, child string) error {
+	buildDir := filepath.Join(m.TargetDir, packageName, "Sources", subpackage)
+	parts := strings.Split(subpackage, "/")
+	targetName := parts[len(parts)-1]
+
+	buildPath := filepath.Join(buildDir, "BUILD.bazel")
+	if fileExists(buildPath) {
+		return m.addIntermediateDep(buildPath, fmt.Sprintf("//packages/%s/Sources/%s/%s", packageName, subpackage, child))
+	}
+
+	children := []string{child}
+	if entries, err := ioutil.ReadDir(buildDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && !contains(children, entry.Name()) && fileExists(filepath.Join(buildDir, entry.Name(), "BUILD.bazel")) {
+				children = append(children, entry.Name())
+			}
 		}
 	}
+	sort.Strings(children)
+	labels := make([]string, len(children))
+	for i, name := range children {
+		labels[i] = fmt.Sprintf("//packages/%s/Sources/%s/%s", packageName, subpackage, name)
+	}
 
+	spec := BuildSpec{
+		PackageName:    packageName,
+		SubpackagePath: subpackage,
+		TargetName:     targetName,
+		Extensions:     m.FileExtensions,
+		Deps:           labels,
+	}
+	generator := NewBuildFileGenerator()
+	var content string
+	var err error
+	if m.hasOwnSources(buildDir) {
+		spec.Deps = append(defaultBuildDeps(packageName, subpackage), labels...)
+		content, err = generator.Generate(spec)
+	} else {
+		content, err = generator.GenerateFilegroup(spec)
+	}
+	if err != nil {
+		return err
+	}
+	return m.writeBuildFile(buildPath, targetName, content)
+}
+
+// addIntermediateDep adds the label of a child subpackage to an existing intermediate BUILD file
+func (m *MigrationHelper) addIntermediateDep(buildPath, label string) error {
+	content, err := m.readFile(buildPath)
+	if err != nil {
+		return fmt.Errorf("error reading BUILD file: %v", err)
+	}
+
+	updated, changed, err := AddBuildLabel(string(content), label)
+	if err != nil {
+		m.warn("Could not update %s: %v", buildPath, err)
+		return nil
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := m.writeFile(buildPath, []byte(updated)); err != nil {
+		return fmt.Errorf("error writing BUILD file: %v", err)
+	}
+	if m.DryRun {
+		return nil
+	}
+	if err := formatBuildFile(buildPath, m.FormatWithLibrary); err != nil {
+		m.warn("Added %s to %s but buildifier formatting failed: %v", label, buildPath, err)
+	} else {
+		fmt.Printf("Added %s to %s\n", label, buildPath)
+	}
+	return nil
+}
+
+// hasOwnSources checks if a directory has source files outside its child directories with BUILD files, which
+// are separate Bazel packages its glob does not reach
+func (m *MigrationHelper) hasOwnSources(dir string) bool {
+	found := false
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return filepath.SkipDir
+		}
+		if found {
+			return filepath.SkipAll
+		}
+		if info.IsDir() {
+			if path != dir && fileExists(filepath.Join(path, "BUILD.bazel")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		found = m.isSourceFile(path)
+		return nil
+	})
+	return found
+}
+
+// writeBuildFile writes a generated BUILD file and formats it with buildifier
+func (m *MigrationHelper) writeBuildFile(buildPath, targetName, content string) error {
+	if err := m.writeFile(buildPath, []byte(content)); err != nil {
+		return fmt.Errorf("error writing BUILD file: %v", err)
+	}
+	if m.DryRun {
+		return nil
+	}
+
+	// Run buildifier to ensure proper formatting
+	if err := formatBuildFile(buildPath, m.FormatWithLibrary); err != nil {
+		m.warn("Created BUILD file but buildifier formatting failed: %v", err)
+	} else {
+		fmt.Printf("Created and formatted BUILD file for %s\n", targetName)
+	}
 	return nil
 }
 
+// warn reports a warning, which counts as an error in strict mode
+func (m *MigrationHelper) warn(format string, args ...interface{}) {
+	if m.Strict {
+		m.mu.Lock()
+		m.strictErrors++
+		m.mu.Unlock()
+		fmt.Printf("❌ ERROR (strict): "+format+"\n", args...)
+		return
+	}
+	fmt.Printf("⚠️ Warning: "+format+"\n", args...)
+}
+
 // Helper functions
 
 // contains checks if a string is in a slice
@@ -564,37 +879,103 @@ func dirHasSwiftFiles(path string) bool {
 	return false
 }
 
-// copyFile copies a file from src to dst
-func copyFile(src, dst string) error {
-	input, err := ioutil.ReadFile(src)
-	if err != nil {
-		return err
-	}
-	return ioutil.WriteFile(dst, input, 0644)
+// subcommands maps subcommand names to their handlers
+var subcommands = map[string]func(args []string) error{
+	"check-swift-access": runCheckSwiftAccess,
+	"detect-splits":      runDetectSplits,
+	"list-mappings":      runListMappings,
+	"scaffold":           runScaffold,
+	"generate-build":     runGenerateBuild,
+	"journal":            runJournal,
+	"migration-diff":     runMigrationDiff,
+	"audit-trail":        runAuditTrail,
+	"validate":           runValidate,
+	"status":             runStatus,
 }
 
 func main() {
-	sourceFlag := flag.String("source", "Sources", "Source directory containing old modules")
+	// Dispatch to a subcommand if one was given
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				log.Fatalf("Error running %s: %v", os.Args[1], err)
+			}
+			return
+		}
+	}
+
+	var sourceFlag sourceDirsFlag
+	flag.Var(&sourceFlag, "source", "Source directory containing old modules (default Sources); repeat to search several directories in order")
 	targetFlag := flag.String("target", "packages", "Target directory for new packages")
 	workspaceFlag := flag.String("workspace", "", "Workspace root for running Bazel queries")
+	workspaceAutoDetectFlag := flag.Bool("workspace-auto-detect", true, "Without -workspace, use the nearest parent of the current directory with a WORKSPACE, WORKSPACE.bazel or MODULE.bazel file as the workspace root (if false, use the source directory's parent)")
 	moduleFlag := flag.String("module", "", "Name of the module to migrate")
 	destinationFlag := flag.String("destination", "", "Destination path in new structure (e.g., UmbraCoreTypes/KeyManagementTypes)")
+	tierFlag := flag.String("tier", "", "Migrate every module mapped to this top-level package (e.g., UmbraCoreTypes) instead of -module")
+	allFlag := flag.Bool("all", false, "Migrate every mapped module not migrated yet, dependencies first (with -dry-run, print the plan)")
+	reportFlag := flag.String("report", "", "Write a report of a -tier or -all migration to this path (HTML for .html, MigrationReport proto for .pb, JSON otherwise)")
 	skipDepsFlag := flag.Bool("skip-deps", false, "Skip dependency validation")
+	includeObjCFlag := flag.Bool("include-objc", false, "Also migrate Objective-C .m and .h files, rewriting #import directives")
+	fileExtensionsFlag := flag.String("file-extensions", "swift", "Comma-separated extensions of the source files to migrate and glob in BUILD files (e.g., swift,h,m)")
+	migrateResourcesFlag := flag.Bool("migrate-resources", false, "Also migrate files in Resources/ and Assets/ directories")
+	progressReportFlag := flag.String("progress-report", "", "Write a Markdown table of every mapped module's migration status to this file, after any migration")
+	checkImportsFlag := flag.Bool("check-imports", false, "List Swift files under -target that import a module under both its old and new name, and exit")
+	checkAccessFlag := flag.Bool("check-access", false, "Warn about internal and fileprivate symbols used across merged source modules")
+	compareSourcesFlag := flag.Bool("compare-sources", false, "Diff the module's source files with their migrated copies, ignoring imports, and exit")
+	verifyAPIFlag := flag.Bool("verify-api", false, "Fail a migrated module whose public declarations differ from the source (with -tier and -all, each module; changes are listed in -report)")
+	asOfFlag := flag.String("as-of", "", "Evaluate phased mappings at this date (YYYY-MM-DD) instead of today")
+	configFlag := flag.String("config", "", "JSON file with packageMappings and validDependencies lists")
+	configModeFlag := flag.String("config-mode", configModeMerge, "How -config combines with the built-in defaults: merge or replace")
+	mappingsFileFlag := flag.String("mappings-file", "", "JSON file with additional package mappings, overriding defaults for the same module")
+	mappingsBzlFlag := flag.String("mappings-bzl", "", "Starlark .bzl file with a {source module: target package} dict constant to merge into the mappings")
+	formatWithLibraryFlag := flag.Bool("format-with-library", false, "Format BUILD files in-process instead of running buildifier (binary must be built with -tags buildtools)")
+	dryRunFlag := flag.Bool("dry-run", false, "Print the files and BUILD files a migration would write without writing them")
+	stateFileFlag := flag.String("state-file", "", "JSON file recording each copied file, so a rerun skips files whose source is unchanged")
+	gitStageFlag := flag.Bool("git-stage", false, "git add every file a successful migration writes (and git rm --cached the sources with -remove-source)")
+	pruneEmptyFlag := flag.Bool("prune-empty", false, "After migrating, remove BUILD files under -target whose srcs glob matches no files, and the directories left empty (alone, prune without migrating)")
+	removeSourceFlag := flag.Bool("remove-source", false, "Delete a module's migrated source files once its migration succeeds and each copy's checksum is verified")
+	overwriteFlag := flag.String("overwrite", "", "What to do with destination files that exist with different content: force, skip or ask (default: abort)")
+	noRollbackFlag := flag.Bool("no-rollback", false, "Leave the files of a failed migration in place instead of undoing it")
+	parallelismFlag := flag.Int("parallelism", defaultParallelism, "Number of files to copy and rewrite at once")
+	rulesFlag := flag.String("rules", "", "YAML file whose rules list replaces the built-in dependency rules (same format as the analyzer config)")
+	mappingsConstFlag := flag.String("mappings-const", "PACKAGE_MAPPINGS", "Name of the dict constant to read from -mappings-bzl")
+	cacheDirFlag := flag.String("cache-dir", "", "Directory for cached Bazel query results (default ~/.cache/umbra/queries)")
+	cacheTTLFlag := flag.Duration("cache-ttl", 0, "Reuse cached Bazel query results younger than this (e.g., 10m); 0 disables the cache")
+	bazelBinaryFlag := flag.String("bazel-binary", "", "Binary to run Bazel queries with (default bazelisk, or bazel if bazelisk is not on the PATH)")
+	noCacheFlag := flag.Bool("no-cache", false, "Run every Bazel query, even one already run in this invocation (for debugging)")
+	strictFlag := flag.Bool("strict", false, "Treat all warnings as errors (recommended for CI)")
+	slackWebhookFlag := flag.String("slack-webhook", "", "Post the outcome of the migration, or a summary of a -tier or -all migration, to this Slack incoming webhook URL")
+	reportURLFlag := flag.String("report-url", "", "Link to an HTML report to include in the Slack notification")
+	exportBzlFlag := flag.String("export-bzl", "", "Export the package mappings as a Starlark .bzl constants file and exit")
+	spmManifestFlag := flag.String("spm-manifest", "", "Write a Package.swift for the package given by -spm-target to this path")
+	signManifestFlag := flag.String("sign-manifest", "", "Sign a manifest of the migrated modules, including those of -tier and -all, with this Ed25519 private key (PEM)")
+	manifestOutputFlag := flag.String("manifest-output", "migration_manifest.json", "Where to write the signed migration manifest")
+	verifyManifestFlag := flag.String("verify-manifest", "", "Verify the signature of this migration manifest and exit")
+	publicKeyFlag := flag.String("public-key", "", "Ed25519 public key (PEM) used with -verify-manifest")
+	spmTargetFlag := flag.String("spm-target", "", "Package to generate a Package.swift for (e.g., UmbraCoreTypes)")
 
 	flag.Parse()
 
-	if *moduleFlag == "" || *destinationFlag == "" {
-		log.Fatal("Required flags: -module and -destination")
+	// Verify a previously signed manifest instead of migrating if requested
+	if *verifyManifestFlag != "" {
+		if *publicKeyFlag == "" {
+			log.Fatal("Required flags: -public-key with -verify-manifest")
+		}
+		manifest, err := LoadManifest(*verifyManifestFlag)
+		if err != nil {
+			log.Fatalf("Error loading manifest: %v", err)
+		}
+		if err := VerifyManifest(manifest, *publicKeyFlag); err != nil {
+			log.Fatalf("❌ Manifest verification failed: %v", err)
+		}
+		fmt.Printf("✅ Manifest %s is authentic (%d modules, commit %s)\n", *verifyManifestFlag, len(manifest.ModuleResults), manifest.WorkspaceCommit)
+		return
 	}
 
 	// Create absolute paths
-	sourceDir := *sourceFlag
-	if !filepath.IsAbs(sourceDir) {
-		var err error
-		sourceDir, err = filepath.Abs(sourceDir)
-		if err != nil {
-			log.Fatalf("Error getting absolute path: %v", err)
-		}
+	sourceDirs, err := absoluteSourceDirs(sourceFlag)
+	if err != nil {
+		log.Fatalf("Error resolving source directories: %v", err)
 	}
 
 	targetDir := *targetFlag
@@ -607,9 +988,17 @@ func main() {
 	}
 
 	workspaceRoot := *workspaceFlag
-	if workspaceRoot == "" {
+	if workspaceRoot == "" && *workspaceAutoDetectFlag {
+		cwd, err := os.Getwd()
+		if err != nil {
+			log.Fatalf("Error getting current directory: %v", err)
+		}
+		if workspaceRoot, err = workspace.FindWorkspaceRoot(cwd); err != nil {
+			log.Fatalf("Error detecting workspace root: %v; pass -workspace or -workspace-auto-detect=false", err)
+		}
+	} else if workspaceRoot == "" {
 		// Use parent of source directory as default workspace root
-		workspaceRoot = filepath.Dir(sourceDir)
+		workspaceRoot = filepath.Dir(sourceDirs[0])
 	} else if !filepath.IsAbs(workspaceRoot) {
 		var err error
 		workspaceRoot, err = filepath.Abs(workspaceRoot)
@@ -618,13 +1007,286 @@ func main() {
 		}
 	}
 
-	migrator := NewMigrationHelper(sourceDir, targetDir, workspaceRoot)
-	success, err := migrator.MigrateModule(*moduleFlag, *destinationFlag, *skipDepsFlag)
+	migrator := NewMigrationHelper(sourceDirs, targetDir, workspaceRoot)
+	migrator.Strict = *strictFlag
+	migrator.IncludeObjC = *includeObjCFlag
+	fileExtensions, err := parseFileExtensions(*fileExtensionsFlag)
 	if err != nil {
-		log.Fatalf("Error migrating module: %v", err)
+		log.Fatalf("Error parsing -file-extensions: %v", err)
+	}
+	migrator.FileExtensions = fileExtensions
+	migrator.MigrateResources = *migrateResourcesFlag
+	migrator.DryRun = *dryRunFlag
+	migrator.Parallelism = *parallelismFlag
+	migrator.NoRollback = *noRollbackFlag
+	if *overwriteFlag != "" && !contains(overwriteModes, *overwriteFlag) {
+		log.Fatalf("Invalid -overwrite %q: must be %s", *overwriteFlag, strings.Join(overwriteModes, ", "))
+	}
+	migrator.Overwrite = *overwriteFlag
+	migrator.GitStage = *gitStageFlag
+	migrator.RemoveSource = *removeSourceFlag
+	migrator.VerifyAPI = *verifyAPIFlag
+	migrator.StateFile = *stateFileFlag
+	migrator.NoCache = *noCacheFlag
+	querycache.BazelBinary = *bazelBinaryFlag
+	migrator.FormatWithLibrary = *formatWithLibraryFlag
+	if *formatWithLibraryFlag && !buildifierLibraryAvailable {
+		log.Printf("Warning: -format-with-library needs a binary built with -tags buildtools; running buildifier instead")
+	}
+
+	if *cacheTTLFlag > 0 {
+		cache, err := querycache.New(*cacheDirFlag, *cacheTTLFlag)
+		if err != nil {
+			log.Fatalf("Error setting up query cache: %v", err)
+		}
+		migrator.QueryCache = cache
+	}
+
+	if *asOfFlag != "" {
+		asOf, err := time.Parse("2006-01-02", *asOfFlag)
+		if err != nil {
+			log.Fatalf("Invalid --as-of date %q: expected YYYY-MM-DD", *asOfFlag)
+		}
+		migrator.AsOf = asOf
+		fmt.Printf("Evaluating package mappings as of %s\n", *asOfFlag)
+	}
+
+	// The config file comes first, so the mapping and rule flags below can override it
+	if err := migrator.loadConfigFile(*configFlag, *configModeFlag); err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+	if *configFlag != "" {
+		fmt.Printf("Loaded package mappings and dependency rules from %s (%s)\n", *configFlag, *configModeFlag)
+	}
+
+	if *mappingsBzlFlag != "" {
+		mappings, err := ParseBzlMappings(*mappingsBzlFlag, *mappingsConstFlag)
+		if err != nil {
+			log.Fatalf("Error loading mappings: %v", err)
+		}
+		fmt.Printf("Loaded %d mappings from %s in %s\n", len(mappings), *mappingsConstFlag, *mappingsBzlFlag)
+		migrator.MergeMappings(mappings)
 	}
 
-	if !success {
-		os.Exit(1)
+	if *mappingsFileFlag != "" {
+		mappings, err := LoadMappingsFile(*mappingsFileFlag)
+		if err != nil {
+			log.Fatalf("Error loading mappings: %v", err)
+		}
+		migrator.MergeMappings(mappings)
+	}
+
+	if *rulesFlag != "" {
+		if err := migrator.ValidDeps.LoadFromFile(*rulesFlag); err != nil {
+			log.Fatalf("Error loading dependency rules: %v", err)
+		}
+		fmt.Printf("Loaded %d dependency rules from %s\n", len(migrator.ValidDeps), *rulesFlag)
+	}
+
+	// Ambiguous mappings are fatal in strict mode
+	if migrator.Strict && len(migrator.Conflicts) > 0 {
+		log.Fatalf("Found %d conflicting package mappings (strict mode)", len(migrator.Conflicts))
+	}
+
+	// Export the mapping table for Bazel macros if requested
+	if *exportBzlFlag != "" {
+		if err := migrator.ExportMappingsAsBzl(*exportBzlFlag); err != nil {
+			log.Fatalf("Error exporting mappings: %v", err)
+		}
+		return
+	}
+
+	// Generate a Swift Package Manager manifest instead of migrating if requested
+	if *spmManifestFlag != "" {
+		if *spmTargetFlag == "" {
+			log.Fatal("Required flags: -spm-target with -spm-manifest")
+		}
+		if err := migrator.GenerateSPMManifest(*spmTargetFlag, *spmManifestFlag); err != nil {
+			log.Fatalf("Error generating Package.swift: %v", err)
+		}
+		return
+	}
+
+	// Check for files that mix old and new module imports instead of migrating if requested
+	if *checkImportsFlag {
+		files, err := migrator.CheckImportConsistency(targetDir)
+		if err != nil {
+			log.Fatalf("Error checking imports: %v", err)
+		}
+		if len(files) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Write the progress tracker once the migration, if any, is done
+	writeProgressReport := func() {
+		if *progressReportFlag == "" || migrator.DryRun {
+			return
+		}
+		if err := migrator.GenerateProgressReport(*progressReportFlag); err != nil {
+			log.Fatalf("Error generating progress report: %v", err)
+		}
+	}
+	if *progressReportFlag != "" && !*allFlag && *tierFlag == "" && *moduleFlag == "" {
+		writeProgressReport()
+		return
+	}
+
+	// Remove the BUILD files that partial migrations left without sources once the migration, if any, is done
+	pruneEmptyBuilds := func() {
+		if !*pruneEmptyFlag {
+			return
+		}
+		removed, err := migrator.PruneEmptyBuildFiles(targetDir)
+		if err != nil {
+			log.Fatalf("Error pruning empty BUILD files: %v", err)
+		}
+		if !migrator.DryRun {
+			fmt.Printf("Pruned %d empty BUILD files and directories under %s\n", len(removed), targetDir)
+		}
+	}
+	if *pruneEmptyFlag && !*allFlag && *tierFlag == "" && *moduleFlag == "" {
+		pruneEmptyBuilds()
+		return
+	}
+
+	// Sign a manifest of the migration results for the audit trail
+	signManifest := func() {
+		if *signManifestFlag == "" {
+			return
+		}
+		manifest, err := GenerateManifest(migrator.Results, *signManifestFlag, workspaceRoot)
+		if err != nil {
+			log.Fatalf("Error generating manifest: %v", err)
+		}
+		if err := SaveManifest(*manifestOutputFlag, manifest); err != nil {
+			log.Fatalf("Error saving manifest: %v", err)
+		}
+		fmt.Printf("Signed migration manifest written to %s\n", *manifestOutputFlag)
+	}
+
+	// Tell the team channel how the migration went
+	notifySlack := func(success bool) {
+		if *slackWebhookFlag == "" || len(migrator.Results) == 0 {
+			return
+		}
+		var payload notify.SlackPayload
+		if len(migrator.Results) == 1 {
+			result := migrator.Results[0]
+			result.Success = success
+			payload = migrationSlackPayload(result)
+		} else {
+			payload = batchSlackPayload(migrator.Results)
+		}
+		if err := notify.NewSlackNotifier().Notify(*slackWebhookFlag, payload.WithReportLink(*reportURLFlag)); err != nil {
+			migrator.warn("Error sending Slack notification: %v", err)
+		}
+	}
+
+	// Every migration mode ends here, so the outputs above cover single modules, tiers and -all alike
+	finish := func(success bool) {
+		signManifest()
+		notifySlack(success)
+		writeProgressReport()
+		pruneEmptyBuilds()
+		if !success {
+			os.Exit(1)
+		}
+	}
+
+	// Migrate every unmigrated module if requested
+	if *allFlag {
+		if *tierFlag != "" || *moduleFlag != "" {
+			log.Fatal("-all cannot be combined with -tier or -module")
+		}
+		report, err := migrator.MigrateAll(*skipDepsFlag)
+		if err != nil {
+			log.Fatalf("Error migrating modules: %v", err)
+		}
+		if migrator.DryRun {
+			return
+		}
+		fmt.Printf("\nMigrated all modules: %d succeeded, %d failed, %d skipped\n", report.Succeeded, report.Failed, report.Skipped)
+		if *reportFlag != "" {
+			if err := report.Save(*reportFlag); err != nil {
+				log.Fatalf("Error saving report: %v", err)
+			}
+			fmt.Printf("Migration report written to %s\n", *reportFlag)
+		}
+		finish(report.Failed == 0)
+		return
+	}
+
+	// Migrate a whole tier of modules if requested
+	if *tierFlag != "" {
+		report, err := migrator.MigratePackageTier(*tierFlag, *skipDepsFlag)
+		if err != nil {
+			log.Fatalf("Error migrating tier: %v", err)
+		}
+		fmt.Printf("\nMigrated %s: %d succeeded, %d failed, %d skipped\n", *tierFlag, report.Succeeded, report.Failed, report.Skipped)
+		if *reportFlag != "" {
+			if err := report.Save(*reportFlag); err != nil {
+				log.Fatalf("Error saving report: %v", err)
+			}
+			fmt.Printf("Migration report written to %s\n", *reportFlag)
+		}
+		finish(report.Failed == 0)
+		return
+	}
+
+	if *moduleFlag == "" || *destinationFlag == "" {
+		log.Fatal("Required flags: -module and -destination")
+	}
+
+	// Check that a completed migration changed nothing but imports
+	if *compareSourcesFlag {
+		diffs, err := migrator.CompareSources(*moduleFlag, *destinationFlag)
+		if err != nil {
+			log.Fatalf("Error comparing sources: %v", err)
+		}
+		for _, diff := range diffs {
+			fmt.Printf("❌ %s differs from %s:\n%s\n", diff.TargetPath, diff.SourcePath, diff.Diff)
+		}
+		if len(diffs) > 0 {
+			fmt.Printf("❌ Found %d files with changes other than imports\n", len(diffs))
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Migrated sources of %s only differ in imports\n", *moduleFlag)
+		return
+	}
+
+	success, err := migrator.MigrateModule(*moduleFlag, *destinationFlag, *skipDepsFlag)
+
+	// Compare the public API of the migrated module with its source
+	if *verifyAPIFlag && err == nil {
+		unchanged, apiErr := migrator.verifyPublicAPI(*moduleFlag, *destinationFlag)
+		if apiErr != nil {
+			log.Fatalf("Error verifying public API: %v", apiErr)
+		}
+		success = success && unchanged
+	}
+
+	// Check for non-public symbols now shared with files from other source modules
+	if *checkAccessFlag && err == nil {
+		warnings, accessErr := migrator.CheckAccessLevels(*destinationFlag)
+		if accessErr != nil {
+			log.Fatalf("Error checking access levels: %v", accessErr)
+		}
+		for _, warning := range warnings {
+			migrator.warn("%s defined in %s is used in %s; consider making it %s",
+				warning.Symbol, warning.DefinedIn, warning.UsedIn, warning.SuggestedAccessLevel)
+		}
+		if len(warnings) == 0 {
+			fmt.Println("✅ No access level issues found")
+		} else if migrator.Strict {
+			success = false
+		}
+	}
+
+	if err != nil {
+		log.Printf("Error migrating module: %v", err)
+		success = false
 	}
+	finish(success)
 }
@@ -0,0 +1,749 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/alphapb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUpdateImports(t *testing.T) {
+	mapping := map[string]string{
+		"Foo":         "UmbraFoo",
+		"CoreDTOs":    "UmbraCoreDTOs",
+		"SameName":    "SameName",
+		"SecurityKit": "UmbraSecurityKit",
+	}
+
+	tests := []struct {
+		name         string
+		content      string
+		mapping      map[string]string
+		expected     string
+		replacements int
+	}{
+		{
+			name:         "simple import",
+			content:      "import Foundation\nimport Foo\n\nstruct A {}\n",
+			mapping:      mapping,
+			expected:     "import Foundation\nimport UmbraFoo\n\nstruct A {}\n",
+			replacements: 1,
+		},
+		{
+			name:         "testable import",
+			content:      "@testable import CoreDTOs\n",
+			mapping:      mapping,
+			expected:     "@testable import UmbraCoreDTOs\n",
+			replacements: 1,
+		},
+		{
+			name:         "implementation-only import",
+			content:      "@_implementationOnly import SecurityKit\n",
+			mapping:      mapping,
+			expected:     "@_implementationOnly import UmbraSecurityKit\n",
+			replacements: 1,
+		},
+		{
+			name:         "several known attributes",
+			content:      "@_implementationOnly @preconcurrency import SecurityKit\n",
+			mapping:      mapping,
+			expected:     "@_implementationOnly @preconcurrency import UmbraSecurityKit\n",
+			replacements: 1,
+		},
+		{
+			name:         "imports already in the new form",
+			content:      "import UmbraFoo\n@testable import UmbraCoreDTOs\n@_implementationOnly import UmbraSecurityKit\n",
+			mapping:      mapping,
+			expected:     "import UmbraFoo\n@testable import UmbraCoreDTOs\n@_implementationOnly import UmbraSecurityKit\n",
+			replacements: 0,
+		},
+		{
+			name:         "old and new forms side by side",
+			content:      "import UmbraFoo\nimport Foo\n@testable import UmbraCoreDTOs\n@testable import CoreDTOs\n",
+			mapping:      mapping,
+			expected:     "import UmbraFoo\nimport UmbraFoo\n@testable import UmbraCoreDTOs\n@testable import UmbraCoreDTOs\n",
+			replacements: 2,
+		},
+		{
+			name:         "each import is rewritten once",
+			content:      "import OldKit\n@testable import OldKit\n",
+			mapping:      map[string]string{"OldKit": "MidKit", "MidKit": "NewKit"},
+			expected:     "import MidKit\n@testable import MidKit\n",
+			replacements: 2,
+		},
+		{
+			name:         "unknown attribute is left alone",
+			content:      "@_spi(Internal) import Foo\n",
+			mapping:      mapping,
+			expected:     "@_spi(Internal) import Foo\n",
+			replacements: 0,
+		},
+		{
+			name:         "sub-module import",
+			content:      "import Foo.Bar\nimport struct Foo.Baz\n",
+			mapping:      mapping,
+			expected:     "import UmbraFoo.Bar\nimport struct UmbraFoo.Baz\n",
+			replacements: 2,
+		},
+		{
+			name:         "import inside #if block",
+			content:      "#if canImport(Foo)\n    import Foo\n#else\nimport CoreDTOs\n#endif\n",
+			mapping:      mapping,
+			expected:     "#if canImport(Foo)\n    import UmbraFoo\n#else\nimport UmbraCoreDTOs\n#endif\n",
+			replacements: 2,
+		},
+		{
+			name:         "multi-line comment with import-like lines",
+			content:      "/*\n import Foo\n /* nested */\n import CoreDTOs\n*/\nimport Foo\n",
+			mapping:      mapping,
+			expected:     "/*\n import Foo\n /* nested */\n import CoreDTOs\n*/\nimport UmbraFoo\n",
+			replacements: 1,
+		},
+		{
+			name:         "line comment does not open a block comment",
+			content:      "// see /* below\nimport Foo\n",
+			mapping:      mapping,
+			expected:     "// see /* below\nimport UmbraFoo\n",
+			replacements: 1,
+		},
+		{
+			name:         "module name that is a prefix of another",
+			content:      "import FooBar\nimport Foo\nimport CoreDTOsExtras\n",
+			mapping:      mapping,
+			expected:     "import FooBar\nimport UmbraFoo\nimport CoreDTOsExtras\n",
+			replacements: 1,
+		},
+		{
+			name:         "module mapped to its own name",
+			content:      "import SameName\n",
+			mapping:      mapping,
+			expected:     "import SameName\n",
+			replacements: 0,
+		},
+		{
+			name:         "trailing comment is kept",
+			content:      "import Foo // needed for A\n",
+			mapping:      mapping,
+			expected:     "import UmbraFoo // needed for A\n",
+			replacements: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helper := NewMigrationHelper(nil, t.TempDir(), "")
+
+			_, changes := helper.importRewriter(test.mapping).RewriteAll(test.content)
+			if len(changes) != test.replacements {
+				t.Errorf("got %d replacements, want %d: %v", len(changes), test.replacements, changes)
+			}
+
+			path := filepath.Join(t.TempDir(), "File.swift")
+			if err := ioutil.WriteFile(path, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := helper.UpdateImports(path, test.mapping); err != nil {
+				t.Fatalf("UpdateImports: %v", err)
+			}
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != test.expected {
+				t.Errorf("got content:\n%s\nwant:\n%s", content, test.expected)
+			}
+		})
+	}
+}
+
+func TestCheckMappings(t *testing.T) {
+	switchover := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	coreDTOs := PackageMapping{SourceModule: "CoreDTOs", TargetPackage: "UmbraCoreTypes/CoreDTOs"}
+	phasedOut := coreDTOs
+	phasedOut.EffectiveUntil = &switchover
+	phasedIn := PackageMapping{SourceModule: "CoreDTOs", TargetPackage: "UmbraCoreTypes/DTOs", EffectiveFrom: &switchover}
+
+	tests := []struct {
+		name     string
+		mappings []PackageMapping
+		expected string // Substring of the error; empty if none is expected
+	}{
+		{name: "distinct", mappings: []PackageMapping{coreDTOs, {SourceModule: "SecurityTypes", TargetPackage: "UmbraCoreTypes/SecurityTypes"}}},
+		{name: "plain duplicate", mappings: []PackageMapping{coreDTOs, coreDTOs}, expected: "duplicate SourceModule in mappings.json: CoreDTOs"},
+		{name: "phased", mappings: []PackageMapping{phasedOut, phasedIn}},
+		{name: "phased from the same time", mappings: []PackageMapping{phasedIn, phasedIn}, expected: "duplicate SourceModule"},
+		{name: "missing target", mappings: []PackageMapping{{SourceModule: "CoreDTOs"}}, expected: "mapping 1 in mappings.json must set both"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkMappings(test.mappings, "mappings.json")
+			if test.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("got error %v, want one containing %q", err, test.expected)
+			}
+		})
+	}
+
+	t.Run("mappings file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mappings.json")
+		content := `[{"SourceModule": "CoreDTOs", "TargetPackage": "UmbraCoreTypes/CoreDTOs"},
+			{"SourceModule": "CoreDTOs", "TargetPackage": "UmbraCoreTypes/DTOs"}]`
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadMappingsFile(path); err == nil || !strings.Contains(err.Error(), "duplicate SourceModule in "+path+": CoreDTOs") {
+			t.Errorf("got error %v, want a duplicate SourceModule error", err)
+		}
+	})
+
+	t.Run("default mappings", func(t *testing.T) {
+		if duplicates := duplicateSourceModules(NewMigrationHelper(nil, "", "").DefaultMappings); len(duplicates) > 0 {
+			t.Errorf("default mappings map %v more than once", duplicates)
+		}
+	})
+}
+
+func TestCheckDefaultMappingsFatal(t *testing.T) {
+	// log.Fatalf exits, so the check runs in a child test process
+	if os.Getenv("CHECK_DEFAULT_MAPPINGS_CHILD") == "1" {
+		mapping := PackageMapping{SourceModule: "CoreDTOs", TargetPackage: "UmbraCoreTypes/CoreDTOs"}
+		checkDefaultMappings([]PackageMapping{mapping, mapping})
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCheckDefaultMappingsFatal$")
+	cmd.Env = append(os.Environ(), "CHECK_DEFAULT_MAPPINGS_CHILD=1")
+	output, err := cmd.CombinedOutput()
+	if _, exited := err.(*exec.ExitError); !exited {
+		t.Fatalf("got error %v, want the child to exit with a failure; output:\n%s", err, output)
+	}
+	if !strings.Contains(string(output), "Duplicate SourceModule in default package mappings: CoreDTOs") {
+		t.Errorf("child did not report the duplicate, output:\n%s", output)
+	}
+}
+
+// fakeBuildifierFailingFor puts a buildifier on the PATH that fails to format BUILD files whose path contains
+// module, so migrating module warns and migrating any other module does not
+func fakeBuildifierFailingFor(t *testing.T, module string) {
+	bin := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\ncase \"$1\" in *%s*) exit 1;; esac\nexit 0\n", module)
+	if err := ioutil.WriteFile(filepath.Join(bin, "buildifier"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestMigrateModuleStrictCountsOwnWarnings(t *testing.T) {
+	root := t.TempDir()
+	sourcesDir := filepath.Join(root, "Sources")
+	for _, path := range []string{"CoreDTOs/BackupDTO.swift", "ErrorHandlingInterfaces/ErrorHandler.swift"} {
+		path = filepath.Join(sourcesDir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte("struct Placeholder {}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fakeBuildifierFailingFor(t, "CoreDTOs")
+
+	helper := NewMigrationHelper([]string{sourcesDir}, filepath.Join(root, "packages"), root)
+	helper.Strict = true
+	if _, err := helper.MigrateModule("CoreDTOs", "UmbraCoreTypes/CoreDTOs", true); err == nil || !strings.Contains(err.Error(), "1 warnings treated as errors") {
+		t.Fatalf("MigrateModule(CoreDTOs): got error %v, want its buildifier warning treated as an error", err)
+	}
+
+	// The next module has no warnings of its own
+	if success, err := helper.MigrateModule("ErrorHandlingInterfaces", "UmbraErrorKit/Interfaces", true); err != nil || !success {
+		t.Errorf("MigrateModule(ErrorHandlingInterfaces): success %v, error %v; want CoreDTOs' warning not to count", success, err)
+	}
+}
+
+func TestDetectRulesDrift(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "dependency_rules.yaml")
+	tests := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		// Without config rules the analyzer applies its defaults, which must be the helper's defaults
+		{name: "defaults", config: "strict: false\n"},
+		{name: "config adds a rule", config: "rules:\n  - source: UmbraUtils\n    target: UmbraErrorKit\n",
+			expected: []string{"rule UmbraUtils -> UmbraErrorKit is only in the analyzer rules with " + configPath}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ioutil.WriteFile(configPath, []byte(test.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			findings, err := NewMigrationHelper(nil, "", "").DetectRulesDrift(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.expected == nil {
+				test.expected = []string{}
+			}
+			if !reflect.DeepEqual(findings, test.expected) {
+				t.Errorf("got findings %v, want %v", findings, test.expected)
+			}
+		})
+	}
+}
+
+func TestTopologicalMigrationOrder(t *testing.T) {
+	newHelper := func(moduleDeps map[string][]string) *MigrationHelper {
+		helper := NewMigrationHelper(nil, "", "")
+		helper.DefaultMappings = []PackageMapping{
+			{SourceModule: "LoggingWrapper", TargetPackage: "UmbraImplementations/LoggingImpl", ImportModuleAs: "LoggingImpl"},
+			{SourceModule: "SecurityInterfaces", TargetPackage: "UmbraInterfaces/SecurityInterfaces", ImportModuleAs: "SecurityInterfaces"},
+			{SourceModule: "KeyManagementTypes", TargetPackage: "UmbraCoreTypes/KeyManagementTypes", ImportModuleAs: "KeyManagementTypes"},
+			{SourceModule: "CoreDTOs", TargetPackage: "UmbraCoreTypes/CoreDTOs", ImportModuleAs: "CoreDTOs"},
+		}
+		helper.ModuleDependencies = moduleDeps
+		return helper
+	}
+
+	tests := []struct {
+		name       string
+		moduleDeps map[string][]string
+		expected   []string
+		cycle      []string // Modules the error must name
+	}{
+		{
+			name:       "package rules only",
+			moduleDeps: map[string][]string{},
+			expected:   []string{"KeyManagementTypes", "CoreDTOs", "SecurityInterfaces", "LoggingWrapper"},
+		},
+		{
+			name:       "module dependency within a package",
+			moduleDeps: map[string][]string{"KeyManagementTypes": {"CoreDTOs", "Foundation"}},
+			expected:   []string{"CoreDTOs", "KeyManagementTypes", "SecurityInterfaces", "LoggingWrapper"},
+		},
+		{
+			name:       "cycle",
+			moduleDeps: map[string][]string{"CoreDTOs": {"KeyManagementTypes"}, "KeyManagementTypes": {"CoreDTOs"}},
+			cycle:      []string{"CoreDTOs", "KeyManagementTypes"},
+		},
+		{
+			name:       "dependency against the package rules",
+			moduleDeps: map[string][]string{"CoreDTOs": {"LoggingWrapper"}},
+			cycle:      []string{"CoreDTOs", "LoggingWrapper"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ordered, err := newHelper(test.moduleDeps).TopologicalMigrationOrder()
+			if test.cycle != nil {
+				if err == nil {
+					t.Fatalf("expected a cycle error, got order %v", ordered)
+				}
+				for _, module := range test.cycle {
+					if !strings.Contains(err.Error(), module) {
+						t.Errorf("error %q does not name %s", err, module)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			modules := []string{}
+			for _, mapping := range ordered {
+				modules = append(modules, mapping.SourceModule)
+			}
+			if strings.Join(modules, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("got order %v, want %v", modules, test.expected)
+			}
+		})
+	}
+}
+
+func TestUpdateObjCImports(t *testing.T) {
+	headerMapping := map[string]string{"ObjCBridgingTypes": "ObjCBridging", "CoreDTOs": "CoreDTOs"}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "module import",
+			content:  "#import <ObjCBridgingTypes/Bridge.h>\n",
+			expected: "#import <ObjCBridging/Bridge.h>\n",
+		},
+		{
+			name:     "quoted import with a module prefix",
+			content:  "#import \"ObjCBridgingTypes/Internal/Bridge.h\"\n",
+			expected: "#import \"ObjCBridging/Internal/Bridge.h\"\n",
+		},
+		{
+			name:     "quoted import in the same module",
+			content:  "#import \"ObjCBridgingTypes.h\"\n#import \"Bridge.h\"\n",
+			expected: "#import \"ObjCBridgingTypes.h\"\n#import \"Bridge.h\"\n",
+		},
+		{
+			name:     "unmapped and unchanged modules",
+			content:  "#import <Foundation/Foundation.h>\n#import <CoreDTOs/CoreDTOs.h>\n",
+			expected: "#import <Foundation/Foundation.h>\n#import <CoreDTOs/CoreDTOs.h>\n",
+		},
+		{
+			name:     "mismatched delimiters are left alone",
+			content:  "#import <ObjCBridgingTypes/Bridge.h\"\n",
+			expected: "#import <ObjCBridgingTypes/Bridge.h\"\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "Bridge.m")
+			if err := ioutil.WriteFile(path, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := NewMigrationHelper(nil, t.TempDir(), "").UpdateObjCImports(path, headerMapping); err != nil {
+				t.Fatalf("UpdateObjCImports: %v", err)
+			}
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != test.expected {
+				t.Errorf("got content:\n%s\nwant:\n%s", content, test.expected)
+			}
+		})
+	}
+}
+
+func TestRunBazelQueryCache(t *testing.T) {
+	spawned := []string{} // Queries that reached the executor, which spawns bazelisk
+	helper := NewMigrationHelper(nil, "", "")
+	helper.Executor = func(workspaceRoot string, args ...string) ([]byte, error) {
+		spawned = append(spawned, args[len(args)-1])
+		return []byte(`{"target": [{"name": "//Sources/CoreDTOs:CoreDTOs"}]}`), nil
+	}
+
+	for _, query := range []string{"deps(//Sources/CoreDTOs:*)", "deps(//Sources/CoreDTOs:*)", "deps(//Sources/Other:*)"} {
+		result, err := helper.RunBazelQuery(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Target) != 1 {
+			t.Errorf("%s: got %d targets, want 1", query, len(result.Target))
+		}
+	}
+	if expected := []string{"deps(//Sources/CoreDTOs:*)", "deps(//Sources/Other:*)"}; !reflect.DeepEqual(spawned, expected) {
+		t.Errorf("spawned bazelisk for %v, want %v", spawned, expected)
+	}
+	if hits, misses := helper.CacheStats(); hits != 1 || misses != 2 {
+		t.Errorf("got %d hits and %d misses, want 1 and 2", hits, misses)
+	}
+
+	helper.NoCache = true
+	if _, err := helper.RunBazelQuery("deps(//Sources/CoreDTOs:*)"); err != nil {
+		t.Fatal(err)
+	}
+	if len(spawned) != 3 {
+		t.Errorf("spawned bazelisk %d times, want a third time with NoCache", len(spawned))
+	}
+}
+
+func TestCreateOrUpdateBuildFileNestedSubpackage(t *testing.T) {
+	targetDir := t.TempDir()
+	sourcesDir := filepath.Join(targetDir, "UmbraInterfaces", "Sources")
+	for _, path := range []string{"SecurityInterfaces/SecurityProvider.swift", "SecurityInterfaces/Models/Keys/KeyModel.swift"} {
+		path = filepath.Join(sourcesDir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte("struct Placeholder {}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := NewMigrationHelper(nil, targetDir, "").CreateOrUpdateBuildFile("UmbraInterfaces", "SecurityInterfaces/Models/Keys"); err != nil {
+		t.Fatalf("CreateOrUpdateBuildFile: %v", err)
+	}
+
+	// Each level has a BUILD file whose target is named after its directory and depends on the level below
+	expected := map[string][]string{
+		"SecurityInterfaces/Models/Keys": {`umbra_swift_library(`, `name = "Keys"`},
+		"SecurityInterfaces/Models":      {`filegroup(`, `name = "Models"`, `"//packages/UmbraInterfaces/Sources/SecurityInterfaces/Models/Keys"`},
+		"SecurityInterfaces":             {`umbra_swift_library(`, `name = "SecurityInterfaces"`, `"//packages/UmbraInterfaces/Sources/SecurityInterfaces/Models"`},
+	}
+	for subpackage, fragments := range expected {
+		content, err := ioutil.ReadFile(filepath.Join(sourcesDir, subpackage, "BUILD.bazel"))
+		if err != nil {
+			t.Fatalf("%s: %v", subpackage, err)
+		}
+		for _, fragment := range fragments {
+			if !strings.Contains(string(content), fragment) {
+				t.Errorf("%s/BUILD.bazel does not contain %s:\n%s", subpackage, fragment, content)
+			}
+		}
+	}
+	if fileExists(filepath.Join(targetDir, "UmbraInterfaces", "Sources", "BUILD.bazel")) {
+		t.Error("created a BUILD file above the subpackage")
+	}
+}
+
+func TestCreateOrUpdateBuildFileKeepsIntermediateBuildFile(t *testing.T) {
+	targetDir := t.TempDir()
+	sourcesDir := filepath.Join(targetDir, "UmbraCoreTypes", "Sources", "SecurityInterfaces")
+	if err := os.MkdirAll(filepath.Join(sourcesDir, "Models"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(sourcesDir, "Models", "KeyModel.swift"), []byte("struct KeyModel {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	handWritten := `load("//tools/swift:build_rules.bzl", "umbra_swift_library")
+
+umbra_swift_library(
+    name = "SecurityInterfaces",
+    srcs = glob(["*.swift"]),
+    deps = [
+        "//packages/UmbraCoreTypes/Sources/UmbraErrors",
+        "//packages/UmbraCoreTypes/Sources/CoreSecurityTypes",
+        "//packages/UmbraCoreTypes/Sources/DomainSecurityTypes",
+        # Settings persistence
+        "//packages/UmbraCoreTypes/Sources/UserDefaults",
+        "//packages/UmbraCoreTypes/Sources/CryptoTypes",
+    ],
+    visibility = ["//visibility:public"],
+)
+`
+	buildPath := filepath.Join(sourcesDir, "BUILD.bazel")
+	if err := ioutil.WriteFile(buildPath, []byte(handWritten), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMigrationHelper(nil, targetDir, "")
+	for i := 0; i < 2; i++ {
+		if err := m.CreateOrUpdateBuildFile("UmbraCoreTypes", "SecurityInterfaces/Models"); err != nil {
+			t.Fatalf("CreateOrUpdateBuildFile: %v", err)
+		}
+	}
+
+	content, err := ioutil.ReadFile(buildPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fragment := range []string{
+		`srcs = glob(["*.swift"])`,
+		`"//packages/UmbraCoreTypes/Sources/UmbraErrors"`,
+		`"//packages/UmbraCoreTypes/Sources/CoreSecurityTypes"`,
+		`"//packages/UmbraCoreTypes/Sources/DomainSecurityTypes"`,
+		`# Settings persistence`,
+		`"//packages/UmbraCoreTypes/Sources/UserDefaults"`,
+		`"//packages/UmbraCoreTypes/Sources/CryptoTypes"`,
+		`visibility = ["//visibility:public"]`,
+	} {
+		if !strings.Contains(string(content), fragment) {
+			t.Errorf("BUILD.bazel lost %s:\n%s", fragment, content)
+		}
+	}
+	if n := strings.Count(string(content), `"//packages/UmbraCoreTypes/Sources/SecurityInterfaces/Models"`); n != 1 {
+		t.Errorf("BUILD.bazel lists the Models subpackage %d times, want 1:\n%s", n, content)
+	}
+}
+
+func TestAddBuildLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		label    string
+		expected string
+		changed  bool
+		wantErr  bool
+	}{
+		{
+			name:     "appends to deps",
+			content:  "swift_library(\n    name = \"A\",\n    deps = [\n        \"//packages/B\",\n    ],\n)\n",
+			label:    "//packages/A/Sources/A/C",
+			expected: "swift_library(\n    name = \"A\",\n    deps = [\n        \"//packages/B\",\n        \"//packages/A/Sources/A/C\",\n    ],\n)\n",
+			changed:  true,
+		},
+		{
+			name:     "appends to srcs without deps",
+			content:  "filegroup(\n    name = \"A\",\n    srcs = [\"//packages/B\"],\n)\n",
+			label:    "//packages/C",
+			expected: "filegroup(\n    name = \"A\",\n    srcs = [\"//packages/B\",\n        \"//packages/C\",\n    ],\n)\n",
+			changed:  true,
+		},
+		{
+			name:     "fills empty deps",
+			content:  "    deps = [],\n",
+			label:    "//packages/C",
+			expected: "    deps = [\n        \"//packages/C\",\n    ],\n",
+			changed:  true,
+		},
+		{
+			name:     "explicit target counts as present",
+			content:  "    deps = [\"//packages/A/Sources/C:C\"],\n",
+			label:    "//packages/A/Sources/C",
+			expected: "    deps = [\"//packages/A/Sources/C:C\"],\n",
+		},
+		{
+			name:    "no list",
+			content: "swift_library(\n    name = \"A\",\n    srcs = glob([\"*.swift\"]),\n)\n",
+			label:   "//packages/C",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, changed, err := AddBuildLabel(tt.content, tt.label)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddBuildLabel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if changed != tt.changed || result != tt.expected {
+				t.Errorf("AddBuildLabel() = %q, %v, want %q, %v", result, changed, tt.expected, tt.changed)
+			}
+		})
+	}
+}
+
+func TestPruneEmptyBuildFiles(t *testing.T) {
+	targetDir := t.TempDir()
+	generator := NewBuildFileGenerator()
+	for _, subpackage := range []string{"Networking", "Caching"} {
+		content, err := generator.Generate(BuildSpec{PackageName: "UmbraUtils", SubpackagePath: subpackage})
+		if err != nil {
+			t.Fatal(err)
+		}
+		dir := filepath.Join(targetDir, "UmbraUtils", "Sources", subpackage)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "BUILD.bazel"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Caching keeps a source; Networking's sources were all migrated elsewhere
+	cachingSource := filepath.Join(targetDir, "UmbraUtils", "Sources", "Caching", "Cache.swift")
+	if err := ioutil.WriteFile(cachingSource, []byte("struct Cache {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := NewMigrationHelper(nil, targetDir, "").PruneEmptyBuildFiles(targetDir)
+	if err != nil {
+		t.Fatalf("PruneEmptyBuildFiles: %v", err)
+	}
+
+	networkingDir := filepath.Join(targetDir, "UmbraUtils", "Sources", "Networking")
+	if expected := []string{filepath.Join(networkingDir, "BUILD.bazel"), networkingDir}; !reflect.DeepEqual(removed, expected) {
+		t.Errorf("removed %v, want %v", removed, expected)
+	}
+	if dirExists(networkingDir) {
+		t.Error("kept the empty Networking directory")
+	}
+	if !fileExists(filepath.Join(targetDir, "UmbraUtils", "Sources", "Caching", "BUILD.bazel")) {
+		t.Error("removed the BUILD file of Caching, which has a source")
+	}
+}
+
+func TestReportToProto(t *testing.T) {
+	report := &MigrationReport{
+		GeneratedAt:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		WorkspaceCommit: "abc123",
+		TotalModules:    3,
+		Succeeded:       1,
+		Failed:          1,
+		Skipped:         1,
+		SkippedModules:  []string{"ResticTypes"},
+		ModuleResults: []MigrationResult{
+			{Module: "CoreDTOs", TargetPackage: "UmbraCoreTypes/CoreDTOs", Success: true, FilesCopied: 2,
+				CompletedAt: time.Date(2024, 3, 1, 11, 59, 0, 0, time.UTC)},
+			{Module: "LoggingWrapper", TargetPackage: "UmbraImplementations/LoggingImpl", Error: "buildifier failed",
+				APIChanges:      []APIDiff{{Kind: "added", DeclName: "struct Logger", File: "Logger.swift"}},
+				TestableImports: []string{"LoggingWrapper"}},
+		},
+		GraphStats: GraphStats{Modules: 2, Dependencies: 3, CrossPackageDependencies: 2, InvalidDependencies: 1},
+	}
+	content, err := report.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+
+	var decoded alphapb.MigrationReport
+	if err := proto.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("error decoding MigrationReport: %v", err)
+	}
+	if !decoded.GeneratedAt.AsTime().Equal(report.GeneratedAt) || decoded.WorkspaceCommit != report.WorkspaceCommit ||
+		decoded.TotalModules != 3 || decoded.Succeeded != 1 || decoded.Failed != 1 || decoded.Skipped != 1 ||
+		!reflect.DeepEqual(decoded.SkippedModules, report.SkippedModules) {
+		t.Errorf("decoded report = %v, want the fields of %+v", &decoded, report)
+	}
+	stats := GraphStats{
+		Modules: int(decoded.GraphStats.GetModules()), Dependencies: int(decoded.GraphStats.GetDependencies()),
+		CrossPackageDependencies: int(decoded.GraphStats.GetCrossPackageDependencies()),
+		InvalidDependencies:      int(decoded.GraphStats.GetInvalidDependencies()),
+	}
+	if stats != report.GraphStats {
+		t.Errorf("decoded graph stats = %+v, want %+v", stats, report.GraphStats)
+	}
+
+	if len(decoded.ModuleResults) != len(report.ModuleResults) {
+		t.Fatalf("decoded %d module results, want %d", len(decoded.ModuleResults), len(report.ModuleResults))
+	}
+	for i, result := range report.ModuleResults {
+		got := decoded.ModuleResults[i]
+		decodedResult := MigrationResult{
+			Module: got.Module, TargetPackage: got.TargetPackage, Success: got.Success, FilesCopied: int(got.FilesCopied),
+			Error: got.Error, TestableImports: got.TestableImports,
+		}
+		if got.CompletedAt != nil {
+			decodedResult.CompletedAt = got.CompletedAt.AsTime()
+		}
+		for _, change := range got.ApiChanges {
+			decodedResult.APIChanges = append(decodedResult.APIChanges, APIDiff{Kind: change.Kind, DeclName: change.DeclName, File: change.File})
+		}
+		if !reflect.DeepEqual(decodedResult, result) {
+			t.Errorf("decoded module result %d = %+v, want %+v", i, decodedResult, result)
+		}
+	}
+}
+
+func TestMigratePackageTierRejectsCycle(t *testing.T) {
+	root := t.TempDir()
+	sourcesDir := filepath.Join(root, "Sources")
+	for _, module := range []string{"CoreDTOs", "KeyManagementTypes"} {
+		if err := os.MkdirAll(filepath.Join(sourcesDir, module), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	helper := NewMigrationHelper([]string{sourcesDir}, filepath.Join(root, "packages"), root)
+	helper.ModuleDependencies = map[string][]string{"CoreDTOs": {"KeyManagementTypes"}, "KeyManagementTypes": {"CoreDTOs"}}
+	report, err := helper.MigratePackageTier("UmbraCoreTypes", false)
+	if err == nil {
+		t.Fatalf("expected a cycle error, got report %+v", report)
+	}
+	for _, module := range []string{"CoreDTOs", "KeyManagementTypes"} {
+		if !strings.Contains(err.Error(), module) {
+			t.Errorf("error %q does not name %s", err, module)
+		}
+	}
+	if len(helper.Results) != 0 {
+		t.Errorf("migrated %d modules before rejecting the cycle", len(helper.Results))
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Manifest is a signed record of completed module migrations
+type Manifest struct {
+	CompletedAt     time.Time         `json:"completedAt"`
+	WorkspaceCommit string            `json:"workspaceCommit"`
+	ModuleResults   []MigrationResult `json:"moduleResults"`
+	Digest          string            `json:"digest"`
+	Signature       []byte            `json:"signature"`
+}
+
+// manifestContents holds the signed fields of a Manifest
+type manifestContents struct {
+	CompletedAt     time.Time         `json:"completedAt"`
+	WorkspaceCommit string            `json:"workspaceCommit"`
+	ModuleResults   []MigrationResult `json:"moduleResults"`
+}
+
+// digest computes the SHA-256 digest of the manifest's JSON-serialized contents
+func (mf Manifest) digest() ([]byte, error) {
+	content, err := json.Marshal(manifestContents{
+		CompletedAt:     mf.CompletedAt,
+		WorkspaceCommit: mf.WorkspaceCommit,
+		ModuleResults:   mf.ModuleResults,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding manifest: %v", err)
+	}
+
+	sum := sha256.Sum256(content)
+	return sum[:], nil
+}
+
+// GenerateManifest creates a manifest of migration results signed with an Ed25519 private key.
+// The workspace commit is taken from the git repository containing workspaceRoot.
+func GenerateManifest(results []MigrationResult, privateKeyPath, workspaceRoot string) (Manifest, error) {
+	privateKey, err := loadEd25519PrivateKey(privateKeyPath)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest := Manifest{
+		CompletedAt:     time.Now().UTC(),
+		WorkspaceCommit: workspaceCommit(workspaceRoot),
+		ModuleResults:   results,
+	}
+
+	digest, err := manifest.digest()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest.Digest = hex.EncodeToString(digest)
+	manifest.Signature = ed25519.Sign(privateKey, digest)
+
+	return manifest, nil
+}
+
+// VerifyManifest checks that a manifest's signature matches its contents
+func VerifyManifest(manifest Manifest, publicKeyPath string) error {
+	publicKey, err := loadEd25519PublicKey(publicKeyPath)
+	if err != nil {
+		return err
+	}
+
+	digest, err := manifest.digest()
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(digest) != manifest.Digest {
+		return fmt.Errorf("manifest digest mismatch: contents have been modified")
+	}
+
+	if !ed25519.Verify(publicKey, digest, manifest.Signature) {
+		return fmt.Errorf("manifest signature is invalid")
+	}
+
+	return nil
+}
+
+// LoadManifest reads a manifest from a JSON file
+func LoadManifest(path string) (Manifest, error) {
+	var manifest Manifest
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return manifest, fmt.Errorf("error reading manifest: %v", err)
+	}
+
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return manifest, fmt.Errorf("error parsing manifest %s: %v", path, err)
+	}
+
+	return manifest, nil
+}
+
+// SaveManifest writes a manifest to a JSON file
+func SaveManifest(path string, manifest Manifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding manifest: %v", err)
+	}
+
+	if err := ioutil.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing manifest %s: %v", path, err)
+	}
+
+	return nil
+}
+
+// loadEd25519PrivateKey reads a PKCS#8 PEM-encoded Ed25519 private key
+func loadEd25519PrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key %s: %v", path, err)
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 private key", path)
+	}
+
+	return privateKey, nil
+}
+
+// loadEd25519PublicKey reads a PKIX PEM-encoded Ed25519 public key
+func loadEd25519PublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key %s: %v", path, err)
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 public key", path)
+	}
+
+	return publicKey, nil
+}
+
+// readPEMBlock reads the first PEM block from a file
+func readPEMBlock(path string) (*pem.Block, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key: %v", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+
+	return block, nil
+}
+
+// workspaceCommit returns the current git commit of the workspace, or an empty string if unknown
+func workspaceCommit(workspaceRoot string) string {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = workspaceRoot
+
+	output, err := cmd.Output()
+	if err != nil {
+		fmt.Printf("Warning: Could not determine workspace commit: %v\n", err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// LoadMappingsFile reads additional package mappings from a JSON file containing a []PackageMapping
+func LoadMappingsFile(path string) ([]PackageMapping, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mappings file: %v", err)
+	}
+
+	var mappings []PackageMapping
+	if err := json.Unmarshal(content, &mappings); err != nil {
+		return nil, fmt.Errorf("error parsing mappings file %s: %v", path, err)
+	}
+
+	if err := checkMappings(mappings, path); err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+// checkMappings rejects mappings loaded from path that leave SourceModule or TargetPackage empty, or that map
+// a source module twice
+func checkMappings(mappings []PackageMapping, path string) error {
+	for i, mapping := range mappings {
+		if mapping.SourceModule == "" || mapping.TargetPackage == "" {
+			return fmt.Errorf("mapping %d in %s must set both SourceModule and TargetPackage", i+1, path)
+		}
+	}
+
+	if duplicates := duplicateSourceModules(mappings); len(duplicates) > 0 {
+		return fmt.Errorf("duplicate SourceModule in %s: %s", path, strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+// MergeMappings adds mappings to the helper, replacing any mapping for the same source module and effective period.
+// Mappings for a different period are added alongside, so that phased mappings can be committed ahead of time.
+func (m *MigrationHelper) MergeMappings(mappings []PackageMapping) {
+	for _, mapping := range mappings {
+		if mapping.ImportModuleAs == "" {
+			mapping.ImportModuleAs = mapping.SourceModule
+		}
+
+		replaced := false
+		for i, existing := range m.DefaultMappings {
+			if existing.SourceModule == mapping.SourceModule &&
+				sameTime(existing.EffectiveFrom, mapping.EffectiveFrom) && sameTime(existing.EffectiveUntil, mapping.EffectiveUntil) {
+				m.DefaultMappings[i] = mapping
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			m.DefaultMappings = append(m.DefaultMappings, mapping)
+		}
+	}
+
+	m.Conflicts = m.ValidateMappingConsistency()
+}
+
+// sameTime checks if two optional times are equal
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// FilterMappings returns the mappings with any column containing filter
+func FilterMappings(mappings []PackageMapping, filter string) []PackageMapping {
+	if filter == "" {
+		return mappings
+	}
+
+	filtered := []PackageMapping{}
+	for _, mapping := range mappings {
+		if strings.Contains(mapping.SourceModule, filter) ||
+			strings.Contains(mapping.TargetPackage, filter) ||
+			strings.Contains(mapping.ImportModuleAs, filter) {
+			filtered = append(filtered, mapping)
+		}
+	}
+	return filtered
+}
+
+// runListMappings implements the list-mappings subcommand
+func runListMappings(args []string) error {
+	fs := flag.NewFlagSet("list-mappings", flag.ExitOnError)
+	configFlag := fs.String("config", "", "JSON file with packageMappings and validDependencies lists")
+	configModeFlag := fs.String("config-mode", configModeMerge, "How -config combines with the built-in defaults: merge or replace")
+	mappingsFileFlag := fs.String("mappings-file", "", "JSON file with additional package mappings")
+	formatFlag := fs.String("list-format", "table", "Output format: table or json")
+	filterFlag := fs.String("filter", "", "Only show mappings with a column containing this substring")
+	fs.Parse(args)
+
+	migrator := NewMigrationHelper(nil, "", "")
+	if err := migrator.loadConfigFile(*configFlag, *configModeFlag); err != nil {
+		return err
+	}
+	if *mappingsFileFlag != "" {
+		mappings, err := LoadMappingsFile(*mappingsFileFlag)
+		if err != nil {
+			return err
+		}
+		migrator.MergeMappings(mappings)
+	}
+
+	mappings := FilterMappings(migrator.DefaultMappings, *filterFlag)
+
+	switch *formatFlag {
+	case "table":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SourceModule\tTargetPackage\tImportModuleAs")
+		for _, mapping := range mappings {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", mapping.SourceModule, mapping.TargetPackage, mapping.ImportModuleAs)
+		}
+		w.Flush()
+		fmt.Printf("\n%d of %d mappings shown\n", len(mappings), len(migrator.DefaultMappings))
+	case "json":
+		content, err := json.MarshalIndent(mappings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding mappings: %v", err)
+		}
+		fmt.Println(string(content))
+	default:
+		return fmt.Errorf("unknown list format %q (expected table or json)", *formatFlag)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// MigrationDiff lists how the files of a module's migration changed between two runs
+type MigrationDiff struct {
+	Added     []string
+	Removed   []string
+	Modified  []string
+	Unchanged []string
+	Unknown   []string // In both runs, but at least one run has no checksum for the file
+}
+
+// fileChecksums returns the hex SHA-256 digest of each file, relative to dir; unreadable files are left out
+func fileChecksums(dir string, files []string) map[string]string {
+	checksums := make(map[string]string)
+	for _, file := range files {
+		if checksum, err := fileChecksum(filepath.Join(dir, filepath.FromSlash(file))); err == nil {
+			checksums[file] = checksum
+		}
+	}
+	return checksums
+}
+
+// fileChecksum returns the hex SHA-256 digest of a file
+func fileChecksum(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RunAt returns the module's latest successful journal entry recorded at or before t
+func (j *MigrationJournal) RunAt(moduleName string, t time.Time) (JournalEntry, bool) {
+	entries, _ := j.Find(moduleName)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Success && !entries[i].Timestamp.After(t) {
+			return entries[i], true
+		}
+	}
+	return JournalEntry{}, false
+}
+
+// DiffMigrationRuns compares the files and checksums recorded for two runs of a module's migration
+func DiffMigrationRuns(before, after JournalEntry) MigrationDiff {
+	diff := MigrationDiff{}
+
+	beforeFiles := make(map[string]bool)
+	for _, file := range before.Files {
+		beforeFiles[file] = true
+	}
+	afterFiles := make(map[string]bool)
+	for _, file := range after.Files {
+		afterFiles[file] = true
+
+		if !beforeFiles[file] {
+			diff.Added = append(diff.Added, file)
+			continue
+		}
+		beforeSum, beforeKnown := before.Checksums[file]
+		afterSum, afterKnown := after.Checksums[file]
+		switch {
+		case !beforeKnown || !afterKnown:
+			diff.Unknown = append(diff.Unknown, file)
+		case beforeSum != afterSum:
+			diff.Modified = append(diff.Modified, file)
+		default:
+			diff.Unchanged = append(diff.Unchanged, file)
+		}
+	}
+	for _, file := range before.Files {
+		if !afterFiles[file] {
+			diff.Removed = append(diff.Removed, file)
+		}
+	}
+
+	for _, files := range [][]string{diff.Added, diff.Removed, diff.Modified, diff.Unchanged, diff.Unknown} {
+		sort.Strings(files)
+	}
+	return diff
+}
+
+// parseDiffTime parses a migration-diff time: a date, which means the end of that day in UTC, or an RFC 3339 time
+func parseDiffTime(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (expected YYYY-MM-DD or RFC 3339)", value)
+	}
+	return t, nil
+}
+
+// runMigrationDiff implements the migration-diff subcommand
+func runMigrationDiff(args []string) error {
+	fs := flag.NewFlagSet("migration-diff", flag.ExitOnError)
+	targetFlag := fs.String("target", "packages", "Target directory containing the migration journal")
+	moduleFlag := fs.String("module", "", "Module whose migration runs to compare")
+	beforeFlag := fs.String("before", "", "Compare the last successful run on or before this date (YYYY-MM-DD or RFC 3339)")
+	afterFlag := fs.String("after", "", "With the last successful run on or before this date (default: the latest run)")
+	fs.Parse(args)
+
+	if *moduleFlag == "" || *beforeFlag == "" {
+		return fmt.Errorf("--module and --before must be specified")
+	}
+	beforeTime, err := parseDiffTime(*beforeFlag)
+	if err != nil {
+		return err
+	}
+	afterTime := time.Now().UTC()
+	if *afterFlag != "" {
+		if afterTime, err = parseDiffTime(*afterFlag); err != nil {
+			return err
+		}
+	}
+
+	journal := NewMigrationJournal("")
+	if err := journal.Load(journalPath(*targetFlag)); err != nil {
+		return err
+	}
+
+	before, found := journal.RunAt(*moduleFlag, beforeTime)
+	if !found {
+		return fmt.Errorf("no successful migration of %s on or before %s", *moduleFlag, *beforeFlag)
+	}
+	after, found := journal.RunAt(*moduleFlag, afterTime)
+	if !found || !after.Timestamp.After(before.Timestamp) {
+		return fmt.Errorf("no successful migration of %s after %s", *moduleFlag, before.Timestamp.Format(time.RFC3339))
+	}
+
+	fmt.Printf("%s: %s -> %s\n", *moduleFlag, before.Timestamp.Format(time.RFC3339), after.Timestamp.Format(time.RFC3339))
+	diff := DiffMigrationRuns(before, after)
+	for _, file := range diff.Added {
+		fmt.Printf("  A %s\n", file)
+	}
+	for _, file := range diff.Removed {
+		fmt.Printf("  D %s\n", file)
+	}
+	for _, file := range diff.Modified {
+		fmt.Printf("  M %s\n", file)
+	}
+	for _, file := range diff.Unknown {
+		fmt.Printf("  ? %s\n", file)
+	}
+
+	fmt.Printf("%d added, %d removed, %d modified, %d unchanged\n", len(diff.Added), len(diff.Removed), len(diff.Modified), len(diff.Unchanged))
+	if len(diff.Unknown) > 0 {
+		fmt.Printf("⚠️ %d files were journaled without checksums, so changes to them are unknown\n", len(diff.Unknown))
+	}
+	return nil
+}
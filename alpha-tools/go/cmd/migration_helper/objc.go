@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// objcModuleImportPattern matches #import <Module/Header.h> directives
+	objcModuleImportPattern = regexp.MustCompile(`(?m)^(\s*#import\s+<)([^/>]+)/([^>]+)(>)`)
+	// objcQuotedImportPattern matches #import "Header.h" directives, including "Module/Header.h"
+	objcQuotedImportPattern = regexp.MustCompile(`(?m)^(\s*#import\s+")(?:([^/"]+)/)?([^"]+)(")`)
+)
+
+// objcExtensions are the Objective-C and C/C++ source and header extensions, whose #import directives are
+// rewritten
+var objcExtensions = []string{"h", "m", "mm", "c", "cc", "cpp", "hpp"}
+
+// isObjCFile checks if a path is an Objective-C or C/C++ source or header file
+func isObjCFile(path string) bool {
+	return contains(objcExtensions, strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// parseFileExtensions parses a comma-separated list of source file extensions, with or without dots. Only
+// Swift and the Objective-C and C/C++ extensions are supported, since those are the files whose imports the
+// helper knows how to rewrite.
+func parseFileExtensions(value string) ([]string, error) {
+	extensions := []string{}
+	for _, ext := range splitList(value) {
+		ext = strings.TrimPrefix(ext, ".")
+		if ext != "swift" && !contains(objcExtensions, ext) {
+			return nil, fmt.Errorf("unsupported file extension %s (supported: swift, %s)", ext, strings.Join(objcExtensions, ", "))
+		}
+		if !contains(extensions, ext) {
+			extensions = append(extensions, ext)
+		}
+	}
+	if len(extensions) == 0 {
+		return nil, fmt.Errorf("no file extensions given")
+	}
+	return extensions, nil
+}
+
+// sourceExtensions returns the extensions of the source files to migrate: FileExtensions, which defaults to
+// swift, and the Objective-C .h and .m files with IncludeObjC
+func (m *MigrationHelper) sourceExtensions() []string {
+	extensions := append([]string{}, m.FileExtensions...)
+	if len(extensions) == 0 {
+		extensions = []string{"swift"}
+	}
+	if m.IncludeObjC {
+		for _, ext := range []string{"h", "m"} {
+			if !contains(extensions, ext) {
+				extensions = append(extensions, ext)
+			}
+		}
+	}
+	return extensions
+}
+
+// isSourceFile checks if a path is a source file to migrate
+func (m *MigrationHelper) isSourceFile(path string) bool {
+	return contains(m.sourceExtensions(), strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// objcHeaderMapping maps old module header prefixes to their new prefixes
+func (m *MigrationHelper) objcHeaderMapping() map[string]string {
+	headerMapping := make(map[string]string)
+	for _, mapping := range m.EffectiveMappings() {
+		prefix := mapping.ObjCHeaderPrefix
+		if prefix == "" {
+			prefix = mapping.ImportModuleAs
+		}
+		headerMapping[mapping.SourceModule] = prefix
+	}
+	return headerMapping
+}
+
+// UpdateObjCImports rewrites the module prefix of #import directives in an Objective-C or C/C++ file
+func (m *MigrationHelper) UpdateObjCImports(filePath string, headerMapping map[string]string) error {
+	content, err := m.readFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading file: %v", err)
+	}
+
+	fileContent, changes := rewriteObjCImports(string(content), headerMapping)
+	for _, change := range changes {
+		m.progressf("Updated #import: %s\n", change)
+	}
+
+	// Write updated content back to file
+	if err := m.writeFile(filePath, []byte(fileContent)); err != nil {
+		return fmt.Errorf("error writing file: %v", err)
+	}
+
+	return nil
+}
+
+// rewriteObjCImports rewrites the module prefix of #import directives, returning the new content and a
+// description of each change. The <Module/Header.h> and "Header.h" forms are matched in separate passes. A
+// quoted header without a module prefix is in the same module and migrates with the file, so it is left alone.
+func rewriteObjCImports(content string, headerMapping map[string]string) (string, []string) {
+	changes := []string{}
+	for _, pattern := range []*regexp.Regexp{objcModuleImportPattern, objcQuotedImportPattern} {
+		content = pattern.ReplaceAllStringFunc(content, func(directive string) string {
+			parts := pattern.FindStringSubmatch(directive)
+			oldPrefix := parts[2]
+			newPrefix, exists := headerMapping[oldPrefix]
+			if oldPrefix == "" || !exists || newPrefix == oldPrefix {
+				return directive
+			}
+
+			// Keep the delimiter style of the original directive
+			changes = append(changes, fmt.Sprintf("%s/%s -> %s/%s", oldPrefix, parts[3], newPrefix, parts[3]))
+			return strings.Join([]string{parts[1], newPrefix, "/", parts[3], parts[4]}, "")
+		})
+	}
+	return content, changes
+}
+
+// modulemapHeaderPattern matches header and umbrella header declarations in a module map
+var modulemapHeaderPattern = regexp.MustCompile(`(?m)^(\s*(?:(?:private|textual|exclude)\s+)*(?:umbrella\s+)?header\s+)"([^"]+)"`)
+
+// MigrateModulemap copies a module.modulemap, rewriting header paths using the longest matching prefix in pathMapping
+func (m *MigrationHelper) MigrateModulemap(sourcePath, targetPath string, pathMapping map[string]string) error {
+	content, err := m.readFile(sourcePath)
+	if err != nil {
+		return fmt.Errorf("error reading modulemap: %v", err)
+	}
+
+	fileContent, changes := rewriteModulemapHeaders(string(content), pathMapping)
+	for _, change := range changes {
+		m.progressf("Updated modulemap header: %s\n", change)
+	}
+
+	if err := m.writeFile(targetPath, []byte(fileContent)); err != nil {
+		return fmt.Errorf("error writing modulemap: %v", err)
+	}
+
+	return nil
+}
+
+// rewriteModulemapHeaders rewrites the header paths of a module map, returning the new content and a
+// description of each change
+func rewriteModulemapHeaders(content string, pathMapping map[string]string) (string, []string) {
+	changes := []string{}
+	content = modulemapHeaderPattern.ReplaceAllStringFunc(content, func(declaration string) string {
+		parts := modulemapHeaderPattern.FindStringSubmatch(declaration)
+		oldPath := parts[2]
+
+		bestPrefix := ""
+		for prefix := range pathMapping {
+			if (oldPath == prefix || strings.HasPrefix(oldPath, prefix+"/")) && len(prefix) > len(bestPrefix) {
+				bestPrefix = prefix
+			}
+		}
+		if bestPrefix == "" || pathMapping[bestPrefix] == bestPrefix {
+			return declaration
+		}
+
+		newPath := pathMapping[bestPrefix] + strings.TrimPrefix(oldPath, bestPrefix)
+		changes = append(changes, fmt.Sprintf("%s -> %s", oldPath, newPath))
+		return fmt.Sprintf("%s\"%s\"", parts[1], newPath)
+	})
+	return content, changes
+}
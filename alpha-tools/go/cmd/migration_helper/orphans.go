@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// srcsGlobPattern matches the start of a srcs = glob(...) attribute
+	srcsGlobPattern = regexp.MustCompile(`\bsrcs\s*=\s*glob\(`)
+
+	// srcsListPattern matches a srcs attribute that lists files explicitly
+	srcsListPattern = regexp.MustCompile(`(?s)\bsrcs\s*=\s*\[(.*?)\]`)
+
+	// globExcludePattern matches the exclude list of a glob call
+	globExcludePattern = regexp.MustCompile(`(?s)\bexclude\s*=\s*\[(.*?)\]`)
+
+	// starlarkStringPattern matches a double-quoted Starlark string
+	starlarkStringPattern = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// buildGlobs holds the srcs patterns of the BUILD file of one Bazel package
+type buildGlobs struct {
+	include []string
+	exclude []string
+}
+
+// DetectGlobOrphans returns the Swift files under targetDir that no srcs glob of their Bazel package matches.
+// Files matched by a glob's exclude list are intentionally left out and are not reported.
+func DetectGlobOrphans(targetDir string) ([]string, error) {
+	packages := make(map[string]*buildGlobs)
+	swiftFiles := []string{}
+
+	err := filepath.Walk(targetDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		switch {
+		case info.Name() == "BUILD" || info.Name() == "BUILD.bazel":
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("error reading BUILD file: %v", err)
+			}
+			packages[filepath.Dir(path)] = parseBuildGlobs(string(content))
+		case strings.HasSuffix(path, ".swift"):
+			swiftFiles = append(swiftFiles, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s: %v", targetDir, err)
+	}
+
+	orphans := []string{}
+	for _, file := range swiftFiles {
+		// A file belongs to the Bazel package of the nearest directory with a BUILD file
+		packageDir := filepath.Dir(file)
+		for packages[packageDir] == nil && packageDir != targetDir && packageDir != filepath.Dir(packageDir) {
+			packageDir = filepath.Dir(packageDir)
+		}
+
+		globs := packages[packageDir]
+		rel, err := filepath.Rel(packageDir, file)
+		if err != nil {
+			return nil, err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if globs == nil || (!matchesAnyGlob(globs.include, rel) && !matchesAnyGlob(globs.exclude, rel)) {
+			orphans = append(orphans, file)
+		}
+	}
+
+	return orphans, nil
+}
+
+// parseBuildGlobs collects the srcs glob patterns and explicitly listed srcs of a BUILD file
+func parseBuildGlobs(content string) *buildGlobs {
+	globs := &buildGlobs{}
+
+	for _, loc := range srcsGlobPattern.FindAllStringIndex(content, -1) {
+		call := globCallBody(content[loc[1]:])
+
+		// The first list in the call holds the include patterns
+		if start, end := strings.Index(call, "["), strings.Index(call, "]"); start >= 0 && end > start {
+			globs.include = append(globs.include, quotedStrings(call[start+1:end])...)
+		}
+		if match := globExcludePattern.FindStringSubmatch(call); match != nil {
+			globs.exclude = append(globs.exclude, quotedStrings(match[1])...)
+		}
+	}
+
+	for _, match := range srcsListPattern.FindAllStringSubmatch(content, -1) {
+		globs.include = append(globs.include, quotedStrings(match[1])...)
+	}
+
+	return globs
+}
+
+// globCallBody returns the text up to the parenthesis closing a glob( call
+func globCallBody(text string) string {
+	depth := 1
+	for i, c := range text {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return text[:i]
+			}
+		}
+	}
+	return text
+}
+
+// quotedStrings returns the values of the string literals in text
+func quotedStrings(text string) []string {
+	values := []string{}
+	for _, match := range starlarkStringPattern.FindAllStringSubmatch(text, -1) {
+		values = append(values, match[1])
+	}
+	return values
+}
+
+// matchesAnyGlob checks if a slash-separated path relative to its package matches any Bazel glob pattern
+func matchesAnyGlob(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if globToRegexp(pattern).MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp converts a Bazel glob pattern, where ** matches any number of directories, to a regexp
+func globToRegexp(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+		case pattern[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
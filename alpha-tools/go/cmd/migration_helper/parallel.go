@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultParallelism is the number of files MigrateModule migrates at once unless -parallelism says otherwise
+const defaultParallelism = 8
+
+// fileJob is one file of a module to copy into the target package
+type fileJob struct {
+	module        string // Module and target package being migrated, which key the state file
+	targetPackage string
+	source        string
+	target        string
+	resource      bool // Copied verbatim
+	objc          bool // #import directives are rewritten
+	modulemap     bool // Header paths are rewritten
+}
+
+// fileJobResult is what migrating one file produced
+type fileJobResult struct {
+	migrated        bool
+	skipped         bool // Unchanged since the copy recorded in the state file
+	testableImports []string
+}
+
+// fileJobSummary is what migrating all of a module's files produced
+type fileJobSummary struct {
+	migratedFiles   []string // Target files, in job order, including skipped ones
+	testableImports []string // Modules imported with @testable, in job order
+	copied          int64
+	skipped         int64
+}
+
+// pathLocks serialises work on the same path; the zero value is ready to use
+type pathLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock locks path and returns the function that unlocks it
+func (l *pathLocks) lock(path string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sync.Mutex)
+	}
+	pathLock, exists := l.locks[path]
+	if !exists {
+		pathLock = &sync.Mutex{}
+		l.locks[path] = pathLock
+	}
+	l.mu.Unlock()
+
+	pathLock.Lock()
+	return pathLock.Unlock
+}
+
+// migrateFiles migrates the jobs on m.Parallelism worker goroutines. After the first error no new jobs are
+// started and that error is returned.
+func (m *MigrationHelper) migrateFiles(jobs []fileJob, moduleMapping, headerMapping map[string]string) (fileJobSummary, error) {
+	workers := m.Parallelism
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	indexes := make(chan int)
+	errs := make(chan error, len(jobs))
+	results := make([]fileJobResult, len(jobs))
+	summary := fileJobSummary{migratedFiles: []string{}, testableImports: []string{}}
+	var failed int32
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if atomic.LoadInt32(&failed) != 0 {
+					continue
+				}
+				result, err := m.migrateFile(jobs[i], moduleMapping, headerMapping)
+				if err != nil {
+					atomic.StoreInt32(&failed, 1)
+					errs <- err
+					continue
+				}
+				results[i] = result
+				if result.skipped {
+					atomic.AddInt64(&summary.skipped, 1)
+				} else if result.migrated {
+					atomic.AddInt64(&summary.copied, 1)
+				}
+			}
+		}()
+	}
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return summary, err
+	}
+
+	for i, result := range results {
+		if result.migrated {
+			summary.migratedFiles = append(summary.migratedFiles, jobs[i].target)
+		}
+		for _, module := range result.testableImports {
+			if !contains(summary.testableImports, module) {
+				summary.testableImports = append(summary.testableImports, module)
+			}
+		}
+	}
+	return summary, nil
+}
+
+// migrateFile migrates one file, unless the state file shows it is unchanged since it was last copied, and
+// records the copy in the state file
+func (m *MigrationHelper) migrateFile(job fileJob, moduleMapping, headerMapping map[string]string) (fileJobResult, error) {
+	// Two source files must not be written to the same destination at once
+	unlock := m.destinationLocks.lock(job.target)
+	defer unlock()
+
+	if m.unchangedSinceState(job) {
+		result := fileJobResult{migrated: true, skipped: true}
+		if !job.resource && !job.objc && !job.modulemap {
+			// The copy started out as the unchanged source, so its @testable imports are the source's
+			if content, err := ioutil.ReadFile(job.source); err == nil {
+				result.testableImports = findTestableImports(string(content))
+			}
+		}
+		m.progressf("Skipped %s (unchanged since the last run)\n", filepath.Base(job.source))
+		return result, nil
+	}
+
+	result, err := m.copyJobFile(job, moduleMapping, headerMapping)
+	if err != nil {
+		return result, err
+	}
+	if err := m.recordState(job); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// copyJobFile copies one file into the target package and rewrites its imports
+func (m *MigrationHelper) copyJobFile(job fileJob, moduleMapping, headerMapping map[string]string) (fileJobResult, error) {
+	result := fileJobResult{}
+	source, target := job.source, job.target
+
+	// Resources are copied verbatim
+	if job.resource {
+		if err := m.copyFile(source, target); err != nil {
+			return result, err
+		}
+		result.migrated = true
+		m.progressf("Copied resource %s to %s\n", filepath.Base(source), target)
+		if strings.HasSuffix(source, ".plist") {
+			m.checkPlistBundleIdentifier(target)
+		}
+		return result, nil
+	}
+
+	// Module maps are rewritten rather than copied verbatim
+	if job.modulemap {
+		if err := m.MigrateModulemap(source, target, headerMapping); err != nil {
+			return result, err
+		}
+		result.migrated = true
+		m.progressf("Migrated %s to %s\n", filepath.Base(source), target)
+		return result, nil
+	}
+
+	// Copy the file
+	if err := m.copyFile(source, target); err != nil {
+		return result, err
+	}
+	result.migrated = true
+	m.progressf("Copied %s to %s\n", filepath.Base(source), target)
+
+	// Update imports
+	if job.objc {
+		if err := m.UpdateObjCImports(target, headerMapping); err != nil {
+			return result, fmt.Errorf("error updating #import directives in %s: %v", target, err)
+		}
+	} else {
+		if content, err := m.readFile(target); err == nil {
+			result.testableImports = findTestableImports(string(content))
+		}
+		if err := m.UpdateImports(target, moduleMapping); err != nil {
+			return result, fmt.Errorf("error updating imports in %s: %v", target, err)
+		}
+	}
+
+	return result, nil
+}
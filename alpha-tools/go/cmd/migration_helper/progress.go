@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Progress statuses of a mapped module
+const (
+	progressMigrated    = "migrated"
+	progressPending     = "pending"
+	progressMissingDeps = "missing-deps" // Pending, and some of its dependencies are not migrated yet
+)
+
+// ModuleProgress is the progress of one mapped module, judged from its target directory
+type ModuleProgress struct {
+	Module        string
+	TargetPackage string
+	Status        string
+	Files         int      // Swift files in the target directory
+	MissingDeps   []string // Mapped dependencies not migrated yet, for modules with missing deps
+}
+
+// CollectProgress reports every effective mapping as migrated if its target directory has Swift files, and
+// as pending otherwise. Pending modules with a mapped dependency that is not migrated yet have missing deps.
+// If the dependencies cannot be queried, pending modules are reported as pending.
+func (m *MigrationHelper) CollectProgress() []ModuleProgress {
+	mappings := m.EffectiveMappings()
+	progress := make([]ModuleProgress, len(mappings))
+	migrated := make(map[string]bool)
+	for i, mapping := range mappings {
+		progress[i] = ModuleProgress{Module: mapping.SourceModule, TargetPackage: mapping.TargetPackage, Status: progressPending}
+		progress[i].Files = countSwiftFiles(m.TargetModulePath(mapping.TargetPackage))
+		if progress[i].Files > 0 {
+			progress[i].Status = progressMigrated
+			migrated[mapping.SourceModule] = true
+		}
+	}
+
+	for i := range progress {
+		if progress[i].Status != progressPending {
+			continue
+		}
+		deps, err := m.moduleDependencies(progress[i].Module)
+		if err != nil {
+			m.warn("Error querying dependencies, so modules with missing dependencies are reported as pending: %v", err)
+			break
+		}
+		for _, dep := range deps {
+			if m.GetTargetMapping(dep) != nil && !migrated[dep] && !contains(progress[i].MissingDeps, dep) {
+				progress[i].MissingDeps = append(progress[i].MissingDeps, dep)
+			}
+		}
+		if len(progress[i].MissingDeps) > 0 {
+			progress[i].Status = progressMissingDeps
+		}
+	}
+	return progress
+}
+
+// GenerateProgressReport writes the progress of every mapped module as a Markdown table, to be committed as a
+// living migration status tracker. The report has no timestamp, so it only changes when progress does.
+func (m *MigrationHelper) GenerateProgressReport(outputFile string) error {
+	progress := m.CollectProgress()
+	migrated := 0
+	for _, module := range progress {
+		if module.Status == progressMigrated {
+			migrated++
+		}
+	}
+
+	labels := map[string]string{
+		progressMigrated:    "✅ Migrated",
+		progressPending:     "⏳ Pending",
+		progressMissingDeps: "❌ Missing Deps",
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Alpha Dot Five Migration Progress\n\n")
+	sb.WriteString(fmt.Sprintf("**%d of %d modules migrated**\n\n", migrated, len(progress)))
+	sb.WriteString("| Module | Target Package | Status | # Files |\n")
+	sb.WriteString("| --- | --- | --- | ---: |\n")
+	for _, module := range progress {
+		status := labels[module.Status]
+		if len(module.MissingDeps) > 0 {
+			status += " (" + strings.Join(module.MissingDeps, ", ") + ")"
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %s | %d |\n", module.Module, module.TargetPackage, status, module.Files))
+	}
+
+	if err := ioutil.WriteFile(outputFile, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("error writing progress report: %v", err)
+	}
+	fmt.Printf("Migration progress report written to %s (%d of %d modules migrated)\n", outputFile, migrated, len(progress))
+	return nil
+}
+
+// countSwiftFiles counts the Swift files in dir and its subdirectories; a missing dir has none
+func countSwiftFiles(dir string) int {
+	count := 0
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && strings.HasSuffix(path, ".swift") {
+			count++
+		}
+		return nil
+	})
+	return count
+}
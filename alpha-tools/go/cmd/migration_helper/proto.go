@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/alphapb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto encodes the report as a MigrationReport message
+func (r *MigrationReport) ToProto() ([]byte, error) {
+	message := &alphapb.MigrationReport{
+		GeneratedAt:     protoTimestamp(r.GeneratedAt),
+		WorkspaceCommit: r.WorkspaceCommit,
+		TotalModules:    int64(r.TotalModules),
+		Succeeded:       int64(r.Succeeded),
+		Failed:          int64(r.Failed),
+		Skipped:         int64(r.Skipped),
+		SkippedModules:  r.SkippedModules,
+		GraphStats: &alphapb.GraphStats{
+			Modules:                  int64(r.GraphStats.Modules),
+			Dependencies:             int64(r.GraphStats.Dependencies),
+			CrossPackageDependencies: int64(r.GraphStats.CrossPackageDependencies),
+			InvalidDependencies:      int64(r.GraphStats.InvalidDependencies),
+		},
+	}
+	for _, result := range r.ModuleResults {
+		message.ModuleResults = append(message.ModuleResults, result.protoMessage())
+	}
+	return marshalProto(message)
+}
+
+// ToProto encodes the result as a MigrationResult message
+func (r MigrationResult) ToProto() ([]byte, error) {
+	return marshalProto(r.protoMessage())
+}
+
+// protoMessage converts the result to a MigrationResult message
+func (r MigrationResult) protoMessage() *alphapb.MigrationResult {
+	message := &alphapb.MigrationResult{
+		Module:          r.Module,
+		TargetPackage:   r.TargetPackage,
+		Success:         r.Success,
+		FilesCopied:     int64(r.FilesCopied),
+		Error:           r.Error,
+		CompletedAt:     protoTimestamp(r.CompletedAt),
+		TestableImports: r.TestableImports,
+	}
+	for _, change := range r.APIChanges {
+		message.ApiChanges = append(message.ApiChanges, &alphapb.APIDiff{Kind: change.Kind, DeclName: change.DeclName, File: change.File})
+	}
+	return message
+}
+
+// protoTimestamp converts a time to a Timestamp message, leaving the zero time unset
+func protoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// marshalProto encodes a message in protobuf wire format
+func marshalProto(message proto.Message) ([]byte, error) {
+	content, err := proto.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s: %v", message.ProtoReflect().Descriptor().Name(), err)
+	}
+	return content, nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PruneEmptyBuildFiles removes the BUILD files under packageDir whose srcs glob matches no files, then the
+// directories left empty, and returns the removed paths. A filegroup of subpackage labels is removed once all
+// of its subpackages are. BUILD files with explicitly listed srcs are kept.
+func (m *MigrationHelper) PruneEmptyBuildFiles(packageDir string) ([]string, error) {
+	packageDir, err := filepath.Abs(packageDir)
+	if err != nil {
+		return nil, fmt.Errorf("error getting absolute path: %v", err)
+	}
+
+	buildFiles := []string{}
+	err = filepath.Walk(packageDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && (info.Name() == "BUILD" || info.Name() == "BUILD.bazel") {
+			buildFiles = append(buildFiles, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s: %v", packageDir, err)
+	}
+
+	// Deepest first, so a package's subpackages are pruned before its own glob is evaluated
+	sort.Slice(buildFiles, func(i, j int) bool {
+		return strings.Count(buildFiles[i], string(os.PathSeparator)) > strings.Count(buildFiles[j], string(os.PathSeparator))
+	})
+
+	removed := []string{}
+	pruned := make(map[string]bool) // Package directories whose BUILD file was removed
+	for _, buildFile := range buildFiles {
+		content, err := ioutil.ReadFile(buildFile)
+		if err != nil {
+			return removed, fmt.Errorf("error reading BUILD file: %v", err)
+		}
+		dir := filepath.Dir(buildFile)
+
+		empty := false
+		if srcsGlobPattern.MatchString(string(content)) {
+			matched, err := m.countGlobMatches(dir, parseBuildGlobs(string(content)), pruned)
+			if err != nil {
+				return removed, err
+			}
+			empty = matched == 0
+		} else if match := srcsListPattern.FindStringSubmatch(string(content)); match != nil {
+			empty = m.allLabelsPruned(quotedStrings(match[1]), pruned)
+		}
+		if !empty {
+			continue
+		}
+
+		pruned[dir] = true
+		if m.DryRun {
+			fmt.Printf("[dry-run] Would remove %s, whose srcs match no files\n", buildFile)
+			continue
+		}
+		if err := os.Remove(buildFile); err != nil {
+			return removed, fmt.Errorf("error removing %s: %v", buildFile, err)
+		}
+		removed = append(removed, buildFile)
+		fmt.Printf("Removed %s, whose srcs match no files\n", buildFile)
+	}
+	if m.DryRun || len(removed) == 0 {
+		return removed, nil
+	}
+
+	// Deepest directories first, so a parent is only checked once its children are gone
+	dirs := []string{}
+	filepath.Walk(packageDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.IsDir() && path != packageDir {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := ioutil.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			if err := os.Remove(dirs[i]); err != nil {
+				return removed, fmt.Errorf("error removing %s: %v", dirs[i], err)
+			}
+			removed = append(removed, dirs[i])
+		}
+	}
+
+	return removed, nil
+}
+
+// countGlobMatches counts the files of the Bazel package in dir that its srcs globs match. Child directories
+// with a BUILD file that was not pruned are separate packages the globs do not reach.
+func (m *MigrationHelper) countGlobMatches(dir string, globs *buildGlobs, pruned map[string]bool) (int, error) {
+	matched := 0
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && !pruned[path] && (fileExists(filepath.Join(path, "BUILD")) || fileExists(filepath.Join(path, "BUILD.bazel"))) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchesAnyGlob(globs.include, rel) && !matchesAnyGlob(globs.exclude, rel) {
+			matched++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error scanning %s: %v", dir, err)
+	}
+	return matched, nil
+}
+
+// allLabelsPruned checks if srcs lists only //packages labels whose package directories were pruned
+func (m *MigrationHelper) allLabelsPruned(srcs []string, pruned map[string]bool) bool {
+	if len(srcs) == 0 {
+		return false
+	}
+	for _, label := range srcs {
+		if !strings.HasPrefix(label, "//packages/") {
+			return false
+		}
+		packagePath := strings.SplitN(strings.TrimPrefix(label, "//packages/"), ":", 2)[0]
+		if !pruned[filepath.Join(m.TargetDir, filepath.FromSlash(packagePath))] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+)
+
+//go:embed assets/migration_report.html.tmpl
+var reportAssets embed.FS
+
+// GraphStats summarises the dependencies between the modules of a migration
+type GraphStats struct {
+	Modules                  int `json:"modules"`
+	Dependencies             int `json:"dependencies"`             // Dependencies on mapped modules
+	CrossPackageDependencies int `json:"crossPackageDependencies"` // Dependencies on modules mapped to another top-level package
+	InvalidDependencies      int `json:"invalidDependencies"`      // Cross-package dependencies no rule allows
+}
+
+// MigrationReport is a structured summary of a multi-module migration
+type MigrationReport struct {
+	GeneratedAt     time.Time         `json:"generatedAt"`
+	WorkspaceCommit string            `json:"workspaceCommit"`
+	TotalModules    int               `json:"totalModules"`
+	Succeeded       int               `json:"succeeded"`
+	Failed          int               `json:"failed"`
+	Skipped         int               `json:"skipped"`
+	SkippedModules  []string          `json:"skippedModules,omitempty"`
+	ModuleResults   []MigrationResult `json:"moduleResults"`
+	GraphStats      GraphStats        `json:"graphStats"`
+}
+
+// NewMigrationReport creates a report from the results of the migrated modules and the modules that were skipped
+func NewMigrationReport(workspaceRoot string, results []MigrationResult, skipped []string, stats GraphStats) *MigrationReport {
+	report := &MigrationReport{
+		GeneratedAt:     time.Now().UTC(),
+		WorkspaceCommit: workspaceCommit(workspaceRoot),
+		TotalModules:    len(results) + len(skipped),
+		Skipped:         len(skipped),
+		SkippedModules:  skipped,
+		ModuleResults:   results,
+		GraphStats:      stats,
+	}
+	for _, result := range results {
+		if result.Success {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	return report
+}
+
+// ToJSON serializes the report as indented JSON
+func (r *MigrationReport) ToJSON() ([]byte, error) {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding report: %v", err)
+	}
+	return content, nil
+}
+
+// ToHTML renders the report as a self-contained HTML page
+func (r *MigrationReport) ToHTML() ([]byte, error) {
+	tmpl, err := template.ParseFS(reportAssets, "assets/migration_report.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing report template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("error rendering report: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Save writes the report to path, as HTML for .html files, as a binary MigrationReport message for .pb files
+// and as JSON otherwise
+func (r *MigrationReport) Save(path string) error {
+	var content []byte
+	var err error
+	switch filepath.Ext(path) {
+	case ".html", ".htm":
+		content, err = r.ToHTML()
+	case ".pb":
+		content, err = r.ToProto()
+	default:
+		content, err = r.ToJSON()
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("error writing to file %s: %v", path, err)
+	}
+	return nil
+}
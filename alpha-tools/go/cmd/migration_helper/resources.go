@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// resourceDirNames are the directory names whose contents are migrated as resources
+var resourceDirNames = []string{"Resources", "Assets"}
+
+// bundleIdentifierPattern matches a CFBundleIdentifier entry in a property list
+var bundleIdentifierPattern = regexp.MustCompile(`<key>CFBundleIdentifier</key>\s*<string>([^<]*)</string>`)
+
+// isResourcePath checks if a path relative to the module lies inside a resource directory
+func isResourcePath(relPath string) bool {
+	for _, component := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if contains(resourceDirNames, component) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceGlobs returns the resource glob patterns for the resource directories present in dir
+func resourceGlobs(dir string) []string {
+	globs := []string{}
+	for _, name := range resourceDirNames {
+		if dirExists(filepath.Join(dir, name)) {
+			globs = append(globs, fmt.Sprintf("\"%s/**\"", name))
+		}
+	}
+	return globs
+}
+
+// checkPlistBundleIdentifier warns if a property list hard-codes a bundle identifier
+func (m *MigrationHelper) checkPlistBundleIdentifier(plistPath string) {
+	content, err := m.readFile(plistPath)
+	if err != nil {
+		m.warn("Error reading %s: %v", plistPath, err)
+		return
+	}
+
+	match := bundleIdentifierPattern.FindStringSubmatch(string(content))
+	if match == nil || strings.Contains(match[1], "$(") {
+		return
+	}
+
+	m.warn("%s hard-codes bundle identifier %q, which may need updating for the new package", plistPath, match[1])
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// MigrationResult records the outcome of migrating a single module
+type MigrationResult struct {
+	Module          string    `json:"module"`
+	TargetPackage   string    `json:"targetPackage"`
+	Success         bool      `json:"success"`
+	FilesCopied     int       `json:"filesCopied"`
+	Error           string    `json:"error,omitempty"`
+	CompletedAt     time.Time `json:"completedAt"`
+	APIChanges      []APIDiff `json:"apiChanges,omitempty"`
+	TestableImports []string  `json:"testableImports,omitempty"`
+}
+
+// recordResult records the outcome of a module migration in the helper's results and the journal
+func (m *MigrationHelper) recordResult(moduleName, targetPackage string, migratedFiles []string, success bool, err error) {
+	result := MigrationResult{
+		Module:        moduleName,
+		TargetPackage: targetPackage,
+		Success:       success,
+		FilesCopied:   len(migratedFiles),
+		CompletedAt:   time.Now().UTC(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	m.Results = append(m.Results, result)
+
+	// A dry run migrated nothing, so there is nothing to journal
+	if m.DryRun {
+		return
+	}
+
+	// Journal file paths relative to the target directory
+	relFiles := make([]string, 0, len(migratedFiles))
+	for _, file := range migratedFiles {
+		if rel, relErr := filepath.Rel(m.TargetDir, file); relErr == nil {
+			relFiles = append(relFiles, filepath.ToSlash(rel))
+		}
+	}
+
+	entry := JournalEntry{
+		Module:        moduleName,
+		SourceDir:     m.FindModuleSourceDir(moduleName),
+		TargetPackage: targetPackage,
+		Files:         relFiles,
+		Checksums:     fileChecksums(m.TargetDir, relFiles),
+		Success:       success,
+		Error:         result.Error,
+		Timestamp:     result.CompletedAt,
+		Operator:      journalOperator(),
+	}
+	if m.journal == nil {
+		m.journal = NewMigrationJournal(journalPath(m.TargetDir))
+	}
+	if journalErr := m.journal.Append(entry); journalErr != nil {
+		m.warn("Error recording migration in journal: %v", journalErr)
+	}
+}
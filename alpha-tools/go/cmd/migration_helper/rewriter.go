@@ -0,0 +1,174 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// swiftImportLinePattern splits a Swift import line into indentation, attributes, import kind, module and the rest
+var swiftImportLinePattern = regexp.MustCompile(`^(\s*)((?:@\w+\s+)*)import\s+((?:(?:class|struct|enum|protocol|func|var|let|typealias)\s+)?)(\w+)(.*)$`)
+
+// Rule rewrites import lines
+type Rule interface {
+	// Match checks if the rule applies to a line
+	Match(line string) bool
+	// Rewrite returns the rewritten line; it is only called for lines the rule matches
+	Rewrite(line string) string
+}
+
+// ImportChange records a line changed by an ImportRewriter
+type ImportChange struct {
+	Line      string
+	Rewritten string
+}
+
+// ImportRewriter rewrites import lines with the first matching rule
+type ImportRewriter struct {
+	Rules []Rule
+}
+
+// NewImportRewriter creates an import rewriter; rules are tried in order
+func NewImportRewriter(rules []Rule) *ImportRewriter {
+	return &ImportRewriter{Rules: rules}
+}
+
+// RewriteLine rewrites a single line with the first rule that matches it
+func (r *ImportRewriter) RewriteLine(line string) string {
+	for _, rule := range r.Rules {
+		if rule.Match(line) {
+			return rule.Rewrite(line)
+		}
+	}
+	return line
+}
+
+// RewriteAll rewrites every line of content outside block comments and returns the new content with the
+// lines that changed
+func (r *ImportRewriter) RewriteAll(content string) (string, []ImportChange) {
+	changes := []ImportChange{}
+	lines := strings.Split(content, "\n")
+	commentDepth := 0
+	for i, line := range lines {
+		inComment := commentDepth > 0
+		commentDepth = blockCommentDepth(line, commentDepth)
+		if inComment {
+			continue
+		}
+		if rewritten := r.RewriteLine(line); rewritten != line {
+			changes = append(changes, ImportChange{Line: line, Rewritten: rewritten})
+			lines[i] = rewritten
+		}
+	}
+	return strings.Join(lines, "\n"), changes
+}
+
+// blockCommentDepth returns the block comment nesting depth at the end of a line that starts at depth.
+// Swift block comments nest; a // comment outside a block comment ends the line.
+func blockCommentDepth(line string, depth int) int {
+	for i := 0; i+1 < len(line); i++ {
+		switch {
+		case depth == 0 && line[i] == '/' && line[i+1] == '/':
+			return depth
+		case line[i] == '/' && line[i+1] == '*':
+			depth++
+			i++
+		case depth > 0 && line[i] == '*' && line[i+1] == '/':
+			depth--
+			i++
+		}
+	}
+	return depth
+}
+
+// SimpleMapRule renames the module of plain import statements (without attributes) using a module mapping
+type SimpleMapRule struct {
+	Mapping map[string]string
+}
+
+// Match checks if the line imports a mapped module without attributes
+func (r SimpleMapRule) Match(line string) bool {
+	match := swiftImportLinePattern.FindStringSubmatch(line)
+	return match != nil && match[2] == "" && mappedModule(r.Mapping, match[4]) != ""
+}
+
+// Rewrite replaces the imported module
+func (r SimpleMapRule) Rewrite(line string) string {
+	return rewriteImportModule(line, r.Mapping)
+}
+
+// AttributePreservingRule renames the module of import statements with one of the given attributes, such as
+// @testable or @_implementationOnly, keeping the attributes
+type AttributePreservingRule struct {
+	Mapping    map[string]string
+	Attributes []string
+}
+
+// Match checks if the line imports a mapped module with only known attributes
+func (r AttributePreservingRule) Match(line string) bool {
+	match := swiftImportLinePattern.FindStringSubmatch(line)
+	if match == nil || match[2] == "" || mappedModule(r.Mapping, match[4]) == "" {
+		return false
+	}
+	for _, attribute := range strings.Fields(match[2]) {
+		if !contains(r.Attributes, attribute) {
+			return false
+		}
+	}
+	return true
+}
+
+// Rewrite replaces the imported module
+func (r AttributePreservingRule) Rewrite(line string) string {
+	return rewriteImportModule(line, r.Mapping)
+}
+
+// RegexRule rewrites lines matching a regular expression with a replacement template, as in
+// regexp.ReplaceAllString
+type RegexRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// Match checks if the line matches the pattern
+func (r RegexRule) Match(line string) bool {
+	return r.Pattern.MatchString(line)
+}
+
+// Rewrite applies the replacement
+func (r RegexRule) Rewrite(line string) string {
+	return r.Pattern.ReplaceAllString(line, r.Replacement)
+}
+
+// mappedModule returns the new name of a module, or "" if it is unmapped or keeps its name
+func mappedModule(mapping map[string]string, module string) string {
+	if newModule, exists := mapping[module]; exists && newModule != module {
+		return newModule
+	}
+	return ""
+}
+
+// rewriteImportModule replaces the module of an import line according to mapping
+func rewriteImportModule(line string, mapping map[string]string) string {
+	match := swiftImportLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return line
+	}
+	newModule := mappedModule(mapping, match[4])
+	if newModule == "" {
+		return line
+	}
+	return match[1] + match[2] + "import " + match[3] + newModule + match[5]
+}
+
+// importAttributes are the import attributes kept when rewriting imports
+var importAttributes = []string{"@testable", "@_implementationOnly", "@_exported", "@preconcurrency"}
+
+// importRewriter returns the rewriter used by UpdateImports: the helper's own rules, then the mapping rules
+func (m *MigrationHelper) importRewriter(moduleMapping map[string]string) *ImportRewriter {
+	rules := append([]Rule{}, m.ImportRules...)
+	rules = append(rules,
+		SimpleMapRule{Mapping: moduleMapping},
+		AttributePreservingRule{Mapping: moduleMapping, Attributes: importAttributes},
+	)
+	return NewImportRewriter(rules)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// writeFileFunc writes every file a migration creates or modifies; the integration tests replace it to inject
+// write errors
+var writeFileFunc = ioutil.WriteFile
+
+// readFileFunc reads the files a migration copies and rewrites; the integration tests replace it to inject
+// read errors
+var readFileFunc = ioutil.ReadFile
+
+// migrationManifest records what a migration wrote, so it can be undone
+type migrationManifest struct {
+	createdFiles []string          // In creation order
+	createdDirs  []string          // Parents before children
+	backups      map[string][]byte // Content of modified files before the first write
+	seen         map[string]bool   // Files and directories already recorded
+}
+
+// newMigrationManifest creates an empty manifest
+func newMigrationManifest() *migrationManifest {
+	return &migrationManifest{
+		backups: make(map[string][]byte),
+		seen:    make(map[string]bool),
+	}
+}
+
+// recordDir records the directories that creating dir would create
+func (r *migrationManifest) recordDir(dir string) {
+	missing := []string{}
+	for d := dir; !r.seen[d] && !dirExists(d); d = filepath.Dir(d) {
+		missing = append(missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		r.seen[missing[i]] = true
+		r.createdDirs = append(r.createdDirs, missing[i])
+	}
+}
+
+// recordFile records that path is about to be written, backing it up the first time if it exists
+func (r *migrationManifest) recordFile(path string) error {
+	if r.seen[path] {
+		return nil
+	}
+	r.recordDir(filepath.Dir(path))
+
+	content, err := ioutil.ReadFile(path)
+	switch {
+	case err == nil:
+		r.backups[path] = content
+	case os.IsNotExist(err):
+		r.createdFiles = append(r.createdFiles, path)
+	default:
+		return fmt.Errorf("error backing up %s: %v", path, err)
+	}
+	r.seen[path] = true
+	return nil
+}
+
+// mkdirAll creates dir and its parents, recording them in the migration's manifest
+func (m *MigrationHelper) mkdirAll(dir string) error {
+	if m.manifest != nil {
+		m.mu.Lock()
+		m.manifest.recordDir(dir)
+		m.mu.Unlock()
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+// rollbackMigration undoes a failed migration: it deletes the files and directories it created and restores
+// the files it modified. It carries on past errors and returns the first.
+func rollbackMigration(manifest *migrationManifest) error {
+	var firstErr error
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for i := len(manifest.createdFiles) - 1; i >= 0; i-- {
+		if err := os.Remove(manifest.createdFiles[i]); err != nil && !os.IsNotExist(err) {
+			fail(fmt.Errorf("error removing %s: %v", manifest.createdFiles[i], err))
+		}
+	}
+	for path, content := range manifest.backups {
+		if err := ioutil.WriteFile(path, content, 0644); err != nil {
+			fail(fmt.Errorf("error restoring %s: %v", path, err))
+		}
+	}
+	// Children are removed before their parents; a directory something else has written to is kept
+	for i := len(manifest.createdDirs) - 1; i >= 0; i-- {
+		if err := os.Remove(manifest.createdDirs[i]); err != nil && !os.IsNotExist(err) {
+			fail(fmt.Errorf("error removing %s: %v", manifest.createdDirs[i], err))
+		}
+	}
+
+	if firstErr == nil {
+		fmt.Printf("↩️ Rolled back the migration: removed %d files, restored %d files\n", len(manifest.createdFiles), len(manifest.backups))
+	}
+	return firstErr
+}
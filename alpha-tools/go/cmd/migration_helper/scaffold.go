@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/deprules"
+)
+
+// placeholderTemplateName is the file in a scaffolder's TemplateDir that overrides the placeholder Swift file
+const placeholderTemplateName = "Placeholder.swift.tmpl"
+
+// defaultPlaceholderTemplate is the placeholder Swift file written when no TemplateDir template exists
+const defaultPlaceholderTemplate = `/// {{.Module}} is part of the {{.Package}} package in the {{.Tier}} tier.
+///
+/// Placeholder so that {{.TargetPackage}} has at least one source file.
+/// Delete this file once real sources have been added.
+`
+
+// ScaffoldSpec describes a new package or subpackage to create
+type ScaffoldSpec struct {
+	PackageName       string   `json:"packageName"`                 // Top-level package, e.g. UmbraUtils
+	SubpackageName    string   `json:"subpackageName,omitempty"`    // Optional subpackage under Sources/, e.g. NetworkUtils
+	Tier              string   `json:"tier,omitempty"`              // Architectural tier; must appear in ValidDeps (defaults to PackageName)
+	InitialDeps       []string `json:"initialDeps,omitempty"`       // Bazel labels; the standard deps of the package are used if empty
+	CreatePlaceholder bool     `json:"createPlaceholder,omitempty"` // Write a placeholder Swift file, which the BUILD glob needs
+}
+
+// placeholderData is passed to the placeholder template
+type placeholderData struct {
+	Package       string
+	Subpackage    string
+	Module        string
+	Tier          string
+	TargetPackage string
+}
+
+// PackageScaffolder creates empty packages that follow the Alpha Dot Five layout
+type PackageScaffolder struct {
+	TargetDir      string
+	ValidDeps      deprules.ValidDependencyRuleSet
+	BuildGenerator BuildFileGenerator
+	TemplateDir    string // Optional directory with a Placeholder.swift.tmpl text/template
+}
+
+// NewPackageScaffolder creates a scaffolder that writes into targetDir and checks tiers against validDeps
+func NewPackageScaffolder(targetDir string, validDeps deprules.ValidDependencyRuleSet) *PackageScaffolder {
+	return &PackageScaffolder{TargetDir: targetDir, ValidDeps: validDeps}
+}
+
+// Scaffold creates the package directory, its BUILD.bazel files and optionally a placeholder Swift file.
+// The spec is validated before anything is written.
+func (s *PackageScaffolder) Scaffold(spec ScaffoldSpec) error {
+	if spec.PackageName == "" {
+		return fmt.Errorf("package must be specified")
+	}
+	if spec.Tier == "" {
+		spec.Tier = spec.PackageName
+	}
+
+	if known, _ := s.ValidDeps.IsComplete([]string{spec.Tier}); !known {
+		return fmt.Errorf("tier %s does not appear in the valid dependency rules", spec.Tier)
+	}
+	for _, dep := range spec.InitialDeps {
+		depPackage := strings.SplitN(strings.TrimPrefix(dep, "//packages/"), "/", 2)[0]
+		depPackage = strings.SplitN(depPackage, ":", 2)[0]
+		if strings.HasPrefix(dep, "//packages/") && depPackage != spec.Tier && !s.ValidDeps.Contains(spec.Tier, depPackage) {
+			return fmt.Errorf("dependency %s is not allowed for tier %s", dep, spec.Tier)
+		}
+	}
+
+	targetPackage := spec.PackageName
+	moduleName := spec.PackageName
+	if spec.SubpackageName != "" {
+		targetPackage = path.Join(spec.PackageName, spec.SubpackageName)
+		moduleName = path.Base(spec.SubpackageName)
+	}
+
+	packageDir := filepath.Join(s.TargetDir, spec.PackageName)
+	moduleDir := filepath.Join(packageDir, "Sources", filepath.FromSlash(spec.SubpackageName))
+	if dirHasSwiftFiles(moduleDir) {
+		return fmt.Errorf("%s already contains Swift sources", moduleDir)
+	}
+
+	placeholder := ""
+	if spec.CreatePlaceholder {
+		var err error
+		placeholder, err = s.renderPlaceholder(placeholderData{
+			Package:       spec.PackageName,
+			Subpackage:    spec.SubpackageName,
+			Module:        moduleName,
+			Tier:          spec.Tier,
+			TargetPackage: targetPackage,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// The package BUILD file is only created if missing; the subpackage BUILD file is always written
+	builds := map[string]BuildSpec{}
+	if !fileExists(filepath.Join(packageDir, "BUILD.bazel")) {
+		builds[packageDir] = BuildSpec{PackageName: spec.PackageName, Deps: defaultBuildDeps(spec.PackageName, "")}
+	}
+	if spec.SubpackageName != "" {
+		builds[moduleDir] = BuildSpec{PackageName: spec.PackageName, SubpackagePath: spec.SubpackageName, Deps: defaultBuildDeps(spec.PackageName, spec.SubpackageName)}
+	}
+	if len(spec.InitialDeps) > 0 {
+		dir := moduleDir
+		if spec.SubpackageName == "" {
+			dir = packageDir
+		}
+		buildSpec := builds[dir]
+		buildSpec.PackageName = spec.PackageName
+		buildSpec.SubpackagePath = spec.SubpackageName
+		buildSpec.Deps = spec.InitialDeps
+		builds[dir] = buildSpec
+	}
+
+	contents := map[string]string{}
+	for dir, buildSpec := range builds {
+		content, err := s.BuildGenerator.Generate(buildSpec)
+		if err != nil {
+			return err
+		}
+		contents[filepath.Join(dir, "BUILD.bazel")] = content
+	}
+	if placeholder != "" {
+		contents[filepath.Join(moduleDir, "Placeholder.swift")] = placeholder
+	}
+
+	if err := os.MkdirAll(moduleDir, 0755); err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+	files := make([]string, 0, len(contents))
+	for file := range contents {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if err := ioutil.WriteFile(file, []byte(contents[file]), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", file, err)
+		}
+	}
+
+	fmt.Printf("✅ Scaffolded %s in %s\n", targetPackage, moduleDir)
+	fmt.Printf("   Bazel target: %s\n", scaffoldLabel(spec))
+	if !spec.CreatePlaceholder {
+		fmt.Println("   The BUILD glob does not allow an empty srcs list; add a Swift file before building.")
+	}
+	return nil
+}
+
+// ScaffoldMany scaffolds each spec in order and returns one error per spec, nil for those that succeeded
+func (s *PackageScaffolder) ScaffoldMany(specs []ScaffoldSpec) []error {
+	errs := make([]error, len(specs))
+	for i, spec := range specs {
+		errs[i] = s.Scaffold(spec)
+	}
+	return errs
+}
+
+// renderPlaceholder renders the placeholder Swift file from the TemplateDir template or the default
+func (s *PackageScaffolder) renderPlaceholder(data placeholderData) (string, error) {
+	text := defaultPlaceholderTemplate
+	if s.TemplateDir != "" {
+		templatePath := filepath.Join(s.TemplateDir, placeholderTemplateName)
+		content, err := ioutil.ReadFile(templatePath)
+		if err == nil {
+			text = string(content)
+		} else if !os.IsNotExist(err) {
+			return "", fmt.Errorf("error reading %s: %v", templatePath, err)
+		}
+	}
+
+	tmpl, err := template.New(placeholderTemplateName).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("error parsing placeholder template: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering placeholder template: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// scaffoldLabel returns the Bazel label of a scaffolded package
+func scaffoldLabel(spec ScaffoldSpec) string {
+	if spec.SubpackageName == "" {
+		return fmt.Sprintf("//packages/%s:%s", spec.PackageName, spec.PackageName)
+	}
+	return fmt.Sprintf("//packages/%s/Sources/%s:%s", spec.PackageName, spec.SubpackageName, path.Base(spec.SubpackageName))
+}
+
+// runScaffold implements the scaffold subcommand
+func runScaffold(args []string) error {
+	fs := flag.NewFlagSet("scaffold", flag.ExitOnError)
+	targetFlag := fs.String("target", "packages", "Target directory for new packages")
+	packageFlag := fs.String("package", "", "Top-level package to create or extend (e.g., UmbraUtils)")
+	subpackageFlag := fs.String("subpackage", "", "Subpackage to create under Sources/ (e.g., NetworkUtils)")
+	tierFlag := fs.String("tier", "", "Tier the package belongs to (defaults to --package)")
+	depsFlag := fs.String("deps", "", "Comma-separated dependency labels (defaults to the package's standard dependencies)")
+	placeholderFlag := fs.Bool("placeholder", true, "Write a placeholder Swift file so the package builds")
+	templateDirFlag := fs.String("template-dir", "", "Directory with a Placeholder.swift.tmpl overriding the placeholder file")
+	specsFlag := fs.String("specs", "", "JSON file with a list of scaffold specs to create instead of --package")
+	fs.Parse(args)
+
+	targetDir, err := filepath.Abs(*targetFlag)
+	if err != nil {
+		return fmt.Errorf("error getting absolute path: %v", err)
+	}
+
+	scaffolder := NewPackageScaffolder(targetDir, deprules.Default())
+	scaffolder.TemplateDir = *templateDirFlag
+
+	if *specsFlag != "" {
+		content, err := ioutil.ReadFile(*specsFlag)
+		if err != nil {
+			return fmt.Errorf("error reading specs: %v", err)
+		}
+		var specs []ScaffoldSpec
+		if err := json.Unmarshal(content, &specs); err != nil {
+			return fmt.Errorf("error parsing specs %s: %v", *specsFlag, err)
+		}
+
+		failed := 0
+		for i, err := range scaffolder.ScaffoldMany(specs) {
+			if err != nil {
+				fmt.Printf("❌ %s: %v\n", path.Join(specs[i].PackageName, specs[i].SubpackageName), err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d specs failed", failed, len(specs))
+		}
+		return nil
+	}
+
+	return scaffolder.Scaffold(ScaffoldSpec{
+		PackageName:       *packageFlag,
+		SubpackageName:    *subpackageFlag,
+		Tier:              *tierFlag,
+		InitialDeps:       splitList(*depsFlag),
+		CreatePlaceholder: *placeholderFlag,
+	})
+}
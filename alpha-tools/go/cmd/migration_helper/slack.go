@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/notify"
+)
+
+// migrationSlackPayload describes the outcome of a module migration for a Slack notification
+func migrationSlackPayload(result MigrationResult) notify.SlackPayload {
+	if !result.Success {
+		details := fmt.Sprintf("Target package: `%s`\nFiles copied: %d", result.TargetPackage, result.FilesCopied)
+		if result.Error != "" {
+			details += fmt.Sprintf("\nError: %s", result.Error)
+		}
+		if len(result.APIChanges) > 0 {
+			details += fmt.Sprintf("\nPublic API changes: %d", len(result.APIChanges))
+		}
+		return notify.NewSlackPayload(fmt.Sprintf("❌ Migration of %s failed", result.Module), details)
+	}
+
+	return notify.NewSlackPayload(
+		fmt.Sprintf("✅ Migrated %s to %s", result.Module, result.TargetPackage),
+		fmt.Sprintf("Files copied: %d", result.FilesCopied),
+	)
+}
+
+// batchSlackPayload summarises the outcome of a -tier or -all migration for a Slack notification
+func batchSlackPayload(results []MigrationResult) notify.SlackPayload {
+	failed := []string{}
+	for _, result := range results {
+		if result.Success {
+			continue
+		}
+		line := fmt.Sprintf("• %s", result.Module)
+		if result.Error != "" {
+			line += fmt.Sprintf(": %s", result.Error)
+		} else if len(result.APIChanges) > 0 {
+			line += fmt.Sprintf(": %d public API changes", len(result.APIChanges))
+		}
+		failed = append(failed, line)
+	}
+
+	if len(failed) == 0 {
+		return notify.NewSlackPayload(fmt.Sprintf("✅ Migrated %d modules", len(results)))
+	}
+	return notify.NewSlackPayload(
+		fmt.Sprintf("❌ %d of %d module migrations failed", len(failed), len(results)),
+		strings.Join(failed, "\n"),
+	)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// importLinePattern matches Swift and Objective-C import lines, which migration is expected to rewrite
+var importLinePattern = regexp.MustCompile(`^\s*(?:(?:@\w+\s+)*import\s|#import\s|@import\s)`)
+
+// FileDiff describes unexpected differences between a source file and its migrated copy
+type FileDiff struct {
+	SourcePath string
+	TargetPath string
+	Diff       string
+}
+
+// CompareSources diffs each source file of a module with its migrated copy in targetPackage, ignoring
+// import lines, and returns the files with any other differences
+func (m *MigrationHelper) CompareSources(moduleName, targetPackage string) ([]FileDiff, error) {
+	sourceDir := m.FindModuleSourceDir(moduleName)
+	if sourceDir == "" {
+		return nil, fmt.Errorf("source module %s not found in %s", moduleName, strings.Join(m.SourceDirs, ", "))
+	}
+	sourceModulePath := filepath.Join(sourceDir, moduleName)
+	targetModulePath := m.TargetModulePath(targetPackage)
+
+	diffs := []FileDiff{}
+	err := filepath.Walk(sourceModulePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Only compare the files MigrateModule rewrites
+		if info.IsDir() {
+			if strings.Contains(path, "Tests") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !m.isSourceFile(path) || strings.HasSuffix(path, "Test.swift") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(sourceModulePath, path)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(targetModulePath, relPath)
+
+		sourceContent, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", path, err)
+		}
+		targetContent, err := ioutil.ReadFile(targetPath)
+		if os.IsNotExist(err) {
+			diffs = append(diffs, FileDiff{SourcePath: path, TargetPath: targetPath, Diff: "target file is missing"})
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading %s: %v", targetPath, err)
+		}
+
+		if diff := diffIgnoringImports(string(sourceContent), string(targetContent)); diff != "" {
+			diffs = append(diffs, FileDiff{SourcePath: path, TargetPath: targetPath, Diff: diff})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error comparing sources: %v", err)
+	}
+
+	return diffs, nil
+}
+
+// numberedLine is a line of a file with its 1-based line number
+type numberedLine struct {
+	number int
+	text   string
+}
+
+// nonImportLines returns the lines of content that are not import lines
+func nonImportLines(content string) []numberedLine {
+	lines := []numberedLine{}
+	for i, line := range strings.Split(content, "\n") {
+		if !importLinePattern.MatchString(line) {
+			lines = append(lines, numberedLine{i + 1, line})
+		}
+	}
+	return lines
+}
+
+// diffIgnoringImports returns a line diff of the non-import lines of two files, or "" if they match
+func diffIgnoringImports(source, target string) string {
+	a := nonImportLines(source)
+	b := nonImportLines(target)
+
+	// Longest common subsequence table, filled from the end
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i].text == b[j].text {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i].text == b[j].text:
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			sb.WriteString(fmt.Sprintf("+%d: %s\n", b[j].number, b[j].text))
+			j++
+		default:
+			sb.WriteString(fmt.Sprintf("-%d: %s\n", a[i].number, a[i].text))
+			i++
+		}
+	}
+
+	return sb.String()
+}
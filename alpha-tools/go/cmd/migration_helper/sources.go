@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// defaultSourceDir is used when no --source flag is given
+const defaultSourceDir = "Sources"
+
+// sourceDirsFlag collects the values of a repeatable --source flag
+type sourceDirsFlag []string
+
+// String returns the source directories as a comma-separated list
+func (s *sourceDirsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set adds a source directory
+func (s *sourceDirsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// absoluteSourceDirs resolves the source directories, defaulting to Sources
+func absoluteSourceDirs(dirs []string) ([]string, error) {
+	if len(dirs) == 0 {
+		dirs = []string{defaultSourceDir}
+	}
+
+	absDirs := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("error getting absolute path: %v", err)
+		}
+		absDirs = append(absDirs, absDir)
+	}
+	return absDirs, nil
+}
+
+// FindModuleSourceDir returns the first source directory containing moduleName, or "" if none does.
+// Source directories are searched in the order given, so a module in an earlier directory shadows
+// a module with the same name in a later one.
+func (m *MigrationHelper) FindModuleSourceDir(moduleName string) string {
+	for _, dir := range m.SourceDirs {
+		if dirExists(filepath.Join(dir, moduleName)) {
+			return dir
+		}
+	}
+	return ""
+}
+
+// sourceLabelPrefix returns the Bazel package prefix of a source directory, e.g. //Sources
+func (m *MigrationHelper) sourceLabelPrefix(dir string) string {
+	rel, err := filepath.Rel(m.WorkspaceRoot, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = filepath.Base(dir)
+	}
+	return "//" + filepath.ToSlash(rel)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SplitModule describes a source module whose files were migrated into more than one target package
+type SplitModule struct {
+	SourceModule     string
+	TargetPackages   []string
+	FileDistribution map[string][]string // Target package -> migrated files still present
+}
+
+// DetectSplitModules checks the migration journal in targetDir for modules from sourceDirs that were
+// migrated to more than one target package. Packages whose migrated files have since been removed are ignored.
+func DetectSplitModules(sourceDirs []string, targetDir string) ([]SplitModule, error) {
+	entries, err := readJournal(journalPath(targetDir))
+	if err != nil {
+		return nil, err
+	}
+
+	distribution := make(map[string]map[string][]string)
+	for _, entry := range entries {
+		if !entry.Success || (entry.SourceDir != "" && !contains(sourceDirs, entry.SourceDir)) {
+			continue
+		}
+		if _, exists := distribution[entry.Module]; !exists {
+			distribution[entry.Module] = make(map[string][]string)
+		}
+		for _, file := range entry.Files {
+			if !fileExists(filepath.Join(targetDir, filepath.FromSlash(file))) {
+				continue
+			}
+			if !contains(distribution[entry.Module][entry.TargetPackage], file) {
+				distribution[entry.Module][entry.TargetPackage] = append(distribution[entry.Module][entry.TargetPackage], file)
+			}
+		}
+	}
+
+	splits := []SplitModule{}
+	for module, byPackage := range distribution {
+		if len(byPackage) < 2 {
+			continue
+		}
+		split := SplitModule{SourceModule: module, FileDistribution: byPackage}
+		for targetPackage := range byPackage {
+			split.TargetPackages = append(split.TargetPackages, targetPackage)
+		}
+		sort.Strings(split.TargetPackages)
+		splits = append(splits, split)
+	}
+
+	sort.Slice(splits, func(i, j int) bool {
+		return splits[i].SourceModule < splits[j].SourceModule
+	})
+
+	return splits, nil
+}
+
+// checkForSplit fails if a module has already been migrated to a different target package
+func (m *MigrationHelper) checkForSplit(moduleName, targetPackage string) error {
+	entries, err := readJournal(journalPath(m.TargetDir))
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Module != moduleName || !entry.Success || entry.TargetPackage == targetPackage {
+			continue
+		}
+		if entry.SourceDir != "" && entry.SourceDir != m.FindModuleSourceDir(moduleName) {
+			continue
+		}
+
+		// Only a problem if files from the earlier migration are still there
+		for _, file := range entry.Files {
+			if fileExists(filepath.Join(m.TargetDir, filepath.FromSlash(file))) {
+				return fmt.Errorf("%s was already migrated to %s; migrating it to %s would split the module across packages", moduleName, entry.TargetPackage, targetPackage)
+			}
+		}
+	}
+
+	return nil
+}
+
+// printSplitModules prints split modules in a readable form
+func printSplitModules(splits []SplitModule) {
+	for _, split := range splits {
+		fmt.Printf("❌ %s is split across %s\n", split.SourceModule, strings.Join(split.TargetPackages, ", "))
+		for _, targetPackage := range split.TargetPackages {
+			files := split.FileDistribution[targetPackage]
+			fmt.Printf("   %s (%d files)\n", targetPackage, len(files))
+			for _, file := range files {
+				fmt.Printf("     • %s\n", file)
+			}
+		}
+	}
+}
+
+// runDetectSplits implements the detect-splits subcommand
+func runDetectSplits(args []string) error {
+	fs := flag.NewFlagSet("detect-splits", flag.ExitOnError)
+	var sourceFlag sourceDirsFlag
+	fs.Var(&sourceFlag, "source", "Source directory containing old modules (default Sources); repeatable")
+	targetFlag := fs.String("target", "packages", "Target directory for new packages")
+	fs.Parse(args)
+
+	sourceDirs, err := absoluteSourceDirs(sourceFlag)
+	if err != nil {
+		return err
+	}
+	targetDir, err := filepath.Abs(*targetFlag)
+	if err != nil {
+		return fmt.Errorf("error getting absolute path: %v", err)
+	}
+
+	splits, err := DetectSplitModules(sourceDirs, targetDir)
+	if err != nil {
+		return err
+	}
+
+	if len(splits) == 0 {
+		fmt.Println("✅ No modules are split across multiple target packages.")
+		return nil
+	}
+
+	printSplitModules(splits)
+	fmt.Printf("❌ Found %d split modules.\n", len(splits))
+	os.Exit(1)
+	return nil
+}
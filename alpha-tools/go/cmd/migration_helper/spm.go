@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SwiftTarget represents a target in a generated Package.swift manifest
+type SwiftTarget struct {
+	Name         string
+	Path         string
+	Dependencies []string
+}
+
+// GenerateSPMManifest writes a Package.swift for targetPackage with one target per mapped subpackage
+func (m *MigrationHelper) GenerateSPMManifest(targetPackage string, outputPath string) error {
+	// Packages this package may depend on according to the Alpha Dot Five rules
+	localDeps := []string{}
+	for _, validDep := range m.ValidDeps {
+		if validDep.Source == targetPackage && !contains(localDeps, validDep.Target) {
+			localDeps = append(localDeps, validDep.Target)
+		}
+	}
+	sort.Strings(localDeps)
+
+	// Collect one target per subpackage mapped under targetPackage
+	targets := []SwiftTarget{}
+	for _, mapping := range m.EffectiveMappings() {
+		if !strings.HasPrefix(mapping.TargetPackage, targetPackage+"/") {
+			continue
+		}
+		subpackage := strings.TrimPrefix(mapping.TargetPackage, targetPackage+"/")
+		targets = append(targets, SwiftTarget{
+			Name:         mapping.ImportModuleAs,
+			Path:         filepath.ToSlash(filepath.Join("Sources", subpackage)),
+			Dependencies: localDeps,
+		})
+	}
+
+	if len(targets) == 0 {
+		return fmt.Errorf("no mapped subpackages found under %s", targetPackage)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+
+	// Generate manifest content
+	var sb strings.Builder
+	sb.WriteString("// swift-tools-version: 5.9\n")
+	sb.WriteString("// Generated by migration_helper from the Alpha Dot Five package mappings. Do not edit.\n\n")
+	sb.WriteString("import PackageDescription\n\n")
+	sb.WriteString("let package = Package(\n")
+	sb.WriteString(fmt.Sprintf("  name: \"%s\",\n", targetPackage))
+	sb.WriteString("  platforms: [\n    .macOS(\"14.7\")\n  ],\n")
+
+	productTargets := make([]string, len(targets))
+	for i, target := range targets {
+		productTargets[i] = fmt.Sprintf("\"%s\"", target.Name)
+	}
+	sb.WriteString("  products: [\n")
+	sb.WriteString(fmt.Sprintf("    .library(name: \"%s\", targets: [%s])\n", targetPackage, strings.Join(productTargets, ", ")))
+	sb.WriteString("  ],\n")
+
+	sb.WriteString("  dependencies: [\n")
+	for i, dep := range localDeps {
+		sep := ","
+		if i == len(localDeps)-1 {
+			sep = ""
+		}
+		sb.WriteString(fmt.Sprintf("    .package(path: \"../%s\")%s\n", dep, sep))
+	}
+	sb.WriteString("  ],\n")
+
+	sb.WriteString("  targets: [\n")
+	for i, target := range targets {
+		deps := make([]string, len(target.Dependencies))
+		for j, dep := range target.Dependencies {
+			deps[j] = fmt.Sprintf(".product(name: \"%s\", package: \"%s\")", dep, dep)
+		}
+		sep := ","
+		if i == len(targets)-1 {
+			sep = ""
+		}
+		sb.WriteString("    .target(\n")
+		sb.WriteString(fmt.Sprintf("      name: \"%s\",\n", target.Name))
+		sb.WriteString(fmt.Sprintf("      dependencies: [%s],\n", strings.Join(deps, ", ")))
+		sb.WriteString(fmt.Sprintf("      path: \"%s\"\n", target.Path))
+		sb.WriteString(fmt.Sprintf("    )%s\n", sep))
+	}
+	sb.WriteString("  ]\n")
+	sb.WriteString(")\n")
+
+	// Create parent directories if needed
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+
+	if err := ioutil.WriteFile(outputPath, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("error writing manifest: %v", err)
+	}
+
+	fmt.Printf("Package.swift for %s written to %s (%d targets)\n", targetPackage, outputPath, len(targets))
+	return nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+)
+
+// MigrationState records the files each module's migration has copied, so a migration that was interrupted
+// can be resumed without copying them again
+type MigrationState struct {
+	ToolVersion string                  `json:"toolVersion"`
+	Modules     map[string]*ModuleState `json:"modules"`
+}
+
+// ModuleState is the state of one module's migration
+type ModuleState struct {
+	TargetPackage string               `json:"targetPackage"`
+	MigratedAt    time.Time            `json:"migratedAt"` // When the last file was copied
+	Files         map[string]FileState `json:"files"`      // By target path, relative to the target directory
+}
+
+// FileState records one copied file
+type FileState struct {
+	Source         string `json:"source"`
+	SourceChecksum string `json:"sourceChecksum"` // SHA-256 of the source when it was copied
+	TargetChecksum string `json:"targetChecksum"` // SHA-256 of the copy after its imports were rewritten
+}
+
+// toolVersion returns the module version and VCS revision the migration helper was built from
+func toolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && len(setting.Value) >= 12 {
+			version += "+" + setting.Value[:12]
+		}
+	}
+	return version
+}
+
+// LoadMigrationState reads a state file; a missing file is an empty state
+func LoadMigrationState(path string) (*MigrationState, error) {
+	state := &MigrationState{Modules: make(map[string]*ModuleState)}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading state file: %v", err)
+	}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("error parsing state file %s: %v", path, err)
+	}
+	if state.Modules == nil {
+		state.Modules = make(map[string]*ModuleState)
+	}
+	return state, nil
+}
+
+// Save writes the state to path atomically: to a temporary file in the same directory, which is then
+// renamed over path, so a crash leaves either the old or the new state
+func (s *MigrationState) Save(path string) error {
+	s.ToolVersion = toolVersion()
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding state: %v", err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary state file: %v", err)
+	}
+	defer os.Remove(tmp.Name()) // Fails harmlessly once renamed
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing state file: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing state file: %v", err)
+	}
+	return nil
+}
+
+// loadState reads the state file, if one is set, at the start of a module's migration
+func (m *MigrationHelper) loadState() error {
+	m.state = nil
+	if m.StateFile == "" || m.DryRun {
+		return nil
+	}
+	state, err := LoadMigrationState(m.StateFile)
+	if err != nil {
+		return err
+	}
+	m.state = state
+	return nil
+}
+
+// unchangedSinceState checks if the state records a copy of the job's source that is unchanged, and whose
+// target still has the content the copy left it with
+func (m *MigrationHelper) unchangedSinceState(job fileJob) bool {
+	if m.state == nil {
+		return false
+	}
+	rel, err := filepath.Rel(m.TargetDir, job.target)
+	if err != nil {
+		return false
+	}
+
+	m.mu.Lock()
+	module := m.state.Modules[job.module]
+	var file FileState
+	found := false
+	if module != nil && module.TargetPackage == job.targetPackage {
+		file, found = module.Files[filepath.ToSlash(rel)]
+	}
+	m.mu.Unlock()
+	if !found {
+		return false
+	}
+
+	sourceChecksum, err := fileChecksum(job.source)
+	if err != nil || sourceChecksum != file.SourceChecksum {
+		return false
+	}
+	targetChecksum, err := fileChecksum(job.target)
+	return err == nil && targetChecksum == file.TargetChecksum
+}
+
+// recordState adds a copied file to the state and saves it
+func (m *MigrationHelper) recordState(job fileJob) error {
+	if m.state == nil {
+		return nil
+	}
+	rel, err := filepath.Rel(m.TargetDir, job.target)
+	if err != nil {
+		return err
+	}
+	sourceChecksum, err := fileChecksum(job.source)
+	if err != nil {
+		return fmt.Errorf("error recording state: %v", err)
+	}
+	targetChecksum, err := fileChecksum(job.target)
+	if err != nil {
+		return fmt.Errorf("error recording state: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	module := m.state.Modules[job.module]
+	if module == nil || module.TargetPackage != job.targetPackage {
+		module = &ModuleState{TargetPackage: job.targetPackage, Files: make(map[string]FileState)}
+		m.state.Modules[job.module] = module
+	}
+	module.MigratedAt = time.Now().UTC()
+	module.Files[filepath.ToSlash(rel)] = FileState{
+		Source:         job.source,
+		SourceChecksum: sourceChecksum,
+		TargetChecksum: targetChecksum,
+	}
+	return m.state.Save(m.StateFile)
+}
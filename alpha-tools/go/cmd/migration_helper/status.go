@@ -0,0 +1,256 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/workspace"
+)
+
+// ModuleStatus describes the migration progress of a single source module
+type ModuleStatus struct {
+	Module        string     `json:"module"`
+	Tier          string     `json:"tier"`
+	Status        string     `json:"status"` // "migrated", "failed" or "pending"
+	Files         int        `json:"files"`
+	MigratedOn    *time.Time `json:"migratedOn,omitempty"`
+	TargetPackage string     `json:"targetPackage,omitempty"`
+}
+
+// MigrationStatus summarizes migration progress across all source modules
+type MigrationStatus struct {
+	Modules  []ModuleStatus `json:"modules"`
+	Migrated int            `json:"migrated"`
+	Total    int            `json:"total"`
+}
+
+// unmappedTier groups source modules without a package mapping
+const unmappedTier = "Unmapped"
+
+// CollectMigrationStatus combines the modules in SourceDirs with the journal to report migration progress.
+// A module name found in more than one source directory is reported once, for the first directory.
+func (m *MigrationHelper) CollectMigrationStatus() (MigrationStatus, error) {
+	status := MigrationStatus{Modules: []ModuleStatus{}}
+
+	entries, err := readJournal(journalPath(m.TargetDir))
+	if err != nil {
+		return status, err
+	}
+
+	// The latest journal entry for each module of each source directory wins
+	latest := make(map[string]JournalEntry)
+	for _, entry := range entries {
+		latest[filepath.Join(entry.SourceDir, entry.Module)] = entry
+	}
+
+	type sourceModule struct {
+		dir  string
+		name string
+	}
+	sourceModules := []sourceModule{}
+	seen := make(map[string]bool)
+	scanner := workspace.NewWorkspaceScanner()
+	for _, sourceDir := range m.SourceDirs {
+		modules, err := scanner.Scan(sourceDir)
+		if err != nil {
+			return status, err
+		}
+		for _, module := range modules {
+			if seen[module.Name] {
+				continue
+			}
+			seen[module.Name] = true
+			sourceModules = append(sourceModules, sourceModule{sourceDir, module.Name})
+		}
+	}
+
+	for _, dirEntry := range sourceModules {
+
+		module := ModuleStatus{
+			Module: dirEntry.name,
+			Tier:   unmappedTier,
+			Status: "pending",
+		}
+		if mapping := m.GetTargetMapping(module.Module); mapping != nil {
+			module.Tier = strings.SplitN(mapping.TargetPackage, "/", 2)[0]
+			module.TargetPackage = mapping.TargetPackage
+		}
+
+		if entry, exists := latest[filepath.Join(dirEntry.dir, module.Module)]; exists {
+			module.Status = "failed"
+			if entry.Success {
+				module.Status = "migrated"
+				status.Migrated++
+			}
+			module.Files = len(entry.Files)
+			module.TargetPackage = entry.TargetPackage
+			migratedOn := entry.Timestamp
+			module.MigratedOn = &migratedOn
+		}
+
+		status.Modules = append(status.Modules, module)
+	}
+	status.Total = len(status.Modules)
+
+	// Order tiers as they appear in the mappings, with unmapped modules last
+	tierOrder := make(map[string]int)
+	for _, mapping := range m.DefaultMappings {
+		tier := strings.SplitN(mapping.TargetPackage, "/", 2)[0]
+		if _, exists := tierOrder[tier]; !exists {
+			tierOrder[tier] = len(tierOrder)
+		}
+	}
+	tierOrder[unmappedTier] = len(tierOrder)
+
+	sort.SliceStable(status.Modules, func(i, j int) bool {
+		a, b := status.Modules[i], status.Modules[j]
+		if a.Tier != b.Tier {
+			return tierOrder[a.Tier] < tierOrder[b.Tier]
+		}
+		return a.Module < b.Module
+	})
+
+	return status, nil
+}
+
+// printMigrationStatus prints migration progress as a table grouped by tier
+func printMigrationStatus(status MigrationStatus, pendingOnly bool) {
+	icons := map[string]string{"migrated": "✅", "failed": "❌", "pending": "⚠️"}
+
+	fmt.Printf("%-40s %-6s %5s  %-10s  %s\n", "Module", "Status", "Files", "MigratedOn", "TargetPackage")
+
+	tier := ""
+	for _, module := range status.Modules {
+		if pendingOnly && module.Status == "migrated" {
+			continue
+		}
+		if module.Tier != tier {
+			tier = module.Tier
+			fmt.Printf("\n%s\n", tier)
+		}
+
+		migratedOn := "-"
+		if module.MigratedOn != nil {
+			migratedOn = module.MigratedOn.Format("2006-01-02")
+		}
+		targetPackage := module.TargetPackage
+		if targetPackage == "" {
+			targetPackage = "-"
+		}
+
+		// Icons are two columns wide, so pad them by hand rather than by byte count
+		fmt.Printf("  %-38s %s     %5d  %-10s  %s\n", module.Module, icons[module.Status], module.Files, migratedOn, targetPackage)
+	}
+
+	percent := 0.0
+	if status.Total > 0 {
+		percent = float64(status.Migrated) * 100 / float64(status.Total)
+	}
+	fmt.Printf("\n%d/%d modules migrated (%.0f%%)\n", status.Migrated, status.Total, percent)
+}
+
+// printMigrationStatusMarkdown prints migration progress as a GitHub Flavored Markdown table
+func printMigrationStatusMarkdown(status MigrationStatus, pendingOnly bool) {
+	icons := map[string]string{"migrated": "✅", "failed": "❌", "pending": "⚠️"}
+
+	fmt.Println("| Tier | Module | Status | Files | MigratedOn | TargetPackage |")
+	fmt.Println("| --- | --- | :---: | ---: | --- | --- |")
+	for _, module := range status.Modules {
+		if pendingOnly && module.Status == "migrated" {
+			continue
+		}
+
+		migratedOn := ""
+		if module.MigratedOn != nil {
+			migratedOn = module.MigratedOn.Format("2006-01-02")
+		}
+		fmt.Printf("| %s | `%s` | %s | %d | %s | %s |\n", module.Tier, module.Module, icons[module.Status], module.Files, migratedOn, module.TargetPackage)
+	}
+
+	percent := 0.0
+	if status.Total > 0 {
+		percent = float64(status.Migrated) * 100 / float64(status.Total)
+	}
+	fmt.Printf("\n**%d/%d modules migrated (%.0f%%)**\n", status.Migrated, status.Total, percent)
+}
+
+// printMigrationStatusTSV prints one tab-separated MODULE, STATUS, FILES, MIGRATED_ON, TARGET_PACKAGE row per module
+func printMigrationStatusTSV(status MigrationStatus, pendingOnly, headers bool) {
+	if headers {
+		fmt.Println(strings.Join([]string{"MODULE", "STATUS", "FILES", "MIGRATED_ON", "TARGET_PACKAGE"}, "\t"))
+	}
+	for _, module := range status.Modules {
+		if pendingOnly && module.Status == "migrated" {
+			continue
+		}
+
+		migratedOn := ""
+		if module.MigratedOn != nil {
+			migratedOn = module.MigratedOn.Format("2006-01-02")
+		}
+		fmt.Println(strings.Join([]string{module.Module, module.Status, strconv.Itoa(module.Files), migratedOn, module.TargetPackage}, "\t"))
+	}
+}
+
+// runStatus implements the status subcommand
+func runStatus(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	var sourceFlag sourceDirsFlag
+	fs.Var(&sourceFlag, "source", "Source directory containing old modules (default Sources); repeatable")
+	targetFlag := fs.String("target", "packages", "Target directory for new packages")
+	format := "table"
+	fs.StringVar(&format, "status-format", format, "Output format: table, json, markdown or tsv")
+	fs.StringVar(&format, "output-format", format, "Alias for --status-format")
+	pendingOnlyFlag := fs.Bool("pending-only", false, "Only show modules that have not been migrated")
+	tsvHeadersFlag := fs.Bool("tsv-headers", false, "Print a header row with --status-format tsv")
+	fs.Parse(args)
+
+	sourceDirs, err := absoluteSourceDirs(sourceFlag)
+	if err != nil {
+		return err
+	}
+	targetDir, err := filepath.Abs(*targetFlag)
+	if err != nil {
+		return fmt.Errorf("error getting absolute path: %v", err)
+	}
+
+	migrator := NewMigrationHelper(sourceDirs, targetDir, filepath.Dir(sourceDirs[0]))
+	status, err := migrator.CollectMigrationStatus()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "table":
+		printMigrationStatus(status, *pendingOnlyFlag)
+	case "markdown":
+		printMigrationStatusMarkdown(status, *pendingOnlyFlag)
+	case "tsv":
+		printMigrationStatusTSV(status, *pendingOnlyFlag, *tsvHeadersFlag)
+	case "json":
+		if *pendingOnlyFlag {
+			pending := []ModuleStatus{}
+			for _, module := range status.Modules {
+				if module.Status != "migrated" {
+					pending = append(pending, module)
+				}
+			}
+			status.Modules = pending
+		}
+		content, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding status: %v", err)
+		}
+		fmt.Println(string(content))
+	default:
+		return fmt.Errorf("unknown status format %q (expected table, json, markdown or tsv)", format)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+)
+
+// testableImportPattern matches `@testable import Module` statements
+var testableImportPattern = regexp.MustCompile(`@testable\s+import\s+(\w+)`)
+
+// findTestableImports returns the modules imported with @testable in Swift source
+func findTestableImports(content string) []string {
+	modules := []string{}
+	for _, match := range testableImportPattern.FindAllStringSubmatch(content, -1) {
+		if !contains(modules, match[1]) {
+			modules = append(modules, match[1])
+		}
+	}
+	sort.Strings(modules)
+	return modules
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MigratePackageTier migrates every module mapped into a top-level package, dependencies first, and returns a
+// report of the outcome. Modules whose sources are not found are skipped; a failed module does not stop the
+// remaining ones. A dependency cycle between modules of the tier is an error.
+func (m *MigrationHelper) MigratePackageTier(tier string, skipDependencyCheck bool) (*MigrationReport, error) {
+	mappings := []PackageMapping{}
+	skipped := []string{}
+	for _, mapping := range m.EffectiveMappings() {
+		if packageName, _ := splitTargetPackage(mapping.TargetPackage); packageName != tier {
+			continue
+		}
+		if m.FindModuleSourceDir(mapping.SourceModule) == "" {
+			fmt.Printf("ℹ️ Skipping %s: source module not found in %s\n", mapping.SourceModule, strings.Join(m.SourceDirs, ", "))
+			skipped = append(skipped, mapping.SourceModule)
+			continue
+		}
+		mappings = append(mappings, mapping)
+	}
+	if len(mappings) == 0 && len(skipped) == 0 {
+		return nil, fmt.Errorf("no modules are mapped to %s", tier)
+	}
+
+	// Dependencies are only known when they are checked
+	stats := GraphStats{Modules: len(mappings)}
+	deps := make(map[string][]string)
+	if !skipDependencyCheck {
+		for _, mapping := range mappings {
+			moduleDeps, err := m.moduleDependencies(mapping.SourceModule)
+			if err != nil {
+				return nil, err
+			}
+			for _, dep := range moduleDeps {
+				depMapping := m.GetTargetMapping(dep)
+				if depMapping == nil {
+					continue
+				}
+				deps[mapping.SourceModule] = append(deps[mapping.SourceModule], dep)
+				stats.Dependencies++
+				if depPackage, _ := splitTargetPackage(depMapping.TargetPackage); depPackage != tier {
+					stats.CrossPackageDependencies++
+					if !m.ValidDeps.Contains(tier, depPackage) {
+						stats.InvalidDependencies++
+					}
+				}
+			}
+		}
+	}
+
+	// Modules of other tiers are not in mappings, so only dependencies within the tier order the migration
+	ordered, cycle := dependencyOrder(mappings, deps)
+	if cycle != nil {
+		fmt.Printf("❌ Dependency cycle: %s\n", strings.Join(cycle, " -> "))
+		return nil, fmt.Errorf("cannot order modules with a dependency cycle between %s", strings.Join(cycle[1:], ", "))
+	}
+
+	firstResult := len(m.Results)
+	for _, mapping := range ordered {
+		fmt.Printf("\n=== Migrating %s to %s ===\n", mapping.SourceModule, mapping.TargetPackage)
+		success, err := m.MigrateModule(mapping.SourceModule, mapping.TargetPackage, skipDependencyCheck)
+		if err != nil {
+			fmt.Printf("❌ Error migrating %s: %v\n", mapping.SourceModule, err)
+			continue
+		}
+		if m.VerifyAPI && success && !m.DryRun {
+			if _, err := m.verifyPublicAPI(mapping.SourceModule, mapping.TargetPackage); err != nil {
+				fmt.Printf("❌ Error verifying the public API of %s: %v\n", mapping.SourceModule, err)
+			}
+		}
+	}
+
+	return NewMigrationReport(m.WorkspaceRoot, m.Results[firstResult:], skipped, stats), nil
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mpy/umbracore/alpha-tools/pkg/deprules"
+	"github.com/mpy/umbracore/alpha-tools/pkg/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+// minBuildifierVersion is the oldest buildifier known to format generated BUILD files correctly
+const minBuildifierVersion = "6.0.0"
+
+var (
+	// swiftIdentifierPattern matches a valid Swift module name
+	swiftIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// buildifierVersionPattern matches the version line printed by buildifier --version
+	buildifierVersionPattern = regexp.MustCompile(`buildifier version:\s*v?(\d+(?:\.\d+)*)`)
+)
+
+// preflightCheck is a single named check run by the validate subcommand
+type preflightCheck struct {
+	name string
+	run  func() ([]string, error)
+}
+
+// ValidateMappings checks that every mapping is complete and targets a known top-level package
+func (m *MigrationHelper) ValidateMappings() []string {
+	findings := []string{}
+	for _, mapping := range m.DefaultMappings {
+		if mapping.SourceModule == "" || mapping.TargetPackage == "" {
+			findings = append(findings, fmt.Sprintf("mapping %+v must set both SourceModule and TargetPackage", mapping))
+			continue
+		}
+		packageName, _ := splitTargetPackage(mapping.TargetPackage)
+		if known, _ := m.ValidDeps.IsComplete([]string{packageName}); !known {
+			findings = append(findings, fmt.Sprintf("%s maps to unknown package %s", mapping.SourceModule, packageName))
+		}
+		if !swiftIdentifierPattern.MatchString(mapping.ImportModuleAs) {
+			findings = append(findings, fmt.Sprintf("%s is imported as %q, which is not a valid Swift module name", mapping.SourceModule, mapping.ImportModuleAs))
+		}
+	}
+	return findings
+}
+
+// DetectConflicts returns the mappings that share a source module or a target package
+func (m *MigrationHelper) DetectConflicts() []string {
+	findings := []string{}
+	for _, conflict := range m.Conflicts {
+		findings = append(findings, conflict.String())
+	}
+	return findings
+}
+
+// DetectStaleMappings finds mappings whose source module neither exists in SourceDirs nor has been migrated
+func (m *MigrationHelper) DetectStaleMappings() ([]string, error) {
+	missing := []string{}
+	for _, sourceDir := range m.SourceDirs {
+		if !dirExists(sourceDir) {
+			missing = append(missing, fmt.Sprintf("source directory %s does not exist", sourceDir))
+		}
+	}
+	if len(missing) > 0 {
+		return missing, nil
+	}
+
+	entries, err := readJournal(journalPath(m.TargetDir))
+	if err != nil {
+		return nil, err
+	}
+
+	migrated := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Success {
+			migrated[entry.Module] = true
+		}
+	}
+
+	findings := []string{}
+	for _, mapping := range m.EffectiveMappings() {
+		if m.FindModuleSourceDir(mapping.SourceModule) == "" && !migrated[mapping.SourceModule] {
+			findings = append(findings, fmt.Sprintf("%s -> %s: source module not found in %s", mapping.SourceModule, mapping.TargetPackage, strings.Join(m.SourceDirs, ", ")))
+		}
+	}
+	return findings, nil
+}
+
+// DetectNamespaceCollisions finds mappings that would produce the same Swift module name for different packages,
+// or a new module name that is still the name of a different source module
+func (m *MigrationHelper) DetectNamespaceCollisions() []string {
+	findings := []string{}
+
+	effective := m.EffectiveMappings()
+
+	byImport := make(map[string]PackageMapping)
+	for _, mapping := range effective {
+		if existing, exists := byImport[mapping.ImportModuleAs]; exists && existing.TargetPackage != mapping.TargetPackage {
+			findings = append(findings, fmt.Sprintf("%s and %s are both imported as %s", existing.SourceModule, mapping.SourceModule, mapping.ImportModuleAs))
+			continue
+		}
+		byImport[mapping.ImportModuleAs] = mapping
+	}
+
+	for _, mapping := range effective {
+		if mapping.ImportModuleAs == mapping.SourceModule {
+			continue
+		}
+		if other := m.GetTargetMapping(mapping.ImportModuleAs); other != nil && other.SourceModule != mapping.SourceModule {
+			findings = append(findings, fmt.Sprintf("%s is imported as %s, which is also the source module mapped to %s", mapping.SourceModule, mapping.ImportModuleAs, other.TargetPackage))
+		}
+	}
+
+	return findings
+}
+
+// checkBuildifierVersion checks that buildifier is installed and recent enough
+func checkBuildifierVersion() ([]string, error) {
+	output, err := exec.Command("buildifier", "--version").CombinedOutput()
+	if err != nil {
+		return []string{fmt.Sprintf("buildifier is not available: %v", err)}, nil
+	}
+
+	match := buildifierVersionPattern.FindStringSubmatch(string(output))
+	if match == nil {
+		return []string{fmt.Sprintf("could not determine buildifier version from %q", strings.TrimSpace(string(output)))}, nil
+	}
+
+	if compareVersions(match[1], minBuildifierVersion) < 0 {
+		return []string{fmt.Sprintf("buildifier %s is older than the required %s", match[1], minBuildifierVersion)}, nil
+	}
+	return nil, nil
+}
+
+// compareVersions compares dotted version strings numerically
+func compareVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+		if aNum != bNum {
+			if aNum < bNum {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// DetectRulesDrift compares the helper's dependency rules with those the dependency analyzer applies with
+// the given config, which are the built-in rules plus the config's rules
+func (m *MigrationHelper) DetectRulesDrift(analyzerConfigPath string) ([]string, error) {
+	content, err := ioutil.ReadFile(analyzerConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading analyzer config: %v", err)
+	}
+
+	// Only the rules list matters here; a config without one adds nothing to the built-in rules
+	var config struct {
+		Rules deprules.ValidDependencyRuleSet `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("error parsing analyzer config %s: %v", analyzerConfigPath, err)
+	}
+	analyzerRules := append(deprules.Default(), config.Rules...)
+
+	onlyHelper, onlyAnalyzer := deprules.CompareValidDepsLists(m.ValidDeps, analyzerRules)
+	findings := []string{}
+	for _, rule := range onlyHelper {
+		findings = append(findings, fmt.Sprintf("rule %s is only in the migration helper rules", rule.Key()))
+	}
+	for _, rule := range onlyAnalyzer {
+		findings = append(findings, fmt.Sprintf("rule %s is only in the analyzer rules with %s", rule.Key(), analyzerConfigPath))
+	}
+	return findings, nil
+}
+
+// runValidate implements the validate subcommand
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	var sourceFlag sourceDirsFlag
+	fs.Var(&sourceFlag, "source", "Source directory containing old modules (default Sources); repeatable")
+	targetFlag := fs.String("target", "packages", "Target directory for new packages")
+	configFlag := fs.String("config", "", "JSON file with packageMappings and validDependencies lists")
+	configModeFlag := fs.String("config-mode", configModeMerge, "How -config combines with the built-in defaults: merge or replace")
+	mappingsFileFlag := fs.String("mappings-file", "", "JSON file with additional package mappings")
+	rulesFlag := fs.String("rules", "", "YAML file whose rules list replaces the built-in dependency rules")
+	analyzerConfigFlag := fs.String("analyzer-config", "", "dependency_analyzer config whose effective rules must match the migration rules")
+	skipCheckFlag := fs.String("skip-check", "", "Comma-separated checks to skip (mappings, conflicts, stale-mappings, namespace-collisions, glob-orphans, rules-drift, buildifier, workspace)")
+	fs.Parse(args)
+
+	sourceDirs, err := absoluteSourceDirs(sourceFlag)
+	if err != nil {
+		return err
+	}
+	targetDir, err := filepath.Abs(*targetFlag)
+	if err != nil {
+		return fmt.Errorf("error getting absolute path: %v", err)
+	}
+
+	migrator := NewMigrationHelper(sourceDirs, targetDir, filepath.Dir(sourceDirs[0]))
+	if err := migrator.loadConfigFile(*configFlag, *configModeFlag); err != nil {
+		return err
+	}
+	if *mappingsFileFlag != "" {
+		mappings, err := LoadMappingsFile(*mappingsFileFlag)
+		if err != nil {
+			return err
+		}
+		migrator.MergeMappings(mappings)
+	}
+	if *rulesFlag != "" {
+		if err := migrator.ValidDeps.LoadFromFile(*rulesFlag); err != nil {
+			return err
+		}
+	}
+
+	checks := []preflightCheck{
+		{"mappings", func() ([]string, error) { return migrator.ValidateMappings(), nil }},
+		{"conflicts", func() ([]string, error) { return migrator.DetectConflicts(), nil }},
+		{"stale-mappings", migrator.DetectStaleMappings},
+		{"namespace-collisions", func() ([]string, error) { return migrator.DetectNamespaceCollisions(), nil }},
+		{"glob-orphans", func() ([]string, error) {
+			if !dirExists(targetDir) {
+				return nil, nil // Nothing migrated yet
+			}
+			orphans, err := DetectGlobOrphans(targetDir)
+			if err != nil {
+				return nil, err
+			}
+			findings := []string{}
+			for _, orphan := range orphans {
+				findings = append(findings, fmt.Sprintf("%s is not matched by any srcs glob in its BUILD file", orphan))
+			}
+			return findings, nil
+		}},
+		{"rules-drift", func() ([]string, error) {
+			if *analyzerConfigFlag == "" {
+				return nil, nil
+			}
+			return migrator.DetectRulesDrift(*analyzerConfigFlag)
+		}},
+		{"buildifier", checkBuildifierVersion},
+		{"workspace", func() ([]string, error) {
+			if _, err := workspace.FindWorkspaceRoot(sourceDirs[0]); err != nil {
+				return []string{err.Error()}, nil
+			}
+			return nil, nil
+		}},
+	}
+
+	skipped := make(map[string]bool)
+	for _, name := range strings.Split(*skipCheckFlag, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		known := false
+		for _, check := range checks {
+			known = known || check.name == name
+		}
+		if !known {
+			return fmt.Errorf("unknown check %q", name)
+		}
+		skipped[name] = true
+	}
+
+	fmt.Println("Pre-flight checks:")
+	failed := 0
+	for _, check := range checks {
+		if skipped[check.name] {
+			fmt.Printf("ℹ️ %s: skipped\n", check.name)
+			continue
+		}
+
+		findings, err := check.run()
+		if err != nil {
+			findings = append(findings, err.Error())
+		}
+		if len(findings) == 0 {
+			fmt.Printf("✅ %s: passed\n", check.name)
+			continue
+		}
+
+		failed++
+		fmt.Printf("❌ %s: %d findings\n", check.name, len(findings))
+		for _, finding := range findings {
+			fmt.Printf("   - %s\n", finding)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("❌ %d of %d checks failed.\n", failed, len(checks)-len(skipped))
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ All checks passed.")
+	return nil
+}
@@ -1,3 +1,10 @@
 module github.com/mpy/umbracore/alpha-tools
 
 go 1.20
+
+require (
+	github.com/bazelbuild/buildtools v0.0.0-20231115204819-d4c9dccdfbb1
+	golang.org/x/sync v0.11.0
+	google.golang.org/protobuf v1.31.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,1135 @@
+// Messages exchanged between the alpha-tools, e.g. from the dependency analyzer to the migration helper or to
+// CI pipeline stages. The Go types in alpha-tools/go/pkg/alphapb are generated from this file; run go generate
+// there after changing it.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: alpha_tools.proto
+
+package alphapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A Bazel target as reported by bazelisk query
+type BazelTarget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rule              string   `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	Tag               []string `protobuf:"bytes,3,rep,name=tag,proto3" json:"tag,omitempty"`
+	Sources           []string `protobuf:"bytes,4,rep,name=sources,proto3" json:"sources,omitempty"`
+	Deps              []string `protobuf:"bytes,5,rep,name=deps,proto3" json:"deps,omitempty"`
+	Hdrs              []string `protobuf:"bytes,6,rep,name=hdrs,proto3" json:"hdrs,omitempty"`
+	ModuleName        string   `protobuf:"bytes,7,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	GeneratorFunction string   `protobuf:"bytes,8,opt,name=generator_function,json=generatorFunction,proto3" json:"generator_function,omitempty"`
+}
+
+func (x *BazelTarget) Reset() {
+	*x = BazelTarget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BazelTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BazelTarget) ProtoMessage() {}
+
+func (x *BazelTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BazelTarget.ProtoReflect.Descriptor instead.
+func (*BazelTarget) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BazelTarget) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BazelTarget) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *BazelTarget) GetTag() []string {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+func (x *BazelTarget) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *BazelTarget) GetDeps() []string {
+	if x != nil {
+		return x.Deps
+	}
+	return nil
+}
+
+func (x *BazelTarget) GetHdrs() []string {
+	if x != nil {
+		return x.Hdrs
+	}
+	return nil
+}
+
+func (x *BazelTarget) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *BazelTarget) GetGeneratorFunction() string {
+	if x != nil {
+		return x.GeneratorFunction
+	}
+	return ""
+}
+
+// The targets of one Bazel query
+type BazelQueryResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target []*BazelTarget `protobuf:"bytes,1,rep,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *BazelQueryResult) Reset() {
+	*x = BazelQueryResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BazelQueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BazelQueryResult) ProtoMessage() {}
+
+func (x *BazelQueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BazelQueryResult.ProtoReflect.Descriptor instead.
+func (*BazelQueryResult) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BazelQueryResult) GetTarget() []*BazelTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+// A dependency between two packages
+type DepEdge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Valid  bool   `protobuf:"varint,3,opt,name=valid,proto3" json:"valid,omitempty"`
+}
+
+func (x *DepEdge) Reset() {
+	*x = DepEdge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DepEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepEdge) ProtoMessage() {}
+
+func (x *DepEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepEdge.ProtoReflect.Descriptor instead.
+func (*DepEdge) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DepEdge) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *DepEdge) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *DepEdge) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+// Coupling, stability and size metrics of a package
+type PackageMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PackageName  string  `protobuf:"bytes,1,opt,name=package_name,json=packageName,proto3" json:"package_name,omitempty"`
+	Afferent     int64   `protobuf:"varint,2,opt,name=afferent,proto3" json:"afferent,omitempty"`
+	Efferent     int64   `protobuf:"varint,3,opt,name=efferent,proto3" json:"efferent,omitempty"`
+	Instability  float64 `protobuf:"fixed64,4,opt,name=instability,proto3" json:"instability,omitempty"`
+	Abstractness float64 `protobuf:"fixed64,5,opt,name=abstractness,proto3" json:"abstractness,omitempty"`
+	Distance     float64 `protobuf:"fixed64,6,opt,name=distance,proto3" json:"distance,omitempty"`
+	FanIn        int64   `protobuf:"varint,7,opt,name=fan_in,json=fanIn,proto3" json:"fan_in,omitempty"`
+	FanOut       int64   `protobuf:"varint,8,opt,name=fan_out,json=fanOut,proto3" json:"fan_out,omitempty"`
+	FileCount    int64   `protobuf:"varint,9,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	Loc          int64   `protobuf:"varint,10,opt,name=loc,proto3" json:"loc,omitempty"`
+	Grade        string  `protobuf:"bytes,11,opt,name=grade,proto3" json:"grade,omitempty"`
+}
+
+func (x *PackageMetrics) Reset() {
+	*x = PackageMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageMetrics) ProtoMessage() {}
+
+func (x *PackageMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageMetrics.ProtoReflect.Descriptor instead.
+func (*PackageMetrics) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PackageMetrics) GetPackageName() string {
+	if x != nil {
+		return x.PackageName
+	}
+	return ""
+}
+
+func (x *PackageMetrics) GetAfferent() int64 {
+	if x != nil {
+		return x.Afferent
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetEfferent() int64 {
+	if x != nil {
+		return x.Efferent
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetInstability() float64 {
+	if x != nil {
+		return x.Instability
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetAbstractness() float64 {
+	if x != nil {
+		return x.Abstractness
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetFanIn() int64 {
+	if x != nil {
+		return x.FanIn
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetFanOut() int64 {
+	if x != nil {
+		return x.FanOut
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetFileCount() int64 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetLoc() int64 {
+	if x != nil {
+		return x.Loc
+	}
+	return 0
+}
+
+func (x *PackageMetrics) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+// The package dependency graph at a point in time
+type DependencySnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version       string                     `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	CapturedAt    *timestamppb.Timestamp     `protobuf:"bytes,2,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	WorkspaceRoot string                     `protobuf:"bytes,3,opt,name=workspace_root,json=workspaceRoot,proto3" json:"workspace_root,omitempty"`
+	Packages      []string                   `protobuf:"bytes,4,rep,name=packages,proto3" json:"packages,omitempty"`
+	Edges         []*DepEdge                 `protobuf:"bytes,5,rep,name=edges,proto3" json:"edges,omitempty"`
+	Metrics       map[string]*PackageMetrics `protobuf:"bytes,6,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DependencySnapshot) Reset() {
+	*x = DependencySnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DependencySnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencySnapshot) ProtoMessage() {}
+
+func (x *DependencySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencySnapshot.ProtoReflect.Descriptor instead.
+func (*DependencySnapshot) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DependencySnapshot) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DependencySnapshot) GetCapturedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return nil
+}
+
+func (x *DependencySnapshot) GetWorkspaceRoot() string {
+	if x != nil {
+		return x.WorkspaceRoot
+	}
+	return ""
+}
+
+func (x *DependencySnapshot) GetPackages() []string {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *DependencySnapshot) GetEdges() []*DepEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *DependencySnapshot) GetMetrics() map[string]*PackageMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+// A change to a module's public API during migration
+type APIDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind     string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // "added", "removed" or "unchanged"
+	DeclName string `protobuf:"bytes,2,opt,name=decl_name,json=declName,proto3" json:"decl_name,omitempty"`
+	File     string `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+}
+
+func (x *APIDiff) Reset() {
+	*x = APIDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *APIDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIDiff) ProtoMessage() {}
+
+func (x *APIDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIDiff.ProtoReflect.Descriptor instead.
+func (*APIDiff) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *APIDiff) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *APIDiff) GetDeclName() string {
+	if x != nil {
+		return x.DeclName
+	}
+	return ""
+}
+
+func (x *APIDiff) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+// The outcome of migrating one module
+type MigrationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Module          string                 `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	TargetPackage   string                 `protobuf:"bytes,2,opt,name=target_package,json=targetPackage,proto3" json:"target_package,omitempty"`
+	Success         bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	FilesCopied     int64                  `protobuf:"varint,4,opt,name=files_copied,json=filesCopied,proto3" json:"files_copied,omitempty"`
+	Error           string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	CompletedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ApiChanges      []*APIDiff             `protobuf:"bytes,7,rep,name=api_changes,json=apiChanges,proto3" json:"api_changes,omitempty"`
+	TestableImports []string               `protobuf:"bytes,8,rep,name=testable_imports,json=testableImports,proto3" json:"testable_imports,omitempty"`
+}
+
+func (x *MigrationResult) Reset() {
+	*x = MigrationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MigrationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrationResult) ProtoMessage() {}
+
+func (x *MigrationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrationResult.ProtoReflect.Descriptor instead.
+func (*MigrationResult) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MigrationResult) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *MigrationResult) GetTargetPackage() string {
+	if x != nil {
+		return x.TargetPackage
+	}
+	return ""
+}
+
+func (x *MigrationResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *MigrationResult) GetFilesCopied() int64 {
+	if x != nil {
+		return x.FilesCopied
+	}
+	return 0
+}
+
+func (x *MigrationResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MigrationResult) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *MigrationResult) GetApiChanges() []*APIDiff {
+	if x != nil {
+		return x.ApiChanges
+	}
+	return nil
+}
+
+func (x *MigrationResult) GetTestableImports() []string {
+	if x != nil {
+		return x.TestableImports
+	}
+	return nil
+}
+
+// Dependency counts of the modules in a migration
+type GraphStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Modules                  int64 `protobuf:"varint,1,opt,name=modules,proto3" json:"modules,omitempty"`
+	Dependencies             int64 `protobuf:"varint,2,opt,name=dependencies,proto3" json:"dependencies,omitempty"`
+	CrossPackageDependencies int64 `protobuf:"varint,3,opt,name=cross_package_dependencies,json=crossPackageDependencies,proto3" json:"cross_package_dependencies,omitempty"`
+	InvalidDependencies      int64 `protobuf:"varint,4,opt,name=invalid_dependencies,json=invalidDependencies,proto3" json:"invalid_dependencies,omitempty"`
+}
+
+func (x *GraphStats) Reset() {
+	*x = GraphStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphStats) ProtoMessage() {}
+
+func (x *GraphStats) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphStats.ProtoReflect.Descriptor instead.
+func (*GraphStats) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GraphStats) GetModules() int64 {
+	if x != nil {
+		return x.Modules
+	}
+	return 0
+}
+
+func (x *GraphStats) GetDependencies() int64 {
+	if x != nil {
+		return x.Dependencies
+	}
+	return 0
+}
+
+func (x *GraphStats) GetCrossPackageDependencies() int64 {
+	if x != nil {
+		return x.CrossPackageDependencies
+	}
+	return 0
+}
+
+func (x *GraphStats) GetInvalidDependencies() int64 {
+	if x != nil {
+		return x.InvalidDependencies
+	}
+	return 0
+}
+
+// A summary of a multi-module migration
+type MigrationReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GeneratedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	WorkspaceCommit string                 `protobuf:"bytes,2,opt,name=workspace_commit,json=workspaceCommit,proto3" json:"workspace_commit,omitempty"`
+	TotalModules    int64                  `protobuf:"varint,3,opt,name=total_modules,json=totalModules,proto3" json:"total_modules,omitempty"`
+	Succeeded       int64                  `protobuf:"varint,4,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed          int64                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	Skipped         int64                  `protobuf:"varint,6,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	SkippedModules  []string               `protobuf:"bytes,7,rep,name=skipped_modules,json=skippedModules,proto3" json:"skipped_modules,omitempty"`
+	ModuleResults   []*MigrationResult     `protobuf:"bytes,8,rep,name=module_results,json=moduleResults,proto3" json:"module_results,omitempty"`
+	GraphStats      *GraphStats            `protobuf:"bytes,9,opt,name=graph_stats,json=graphStats,proto3" json:"graph_stats,omitempty"`
+}
+
+func (x *MigrationReport) Reset() {
+	*x = MigrationReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alpha_tools_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MigrationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrationReport) ProtoMessage() {}
+
+func (x *MigrationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_alpha_tools_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrationReport.ProtoReflect.Descriptor instead.
+func (*MigrationReport) Descriptor() ([]byte, []int) {
+	return file_alpha_tools_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MigrationReport) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *MigrationReport) GetWorkspaceCommit() string {
+	if x != nil {
+		return x.WorkspaceCommit
+	}
+	return ""
+}
+
+func (x *MigrationReport) GetTotalModules() int64 {
+	if x != nil {
+		return x.TotalModules
+	}
+	return 0
+}
+
+func (x *MigrationReport) GetSucceeded() int64 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *MigrationReport) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *MigrationReport) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *MigrationReport) GetSkippedModules() []string {
+	if x != nil {
+		return x.SkippedModules
+	}
+	return nil
+}
+
+func (x *MigrationReport) GetModuleResults() []*MigrationResult {
+	if x != nil {
+		return x.ModuleResults
+	}
+	return nil
+}
+
+func (x *MigrationReport) GetGraphStats() *GraphStats {
+	if x != nil {
+		return x.GraphStats
+	}
+	return nil
+}
+
+var File_alpha_tools_proto protoreflect.FileDescriptor
+
+var file_alpha_tools_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x10, 0x75, 0x6d, 0x62, 0x72, 0x61, 0x2e, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd9, 0x01, 0x0a, 0x0b, 0x42, 0x61, 0x7a, 0x65, 0x6c,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65,
+	0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x70, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x64, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x68, 0x64,
+	0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x49, 0x0a, 0x10, 0x42, 0x61, 0x7a, 0x65, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x35, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x75, 0x6d, 0x62, 0x72, 0x61, 0x2e, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x42, 0x61, 0x7a, 0x65, 0x6c, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x4f, 0x0a,
+	0x07, 0x44, 0x65, 0x70, 0x45, 0x64, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x22, 0xc4,
+	0x02, 0x0a, 0x0e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x65, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x22,
+	0x0a, 0x0c, 0x61, 0x62, 0x73, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x62, 0x73, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6e, 0x65,
+	0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x15,
+	0x0a, 0x06, 0x66, 0x61, 0x6e, 0x5f, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x66, 0x61, 0x6e, 0x49, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x61, 0x6e, 0x5f, 0x6f, 0x75, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x6e, 0x4f, 0x75, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6c, 0x6f, 0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6c, 0x6f, 0x63, 0x12,
+	0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x67, 0x72, 0x61, 0x64, 0x65, 0x22, 0x8a, 0x03, 0x0a, 0x12, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x6e, 0x63, 0x79, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x77, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x75, 0x6d, 0x62, 0x72, 0x61, 0x2e, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x44, 0x65, 0x70, 0x45, 0x64, 0x67, 0x65,
+	0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x4b, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x75, 0x6d, 0x62, 0x72, 0x61,
+	0x2e, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x44, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x1a, 0x5c, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x75, 0x6d, 0x62, 0x72, 0x61, 0x2e, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x4e, 0x0a, 0x07, 0x41, 0x50, 0x49, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12, 0x0a,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x63, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x22, 0xc9, 0x02, 0x0a, 0x0f, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x43, 0x6f, 0x70, 0x69,
+	0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3d, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x61, 0x70, 0x69, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x75,
+	0x6d, 0x62, 0x72, 0x61, 0x2e, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e,
+	0x41, 0x50, 0x49, 0x44, 0x69, 0x66, 0x66, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x65, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x74,
+	0x65, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0xbb,
+	0x01, 0x0a, 0x0a, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x1a, 0x63,
+	0x72, 0x6f, 0x73, 0x73, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x18, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x44, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x69, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x5f, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x22, 0xa2, 0x03, 0x0a,
+	0x0f, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x3d, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x29, 0x0a, 0x10, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x77, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12,
+	0x27, 0x0a, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x48, 0x0a, 0x0e, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x75, 0x6d, 0x62, 0x72, 0x61, 0x2e, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x74, 0x6f,
+	0x6f, 0x6c, 0x73, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x0d, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x75, 0x6d, 0x62, 0x72, 0x61, 0x2e,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x67, 0x72, 0x61, 0x70, 0x68, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6d, 0x70, 0x79, 0x2f, 0x75, 0x6d, 0x62, 0x72, 0x61, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x2d, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_alpha_tools_proto_rawDescOnce sync.Once
+	file_alpha_tools_proto_rawDescData = file_alpha_tools_proto_rawDesc
+)
+
+func file_alpha_tools_proto_rawDescGZIP() []byte {
+	file_alpha_tools_proto_rawDescOnce.Do(func() {
+		file_alpha_tools_proto_rawDescData = protoimpl.X.CompressGZIP(file_alpha_tools_proto_rawDescData)
+	})
+	return file_alpha_tools_proto_rawDescData
+}
+
+var file_alpha_tools_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_alpha_tools_proto_goTypes = []interface{}{
+	(*BazelTarget)(nil),           // 0: umbra.alphatools.BazelTarget
+	(*BazelQueryResult)(nil),      // 1: umbra.alphatools.BazelQueryResult
+	(*DepEdge)(nil),               // 2: umbra.alphatools.DepEdge
+	(*PackageMetrics)(nil),        // 3: umbra.alphatools.PackageMetrics
+	(*DependencySnapshot)(nil),    // 4: umbra.alphatools.DependencySnapshot
+	(*APIDiff)(nil),               // 5: umbra.alphatools.APIDiff
+	(*MigrationResult)(nil),       // 6: umbra.alphatools.MigrationResult
+	(*GraphStats)(nil),            // 7: umbra.alphatools.GraphStats
+	(*MigrationReport)(nil),       // 8: umbra.alphatools.MigrationReport
+	nil,                           // 9: umbra.alphatools.DependencySnapshot.MetricsEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_alpha_tools_proto_depIdxs = []int32{
+	0,  // 0: umbra.alphatools.BazelQueryResult.target:type_name -> umbra.alphatools.BazelTarget
+	10, // 1: umbra.alphatools.DependencySnapshot.captured_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: umbra.alphatools.DependencySnapshot.edges:type_name -> umbra.alphatools.DepEdge
+	9,  // 3: umbra.alphatools.DependencySnapshot.metrics:type_name -> umbra.alphatools.DependencySnapshot.MetricsEntry
+	10, // 4: umbra.alphatools.MigrationResult.completed_at:type_name -> google.protobuf.Timestamp
+	5,  // 5: umbra.alphatools.MigrationResult.api_changes:type_name -> umbra.alphatools.APIDiff
+	10, // 6: umbra.alphatools.MigrationReport.generated_at:type_name -> google.protobuf.Timestamp
+	6,  // 7: umbra.alphatools.MigrationReport.module_results:type_name -> umbra.alphatools.MigrationResult
+	7,  // 8: umbra.alphatools.MigrationReport.graph_stats:type_name -> umbra.alphatools.GraphStats
+	3,  // 9: umbra.alphatools.DependencySnapshot.MetricsEntry.value:type_name -> umbra.alphatools.PackageMetrics
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_alpha_tools_proto_init() }
+func file_alpha_tools_proto_init() {
+	if File_alpha_tools_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_alpha_tools_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BazelTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alpha_tools_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BazelQueryResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alpha_tools_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DepEdge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alpha_tools_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PackageMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alpha_tools_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DependencySnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alpha_tools_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APIDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alpha_tools_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MigrationResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alpha_tools_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alpha_tools_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MigrationReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_alpha_tools_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_alpha_tools_proto_goTypes,
+		DependencyIndexes: file_alpha_tools_proto_depIdxs,
+		MessageInfos:      file_alpha_tools_proto_msgTypes,
+	}.Build()
+	File_alpha_tools_proto = out.File
+	file_alpha_tools_proto_rawDesc = nil
+	file_alpha_tools_proto_goTypes = nil
+	file_alpha_tools_proto_depIdxs = nil
+}
@@ -0,0 +1,6 @@
+// Package alphapb holds the Go types of the messages in alpha-tools/proto/alpha_tools.proto, which the tools
+// exchange between pipeline stages. alpha_tools.pb.go is generated and committed, so building the tools does
+// not need protoc; run go generate after changing the schema.
+package alphapb
+
+//go:generate protoc --proto_path=../../../proto --go_out=. --go_opt=paths=source_relative alpha_tools.proto
@@ -0,0 +1,128 @@
+// Package analyzerclient provides a JSON-RPC 2.0 client for a dependency_analyzer
+// started with --serve, so editor and IDE plugins can query it without shelling out.
+package analyzerclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// RPCError is an error returned by the server
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
+
+// request represents a JSON-RPC 2.0 request
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+	ID      int64       `json:"id"`
+}
+
+// response represents a JSON-RPC 2.0 response
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+	ID      int64           `json:"id"`
+}
+
+// AnalyzerClient talks to a dependency_analyzer JSON-RPC server
+type AnalyzerClient struct {
+	Endpoint   string
+	HTTPClient *http.Client
+	nextID     int64
+}
+
+// NewAnalyzerClient creates a client for the server at endpoint (e.g., http://localhost:9876/)
+func NewAnalyzerClient(endpoint string) *AnalyzerClient {
+	return &AnalyzerClient{
+		Endpoint:   endpoint,
+		HTTPClient: &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+// Call invokes a JSON-RPC method and decodes its result into result
+func (c *AnalyzerClient) Call(method string, params Params, result interface{}) error {
+	req := request{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      atomic.AddInt64(&c.nextID, 1),
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("error encoding request: %v", err)
+	}
+
+	httpResp, err := c.HTTPClient.Post(c.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error calling %s: %v", method, err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("error decoding %s result: %v", method, err)
+		}
+	}
+
+	return nil
+}
+
+// Analyze runs a full dependency analysis on the server
+func (c *AnalyzerClient) Analyze(packages string) (*AnalysisResult, error) {
+	var result AnalysisResult
+	if err := c.Call("analyze", Params{Packages: packages}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetImpact returns every package that directly or transitively depends on pkg
+func (c *AnalyzerClient) GetImpact(pkg string) ([]string, error) {
+	var result ImpactResult
+	if err := c.Call("getImpact", Params{Package: pkg}, &result); err != nil {
+		return nil, err
+	}
+	return result.Impacted, nil
+}
+
+// FindPath returns the shortest dependency path from one package to another
+func (c *AnalyzerClient) FindPath(from, to string) ([]string, error) {
+	var result PathResult
+	if err := c.Call("findPath", Params{From: from, To: to}, &result); err != nil {
+		return nil, err
+	}
+	return result.Path, nil
+}
+
+// Snapshot captures the current dependency graph on the server
+func (c *AnalyzerClient) Snapshot() (*DependencySnapshot, error) {
+	var result DependencySnapshot
+	if err := c.Call("snapshot", Params{}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,82 @@
+package analyzerclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DepEdge represents a dependency from one package to another
+type DepEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Valid  bool   `json:"valid"`
+}
+
+// AnalysisResult represents the outcome of a dependency analysis run
+type AnalysisResult struct {
+	Packages     []string  `json:"packages"`
+	Edges        []DepEdge `json:"edges"`
+	InvalidCount int       `json:"invalidCount"`
+}
+
+// PackageMetrics holds the coupling, stability and size metrics of a package. Snapshots only record the
+// metrics derived from the dependency graph; the others are filled in by the analyzer's ComputeAllMetrics.
+type PackageMetrics struct {
+	PackageName  string  `json:"packageName,omitempty"`
+	Afferent     int     `json:"afferent"`               // Martin's Ca: packages depending on this package
+	Efferent     int     `json:"efferent"`               // Martin's Ce: packages this package depends on
+	Instability  float64 `json:"instability"`            // Ce / (Ca + Ce)
+	Abstractness float64 `json:"abstractness,omitempty"` // Protocols / all type declarations
+	Distance     float64 `json:"distance,omitempty"`     // |Abstractness + Instability - 1|, distance from the main sequence
+	FanIn        int     `json:"fanIn"`                  // Packages depending on this package
+	FanOut       int     `json:"fanOut"`                 // Packages this package depends on
+	FileCount    int     `json:"fileCount,omitempty"`    // Swift files in the package
+	LOC          int     `json:"loc,omitempty"`          // Lines in the package's Swift files
+	Grade        string  `json:"grade,omitempty"`        // Health grade with the default scoring weights
+}
+
+// String formats the metrics as one table row
+func (m PackageMetrics) String() string {
+	return fmt.Sprintf("%-25s Ca=%-3d Ce=%-3d I=%.2f A=%.2f D=%.2f files=%-4d loc=%-6d grade=%s",
+		m.PackageName, m.Afferent, m.Efferent, m.Instability, m.Abstractness, m.Distance, m.FileCount, m.LOC, m.Grade)
+}
+
+// JSON encodes the metrics as indented JSON
+func (m PackageMetrics) JSON() []byte {
+	content, _ := json.MarshalIndent(m, "", "  ") // Cannot fail: the struct only has plain fields
+	return content
+}
+
+// DependencySnapshot captures the state of the package dependency graph at a point in time.
+// The analyzer's snapshot type is defined from this one, so the fields cannot drift apart.
+type DependencySnapshot struct {
+	Version       string                    `json:"version"`
+	CapturedAt    time.Time                 `json:"capturedAt"`
+	WorkspaceRoot string                    `json:"workspaceRoot"`
+	Packages      []string                  `json:"packages"`
+	Edges         []DepEdge                 `json:"edges"`
+	Metrics       map[string]PackageMetrics `json:"metrics"`
+}
+
+// Params holds the parameters accepted by the analyzer methods.
+// Paths are relative to the server's workspace root.
+type Params struct {
+	Packages string `json:"packages,omitempty"`
+	Package  string `json:"package,omitempty"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+}
+
+// ImpactResult is returned by the getImpact method
+type ImpactResult struct {
+	Package  string   `json:"package"`
+	Impacted []string `json:"impacted"`
+}
+
+// PathResult is returned by the findPath method
+type PathResult struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Path []string `json:"path"`
+}
@@ -0,0 +1,244 @@
+// Package deprules defines the Alpha Dot Five dependency rules between top-level packages, so both tools
+// check dependencies against one list.
+package deprules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidDependency represents a valid dependency between packages.
+// Pattern entries match package names using path.Match glob semantics (e.g., "*Impl" -> "*Interfaces").
+type ValidDependency struct {
+	Source         string `yaml:"source,omitempty" json:"source,omitempty"`
+	Target         string `yaml:"target,omitempty" json:"target,omitempty"`
+	SourcePattern  string `yaml:"sourcePattern,omitempty" json:"sourcePattern,omitempty"`
+	TargetPattern  string `yaml:"targetPattern,omitempty" json:"targetPattern,omitempty"`
+	IsPatternEntry bool   `yaml:"-" json:"-"`
+}
+
+// Matches checks if the rule permits a dependency from source to target
+func (d ValidDependency) Matches(source, target string) bool {
+	if !d.IsPatternEntry {
+		return d.Source == source && d.Target == target
+	}
+
+	return PatternMatches(d.SourcePattern, source) && PatternMatches(d.TargetPattern, target)
+}
+
+// Mentions checks if the rule names pkg as its source or target, or matches it with a pattern
+func (d ValidDependency) Mentions(pkg string) bool {
+	if !d.IsPatternEntry {
+		return d.Source == pkg || d.Target == pkg
+	}
+
+	return PatternMatches(d.SourcePattern, pkg) || PatternMatches(d.TargetPattern, pkg)
+}
+
+// validate checks that the rule is either a complete exact entry or a complete pattern entry, and marks
+// pattern entries
+func (d *ValidDependency) validate() error {
+	switch {
+	case d.SourcePattern != "" || d.TargetPattern != "":
+		if d.SourcePattern == "" || d.TargetPattern == "" {
+			return fmt.Errorf("must set both sourcePattern and targetPattern")
+		}
+		if _, err := path.Match(d.SourcePattern, ""); err != nil {
+			return fmt.Errorf("invalid sourcePattern %q: %v", d.SourcePattern, err)
+		}
+		if _, err := path.Match(d.TargetPattern, ""); err != nil {
+			return fmt.Errorf("invalid targetPattern %q: %v", d.TargetPattern, err)
+		}
+		d.IsPatternEntry = true
+	case d.Source == "" || d.Target == "":
+		return fmt.Errorf("must set both source and target")
+	}
+	return nil
+}
+
+// PatternMatches checks if name matches a path.Match glob pattern
+func PatternMatches(pattern, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// ValidDependencyRuleSet is a list of dependency rules, validated when decoded from YAML or JSON
+type ValidDependencyRuleSet []ValidDependency
+
+// Default returns the Alpha Dot Five rules
+func Default() ValidDependencyRuleSet {
+	return ValidDependencyRuleSet{
+		{Source: "UmbraErrorKit", Target: "UmbraCoreTypes"},
+		{Source: "UmbraInterfaces", Target: "UmbraCoreTypes"},
+		{Source: "UmbraInterfaces", Target: "UmbraErrorKit"},
+		{Source: "UmbraUtils", Target: "UmbraCoreTypes"},
+		{Source: "UmbraImplementations", Target: "UmbraInterfaces"},
+		{Source: "UmbraImplementations", Target: "UmbraCoreTypes"},
+		{Source: "UmbraImplementations", Target: "UmbraErrorKit"},
+		{Source: "UmbraImplementations", Target: "UmbraUtils"},
+		{Source: "UmbraFoundationBridge", Target: "UmbraCoreTypes"},
+		{Source: "ResticKit", Target: "UmbraInterfaces"},
+		{Source: "ResticKit", Target: "UmbraCoreTypes"},
+		{Source: "ResticKit", Target: "UmbraUtils"},
+	}
+}
+
+// ruleFile is the layout of a rules file; it matches the rules key of the analyzer config, so one file
+// can serve both tools
+type ruleFile struct {
+	Rules ValidDependencyRuleSet `yaml:"rules"`
+}
+
+// LoadFromFile replaces the rules with those in the rules list of a YAML file
+func (s *ValidDependencyRuleSet) LoadFromFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading rules: %v", err)
+	}
+
+	var file ruleFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("error parsing rules %s: %v", path, err)
+	}
+	if len(file.Rules) == 0 {
+		return fmt.Errorf("no rules found in %s", path)
+	}
+
+	*s = file.Rules
+	return nil
+}
+
+// Add adds an exact rule unless the set already has it
+func (s *ValidDependencyRuleSet) Add(source, target string) {
+	for _, dep := range *s {
+		if !dep.IsPatternEntry && dep.Source == source && dep.Target == target {
+			return
+		}
+	}
+	*s = append(*s, ValidDependency{Source: source, Target: target})
+}
+
+// Contains checks if an exact or pattern rule permits a dependency from source to target
+func (s ValidDependencyRuleSet) Contains(source, target string) bool {
+	for _, dep := range s {
+		if dep.Matches(source, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// TargetsOf returns the targets of the exact rules for source, in rule order
+func (s ValidDependencyRuleSet) TargetsOf(source string) []string {
+	targets := []string{}
+	for _, dep := range s {
+		if !dep.IsPatternEntry && dep.Source == source {
+			targets = append(targets, dep.Target)
+		}
+	}
+	return targets
+}
+
+// IsComplete checks that every known package appears in at least one rule and returns those that do not
+func (s ValidDependencyRuleSet) IsComplete(knownPackages []string) (bool, []string) {
+	missing := []string{}
+	for _, pkg := range knownPackages {
+		covered := false
+		for _, dep := range s {
+			if dep.Mentions(pkg) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			missing = append(missing, pkg)
+		}
+	}
+	return len(missing) == 0, missing
+}
+
+// MarshalYAML encodes the set as a plain list of rules
+func (s ValidDependencyRuleSet) MarshalYAML() (interface{}, error) {
+	return []ValidDependency(s), nil
+}
+
+// UnmarshalYAML decodes a list of rules and validates each one
+func (s *ValidDependencyRuleSet) UnmarshalYAML(value *yaml.Node) error {
+	var rules []ValidDependency
+	if err := value.Decode(&rules); err != nil {
+		return err
+	}
+
+	for i := range rules {
+		if err := rules[i].validate(); err != nil {
+			return fmt.Errorf("rule %d %v", i+1, err)
+		}
+	}
+
+	*s = rules
+	return nil
+}
+
+// UnmarshalJSON decodes a list of rules and validates each one
+func (s *ValidDependencyRuleSet) UnmarshalJSON(data []byte) error {
+	var rules []ValidDependency
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return err
+	}
+
+	for i := range rules {
+		if err := rules[i].validate(); err != nil {
+			return fmt.Errorf("rule %d %v", i+1, err)
+		}
+	}
+
+	*s = rules
+	return nil
+}
+
+// Merge adds the rules the set does not already have, keeping their order
+func (s *ValidDependencyRuleSet) Merge(rules []ValidDependency) {
+	keys := make(map[string]bool, len(*s))
+	for _, dep := range *s {
+		keys[dep.Key()] = true
+	}
+	for _, dep := range rules {
+		if !keys[dep.Key()] {
+			keys[dep.Key()] = true
+			*s = append(*s, dep)
+		}
+	}
+}
+
+// Key identifies a rule independently of how it was loaded, e.g. "UmbraUtils -> UmbraCoreTypes"
+func (d ValidDependency) Key() string {
+	if d.SourcePattern != "" || d.TargetPattern != "" {
+		return "pattern " + d.SourcePattern + " -> " + d.TargetPattern
+	}
+	return d.Source + " -> " + d.Target
+}
+
+// CompareValidDepsLists returns the rules only in a and the rules only in b, each in list order
+func CompareValidDepsLists(a, b []ValidDependency) ([]ValidDependency, []ValidDependency) {
+	return rulesMissingFrom(a, b), rulesMissingFrom(b, a)
+}
+
+// rulesMissingFrom returns the rules of list that other does not have
+func rulesMissingFrom(list, other []ValidDependency) []ValidDependency {
+	keys := make(map[string]bool, len(other))
+	for _, dep := range other {
+		keys[dep.Key()] = true
+	}
+
+	missing := []ValidDependency{}
+	for _, dep := range list {
+		if !keys[dep.Key()] {
+			missing = append(missing, dep)
+		}
+	}
+	return missing
+}
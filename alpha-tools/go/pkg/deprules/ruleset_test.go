@@ -0,0 +1,45 @@
+package deprules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareValidDepsLists(t *testing.T) {
+	errorKit := ValidDependency{Source: "UmbraErrorKit", Target: "UmbraCoreTypes"}
+	interfaces := ValidDependency{Source: "UmbraInterfaces", Target: "UmbraCoreTypes"}
+	implPattern := ValidDependency{SourcePattern: "*Impl", TargetPattern: "*Interfaces", IsPatternEntry: true}
+
+	tests := []struct {
+		name       string
+		a, b       []ValidDependency
+		onlyA      []ValidDependency
+		onlyB      []ValidDependency
+		wantsEqual bool
+	}{
+		{name: "equal", a: []ValidDependency{errorKit, interfaces}, b: []ValidDependency{errorKit, interfaces}, wantsEqual: true},
+		{name: "equal in another order", a: []ValidDependency{errorKit, implPattern}, b: []ValidDependency{implPattern, errorKit}, wantsEqual: true},
+		{name: "divergent", a: []ValidDependency{errorKit, interfaces}, b: []ValidDependency{errorKit, implPattern},
+			onlyA: []ValidDependency{interfaces}, onlyB: []ValidDependency{implPattern}},
+		{name: "one empty", a: []ValidDependency{errorKit}, b: nil, onlyA: []ValidDependency{errorKit}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			onlyA, onlyB := CompareValidDepsLists(test.a, test.b)
+			if equal := len(onlyA) == 0 && len(onlyB) == 0; equal != test.wantsEqual {
+				t.Errorf("lists compare equal: %v, want %v", equal, test.wantsEqual)
+			}
+			if !test.wantsEqual && (!reflect.DeepEqual(onlyA, nonNil(test.onlyA)) || !reflect.DeepEqual(onlyB, nonNil(test.onlyB))) {
+				t.Errorf("got only in a %v and only in b %v, want %v and %v", onlyA, onlyB, test.onlyA, test.onlyB)
+			}
+		})
+	}
+}
+
+// nonNil returns rules, or an empty list if it is nil, as CompareValidDepsLists does
+func nonNil(rules []ValidDependency) []ValidDependency {
+	if rules == nil {
+		return []ValidDependency{}
+	}
+	return rules
+}
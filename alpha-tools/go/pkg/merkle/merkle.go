@@ -0,0 +1,63 @@
+// Package merkle computes SHA-256 Merkle tree hashes over ordered records, so an audit log can be checked
+// for changed, removed, added or reordered records by comparing a single root hash.
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Domain separation prefixes, as in RFC 6962, so a leaf can never be mistaken for an interior node
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// LeafHash returns the hash of one record
+func LeafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// nodeHash returns the hash of an interior node from the hashes of its children
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// Root returns the root hash of the tree whose leaves are the given records, in order. A node without a
+// sibling is promoted to the next level unchanged. The root of no records is the hash of empty input.
+func Root(records [][]byte) []byte {
+	if len(records) == 0 {
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	}
+
+	level := make([][]byte, len(records))
+	for i, record := range records {
+		level[i] = LeafHash(record)
+	}
+
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, nodeHash(level[i], level[i+1]))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// RootHex returns Root as a hex string
+func RootHex(records [][]byte) string {
+	return hex.EncodeToString(Root(records))
+}
@@ -0,0 +1,91 @@
+// Package notify posts migration and dependency analysis outcomes to team chat, so CI pipelines can
+// report completed migration waves and new dependency violations.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// SlackPayload is the message posted to a Slack incoming webhook
+type SlackPayload struct {
+	Text   string       `json:"text"` // Fallback text for notifications
+	Blocks []SlackBlock `json:"blocks,omitempty"`
+}
+
+// SlackBlock is a Block Kit section with Markdown text
+type SlackBlock struct {
+	Type string    `json:"type"`
+	Text SlackText `json:"text"`
+}
+
+// SlackText is the text object of a Block Kit section
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// NewSlackPayload creates a payload with a summary line and Markdown detail sections
+func NewSlackPayload(summary string, sections ...string) SlackPayload {
+	payload := SlackPayload{Text: summary}
+	for _, section := range append([]string{summary}, sections...) {
+		payload.Blocks = append(payload.Blocks, SlackBlock{
+			Type: "section",
+			Text: SlackText{Type: "mrkdwn", Text: section},
+		})
+	}
+	return payload
+}
+
+// WithReportLink adds a link to an HTML report to the payload; an empty URL leaves it unchanged
+func (p SlackPayload) WithReportLink(reportURL string) SlackPayload {
+	if reportURL == "" {
+		return p
+	}
+	link := fmt.Sprintf("<%s|View the HTML report>", reportURL)
+	p.Blocks = append(p.Blocks, SlackBlock{
+		Type: "section",
+		Text: SlackText{Type: "mrkdwn", Text: link},
+	})
+	return p
+}
+
+// SlackNotifier posts payloads to Slack incoming webhooks
+type SlackNotifier struct {
+	Client *http.Client
+}
+
+// NewSlackNotifier creates a notifier with a request timeout suitable for CI
+func NewSlackNotifier() *SlackNotifier {
+	return &SlackNotifier{Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify posts a payload to a Slack incoming webhook URL
+func (n *SlackNotifier) Notify(webhookURL string, payload SlackPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error encoding Slack payload: %v", err)
+	}
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error posting to Slack: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	return nil
+}
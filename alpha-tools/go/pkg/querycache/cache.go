@@ -0,0 +1,223 @@
+// Package querycache caches Bazel query output on disk, so repeated analyses of an unchanged workspace
+// do not re-run bazelisk for every query.
+package querycache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Executor runs bazelisk with args in workspaceRoot and returns its standard output
+type Executor func(workspaceRoot string, args ...string) ([]byte, error)
+
+// killWaitDelay is how long a killed query's output is waited for, in case a child of bazelisk still holds it
+const killWaitDelay = 5 * time.Second
+
+// ErrQueryTimeout is returned for a query that ran past its deadline
+var ErrQueryTimeout = errors.New("bazel query timed out")
+
+// BazelBinary is the binary queries run with, e.g. from a -bazel-binary flag. If it is empty, bazelisk is used, or
+// bazel if bazelisk is not on the PATH.
+var BazelBinary string
+
+// lookPath finds a binary on the PATH; the tests replace it
+var lookPath = exec.LookPath
+
+// resolveBazelBinary returns the binary to run queries with: BazelBinary if it is set, otherwise bazelisk or
+// bazel, whichever is found first on the PATH
+func resolveBazelBinary() (string, error) {
+	if BazelBinary != "" {
+		return BazelBinary, nil
+	}
+	for _, binary := range []string{"bazelisk", "bazel"} {
+		if _, err := lookPath(binary); err == nil {
+			return binary, nil
+		}
+	}
+	return "", fmt.Errorf("neither bazelisk nor bazel was found in PATH (set -bazel-binary to use another binary)")
+}
+
+// BazeliskExecutor runs queries with bazelisk, or with bazel if bazelisk is not on the PATH
+func BazeliskExecutor(workspaceRoot string, args ...string) ([]byte, error) {
+	return BazeliskExecutorWithTimeout(0)(workspaceRoot, args...)
+}
+
+// BazeliskExecutorWithTimeout returns an Executor like BazeliskExecutor that kills a query still running after
+// timeout and returns ErrQueryTimeout. A zero timeout lets queries run as long as they need.
+func BazeliskExecutorWithTimeout(timeout time.Duration) Executor {
+	return func(workspaceRoot string, args ...string) ([]byte, error) {
+		ctx := context.Background()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		binary, err := resolveBazelBinary()
+		if err != nil {
+			return nil, err
+		}
+		cmd := exec.CommandContext(ctx, binary, args...)
+		cmd.Dir = workspaceRoot
+		cmd.WaitDelay = killWaitDelay
+
+		output, err := cmd.Output()
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%w after %s: %s %s", ErrQueryTimeout, timeout, binary, strings.Join(args, " "))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error running bazel query: %v: %v", err, string(output))
+		}
+		return output, nil
+	}
+}
+
+// cacheEntry is the JSON file stored for each cached query
+type cacheEntry struct {
+	WorkspaceRoot string   `json:"workspaceRoot"`
+	Args          []string `json:"args"`
+	Query         string   `json:"query"` // The query expression, i.e. the last argument
+	Output        string   `json:"output"`
+	BinaryOutput  []byte   `json:"binaryOutput,omitempty"` // Set instead of Output for non-UTF-8 output, e.g. --output=proto
+}
+
+// BazelQueryCache runs Bazel queries through an Executor and keeps their output in Dir for TTL.
+// A zero TTL disables caching.
+type BazelQueryCache struct {
+	Dir     string
+	TTL     time.Duration
+	Execute Executor
+}
+
+// DefaultDir returns ~/.cache/umbra/queries
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error locating home directory: %v", err)
+	}
+	return filepath.Join(home, ".cache", "umbra", "queries"), nil
+}
+
+// New creates a cache in dir that runs queries with bazelisk; an empty dir uses DefaultDir
+func New(dir string, ttl time.Duration) (*BazelQueryCache, error) {
+	if dir == "" {
+		var err error
+		if dir, err = DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return &BazelQueryCache{Dir: dir, TTL: ttl, Execute: BazeliskExecutor}, nil
+}
+
+// Query returns the output of bazelisk args in workspaceRoot, from the cache if a fresh entry exists
+func (c *BazelQueryCache) Query(workspaceRoot string, args ...string) ([]byte, error) {
+	execute := c.Execute
+	if execute == nil {
+		execute = BazeliskExecutor
+	}
+	if c.TTL <= 0 {
+		return execute(workspaceRoot, args...)
+	}
+
+	path := c.entryPath(workspaceRoot, args)
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < c.TTL {
+		if entry, err := readEntry(path); err == nil {
+			if entry.BinaryOutput != nil {
+				return entry.BinaryOutput, nil
+			}
+			return []byte(entry.Output), nil
+		}
+	}
+
+	output, err := execute(workspaceRoot, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// A cache that cannot be written only costs time, so the query still succeeds
+	entry := cacheEntry{WorkspaceRoot: workspaceRoot, Args: args}
+	if utf8.Valid(output) {
+		entry.Output = string(output)
+	} else {
+		entry.BinaryOutput = output
+	}
+	if len(args) > 0 {
+		entry.Query = args[len(args)-1]
+	}
+	_ = writeEntry(path, entry)
+
+	return output, nil
+}
+
+// Invalidate removes the cached entries whose query expression starts with queryPrefix
+func (c *BazelQueryCache) Invalidate(queryPrefix string) error {
+	paths, err := filepath.Glob(filepath.Join(c.Dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("error listing cache entries: %v", err)
+	}
+
+	for _, path := range paths {
+		entry, err := readEntry(path)
+		if err != nil || strings.HasPrefix(entry.Query, queryPrefix) {
+			// Unreadable entries are removed as well
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("error removing cache entry: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// entryPath returns the file a query is cached in, named by the SHA-256 of the workspace and arguments
+func (c *BazelQueryCache) entryPath(workspaceRoot string, args []string) string {
+	sum := sha256.Sum256([]byte(workspaceRoot + "\x00" + strings.Join(args, "\x00")))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// readEntry reads a cache entry file
+func readEntry(path string) (cacheEntry, error) {
+	var entry cacheEntry
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(content, &entry)
+	return entry, err
+}
+
+// writeEntry atomically writes a cache entry file
+func writeEntry(path string, entry cacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".query-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,179 @@
+package querycache
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingCache returns a cache in a temporary directory whose executor counts its runs per query and
+// returns output for every query
+func countingCache(t *testing.T, ttl time.Duration, output []byte) (*BazelQueryCache, map[string]int) {
+	runs := make(map[string]int)
+	cache := &BazelQueryCache{
+		Dir: t.TempDir(),
+		TTL: ttl,
+		Execute: func(workspaceRoot string, args ...string) ([]byte, error) {
+			runs[args[len(args)-1]]++
+			return output, nil
+		},
+	}
+	return cache, runs
+}
+
+func TestQueryHitAndMiss(t *testing.T) {
+	cache, runs := countingCache(t, time.Hour, []byte("//packages/UmbraCoreTypes:UmbraCoreTypes\n"))
+
+	for i := 0; i < 2; i++ {
+		output, err := cache.Query("/workspace", "query", "deps(//packages/UmbraCoreTypes:*)")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(output) != "//packages/UmbraCoreTypes:UmbraCoreTypes\n" {
+			t.Errorf("query %d returned %q", i+1, output)
+		}
+	}
+	if runs["deps(//packages/UmbraCoreTypes:*)"] != 1 {
+		t.Errorf("executed %d times, want a miss then a hit", runs["deps(//packages/UmbraCoreTypes:*)"])
+	}
+
+	// The same query in another workspace is a different entry
+	if _, err := cache.Query("/other", "query", "deps(//packages/UmbraCoreTypes:*)"); err != nil {
+		t.Fatal(err)
+	}
+	if runs["deps(//packages/UmbraCoreTypes:*)"] != 2 {
+		t.Errorf("executed %d times, want another miss for a different workspace", runs["deps(//packages/UmbraCoreTypes:*)"])
+	}
+}
+
+func TestQueryExpiresAfterTTL(t *testing.T) {
+	cache, runs := countingCache(t, time.Minute, []byte("output"))
+	args := []string{"query", "deps(//packages/UmbraErrorKit:*)"}
+
+	if _, err := cache.Query("/workspace", args...); err != nil {
+		t.Fatal(err)
+	}
+	// Age the entry past the TTL instead of waiting for it
+	old := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(cache.entryPath("/workspace", args), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Query("/workspace", args...); err != nil {
+		t.Fatal(err)
+	}
+	if runs[args[1]] != 2 {
+		t.Errorf("executed %d times, want the expired entry to be queried again", runs[args[1]])
+	}
+}
+
+func TestQueryZeroTTLBypassesCache(t *testing.T) {
+	cache, runs := countingCache(t, 0, []byte("output"))
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Query("/workspace", "query", "deps(//packages/UmbraInterfaces:*)"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if runs["deps(//packages/UmbraInterfaces:*)"] != 2 {
+		t.Errorf("executed %d times, want every query to run with a zero TTL", runs["deps(//packages/UmbraInterfaces:*)"])
+	}
+	if entries, _ := filepath.Glob(filepath.Join(cache.Dir, "*.json")); len(entries) != 0 {
+		t.Errorf("wrote %d cache entries with a zero TTL", len(entries))
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	cache, runs := countingCache(t, time.Hour, []byte("output"))
+	queries := []string{"deps(//packages/UmbraCoreTypes:*)", "deps(//packages/UmbraCoreTypes/CoreDTOs:*)", "deps(//packages/UmbraErrorKit:*)"}
+	for _, query := range queries {
+		if _, err := cache.Query("/workspace", "query", query); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := cache.Invalidate("deps(//packages/UmbraCoreTypes"); err != nil {
+		t.Fatal(err)
+	}
+	for _, query := range queries {
+		if _, err := cache.Query("/workspace", "query", query); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := map[string]int{queries[0]: 2, queries[1]: 2, queries[2]: 1}
+	for query, count := range expected {
+		if runs[query] != count {
+			t.Errorf("%s executed %d times, want %d", query, runs[query], count)
+		}
+	}
+}
+
+func TestQueryBinaryOutput(t *testing.T) {
+	// Proto output is not valid UTF-8 and must come back byte for byte
+	output := []byte{0x0a, 0x8f, 0xff, 0x00, 0xc3, 0x28, 0x12}
+	cache, runs := countingCache(t, time.Hour, output)
+	args := []string{"query", "--output=proto", "deps(//packages/UmbraCoreTypes:*)"}
+
+	for i := 0; i < 2; i++ {
+		result, err := cache.Query("/workspace", args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(result, output) {
+			t.Errorf("query %d returned %x, want %x", i+1, result, output)
+		}
+	}
+	if runs[args[2]] != 1 {
+		t.Errorf("executed %d times, want the second query served from the cache", runs[args[2]])
+	}
+}
+
+func TestResolveBazelBinary(t *testing.T) {
+	tests := []struct {
+		name     string
+		onPath   []string // Binaries the mocked lookPath finds
+		override string   // BazelBinary
+		expected string   // Empty if an error is expected
+	}{
+		{name: "bazelisk first", onPath: []string{"bazel", "bazelisk"}, expected: "bazelisk"},
+		{name: "bazel fallback", onPath: []string{"bazel"}, expected: "bazel"},
+		{name: "neither", onPath: nil},
+		{name: "override", onPath: nil, override: "/opt/bazel/bin/bazel-7", expected: "/opt/bazel/bin/bazel-7"},
+	}
+
+	originalLookPath := lookPath
+	t.Cleanup(func() {
+		lookPath = originalLookPath
+		BazelBinary = ""
+	})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lookPath = func(file string) (string, error) {
+				for _, binary := range test.onPath {
+					if binary == file {
+						return "/usr/bin/" + file, nil
+					}
+				}
+				return "", fmt.Errorf("exec: %q: executable file not found in $PATH", file)
+			}
+			BazelBinary = test.override
+
+			binary, err := resolveBazelBinary()
+			if test.expected == "" {
+				if err == nil || !strings.Contains(err.Error(), "neither bazelisk nor bazel") {
+					t.Fatalf("got %q, %v; want an error naming both binaries", binary, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if binary != test.expected {
+				t.Errorf("got %q, want %q", binary, test.expected)
+			}
+		})
+	}
+}
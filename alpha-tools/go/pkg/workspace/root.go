@@ -0,0 +1,33 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// workspaceMarkers are the files that mark the root of a Bazel workspace
+var workspaceMarkers = []string{"WORKSPACE", "WORKSPACE.bazel", "MODULE.bazel"}
+
+// FindWorkspaceRoot walks up from startDir to the nearest directory containing a WORKSPACE, WORKSPACE.bazel or
+// MODULE.bazel file, stopping at the filesystem root
+func FindWorkspaceRoot(startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("error getting absolute path: %v", err)
+	}
+
+	for {
+		for _, marker := range workspaceMarkers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no Bazel workspace found in %s or any parent directory (looked for WORKSPACE, WORKSPACE.bazel and MODULE.bazel)", startDir)
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,119 @@
+// Package workspace discovers the Swift modules in a source or packages directory, so both tools
+// share one definition of what a module is.
+package workspace
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// targetNamePattern matches the name attribute of a rule in a BUILD file
+var targetNamePattern = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
+
+// ModuleInfo describes a Swift module directory
+type ModuleInfo struct {
+	Name            string
+	Path            string
+	FileCount       int      // Number of Swift files
+	ByteSize        int64    // Total size of the Swift files
+	HasTests        bool     // Whether any Swift file is in a Tests directory or named *Test(s).swift
+	SwiftFiles      []string // Relative to Path, slash-separated
+	DeclaredTargets []string // Rule names in the module's own BUILD file, if it has one
+}
+
+// WorkspaceScanner discovers Swift modules
+type WorkspaceScanner struct{}
+
+// NewWorkspaceScanner creates a workspace scanner
+func NewWorkspaceScanner() *WorkspaceScanner {
+	return &WorkspaceScanner{}
+}
+
+// Scan returns a ModuleInfo for each direct subdirectory of sourceDir that contains Swift files, sorted by name
+func (s *WorkspaceScanner) Scan(sourceDir string) ([]ModuleInfo, error) {
+	entries, err := ioutil.ReadDir(sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading source directory: %v", err)
+	}
+
+	modules := []ModuleInfo{}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		module, err := s.ScanModule(filepath.Join(sourceDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if module.FileCount > 0 {
+			modules = append(modules, module)
+		}
+	}
+
+	return modules, nil
+}
+
+// ScanModule collects the metadata of the module in dir
+func (s *WorkspaceScanner) ScanModule(dir string) (ModuleInfo, error) {
+	module := ModuleInfo{
+		Name:       filepath.Base(dir),
+		Path:       dir,
+		SwiftFiles: []string{},
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".swift") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		module.SwiftFiles = append(module.SwiftFiles, rel)
+		module.FileCount++
+		module.ByteSize += info.Size()
+		if IsTestPath(rel) {
+			module.HasTests = true
+		}
+		return nil
+	})
+	if err != nil {
+		return module, fmt.Errorf("error scanning %s: %v", dir, err)
+	}
+
+	for _, name := range []string{"BUILD.bazel", "BUILD"} {
+		content, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return module, fmt.Errorf("error reading BUILD file: %v", err)
+		}
+		for _, match := range targetNamePattern.FindAllStringSubmatch(string(content), -1) {
+			module.DeclaredTargets = append(module.DeclaredTargets, match[1])
+		}
+		break
+	}
+
+	return module, nil
+}
+
+// IsTestPath checks if a slash-separated Swift file path relative to a module belongs to its tests
+func IsTestPath(rel string) bool {
+	for _, component := range strings.Split(rel, "/") {
+		if strings.Contains(component, "Tests") && !strings.HasSuffix(component, ".swift") {
+			return true
+		}
+	}
+	return strings.HasSuffix(rel, "Test.swift") || strings.HasSuffix(rel, "Tests.swift")
+}